@@ -0,0 +1,78 @@
+package irys
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Ja7ad/irys/types"
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+const (
+	_getApprovals = "%s/account/approvals?%s"
+	_paidByHeader = "x-irys-paid-by"
+)
+
+// tags of approval transactions read by node
+const (
+	ApproveAddressTag   = "x-irys-approve-address"    // ApproveAddressTag is address approved to spend balance of uploader
+	ApproveAmountTag    = "x-irys-approve-amount"     // ApproveAmountTag is amount approved in atomic units of currency
+	ApproveExpiresInTag = "x-irys-approve-expires-in" // ApproveExpiresInTag is lifetime of approval in seconds
+	RevokeApprovalTag   = "x-irys-revoke-approval"    // RevokeApprovalTag is address of revoked approval
+)
+
+func (c *Client) CreateApproval(ctx context.Context, approvedAddress string, amount *big.Int, expiry time.Duration) (types.Transaction, error) {
+	tags := []types.Tag{
+		{Name: ApproveAddressTag, Value: approvedAddress},
+		{Name: ApproveAmountTag, Value: amount.String()},
+	}
+
+	if expiry > 0 {
+		tags = append(tags, types.Tag{Name: ApproveExpiresInTag, Value: strconv.FormatInt(int64(expiry.Seconds()), 10)})
+	}
+
+	c.debugMsg("[CreateApproval] approve %s for address %s", amount.String(), approvedAddress)
+	return c.Upload(ctx, []byte{}, tags...)
+}
+
+func (c *Client) RevokeApproval(ctx context.Context, approvedAddress string) (types.Transaction, error) {
+	c.debugMsg("[RevokeApproval] revoke approval of address %s", approvedAddress)
+	return c.Upload(ctx, []byte{}, types.Tag{Name: RevokeApprovalTag, Value: approvedAddress})
+}
+
+func (c *Client) GetApprovals(ctx context.Context, approvedAddresses ...string) ([]types.Approval, error) {
+	ctx, cancel := withTimeout(ctx, c.timeouts.Query)
+	defer cancel()
+
+	query := url.Values{"payingAddresses": {c.Address()}}
+	if len(approvedAddresses) != 0 {
+		query.Set("approvedAddresses", strings.Join(approvedAddresses, ","))
+	}
+
+	req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(_getApprovals, c.network, query.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+		if err := statusCheck(resp); err != nil {
+			return nil, err
+		}
+		return decodeBody[[]types.Approval](c.codec, c.limitBody(EndpointApproval, resp.Body))
+	}
+}
@@ -0,0 +1,108 @@
+package irys
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ja7ad/irys/currency"
+	"github.com/Ja7ad/irys/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApprovals(t *testing.T) {
+	var (
+		uploaded []types.Tag
+		query    url.Values
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tx/matic":
+			b, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+
+			item := new(types.BundleItem)
+			require.NoError(t, item.Unmarshal(b))
+			uploaded = item.Tags
+			_ = json.NewEncoder(w).Encode(types.Transaction{ID: item.Id.Base64()})
+		case "/account/approvals":
+			query = r.URL.Query()
+			_ = json.NewEncoder(w).Encode([]types.Approval{{Amount: "100", ApprovedAddress: "0xb"}})
+		}
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	c := &Client{client: retryablehttp.NewClient(), network: Node(srv.URL), currency: matic}
+	c.client.RetryMax = 0
+
+	_, err = c.CreateApproval(context.Background(), "0xb", big.NewInt(100), time.Hour)
+	require.NoError(t, err)
+	require.Subset(t, uploaded, []types.Tag{
+		{Name: ApproveAddressTag, Value: "0xb"},
+		{Name: ApproveAmountTag, Value: "100"},
+		{Name: ApproveExpiresInTag, Value: "3600"},
+	})
+
+	_, err = c.RevokeApproval(context.Background(), "0xb")
+	require.NoError(t, err)
+	require.Subset(t, uploaded, []types.Tag{{Name: RevokeApprovalTag, Value: "0xb"}})
+
+	// addresses escaped in query
+	approvals, err := c.GetApprovals(context.Background(), "0xb", "a&b=c")
+	require.NoError(t, err)
+	require.Len(t, approvals, 1)
+	require.Equal(t, c.Address(), query.Get("payingAddresses"))
+	require.Equal(t, "0xb,a&b=c", query.Get("approvedAddresses"))
+	require.NotContains(t, query, "b")
+}
+
+func TestUploadPaidBy(t *testing.T) {
+	var paidBy []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paidBy = append(paidBy, r.Header.Get(_paidByHeader))
+
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		item := new(types.BundleItem)
+		require.NoError(t, item.Unmarshal(b))
+		_ = json.NewEncoder(w).Encode(types.Transaction{ID: item.Id.Base64()})
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	c := &Client{client: retryablehttp.NewClient(), network: Node(srv.URL), currency: matic}
+	c.client.RetryMax = 0
+
+	_, err = c.Upload(context.Background(), []byte("hello"))
+	require.NoError(t, err)
+
+	WithPaidBy("0xa", "0xb")(c)
+	_, err = c.Upload(context.Background(), []byte("hello"))
+	require.NoError(t, err)
+	_, err = c.UploadReader(context.Background(), strings.NewReader("hello"), 5)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"", "0xa,0xb", "0xa,0xb"}, paidBy)
+}
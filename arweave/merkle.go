@@ -0,0 +1,146 @@
+package arweave
+
+import (
+	"crypto/sha256"
+	"math/big"
+)
+
+const (
+	MaxChunkSize = 256 * 1024 // MaxChunkSize is max size of data chunk
+	MinChunkSize = 32 * 1024  // MinChunkSize is min size of data chunk, except last chunk
+
+	_noteSize = 32 // size of offset note in merkle path
+)
+
+// Chunk is data range of transaction chunk
+type Chunk struct {
+	DataHash     []byte
+	MinByteRange int
+	MaxByteRange int
+}
+
+// Proof is merkle path of chunk for data root
+type Proof struct {
+	Offset int
+	Path   []byte
+}
+
+type merkleNode struct {
+	id           []byte
+	dataHash     []byte
+	byteRange    int
+	maxByteRange int
+	left, right  *merkleNode
+}
+
+// GenerateChunks split data to chunks and compute data root and proof of each chunk,
+// same as arweave-js so data root accepted by arweave nodes.
+func GenerateChunks(data []byte) (dataRoot []byte, chunks []Chunk, proofs []Proof) {
+	chunks = chunkData(data)
+
+	leaves := make([]*merkleNode, len(chunks))
+	for i, chunk := range chunks {
+		leaves[i] = &merkleNode{
+			id:           hash(hash(chunk.DataHash), hash(note(chunk.MaxByteRange))),
+			dataHash:     chunk.DataHash,
+			maxByteRange: chunk.MaxByteRange,
+		}
+	}
+
+	root := buildLayers(leaves)
+	proofs = resolveProofs(root, nil)
+
+	// empty last chunk is part of root but not uploaded
+	if last := chunks[len(chunks)-1]; last.MaxByteRange == last.MinByteRange {
+		chunks = chunks[:len(chunks)-1]
+		proofs = proofs[:len(proofs)-1]
+	}
+
+	return root.id, chunks, proofs
+}
+
+// chunkData split data to max size chunks, last two chunks balanced when last chunk is smaller than min size
+func chunkData(data []byte) []Chunk {
+	var (
+		chunks []Chunk
+		cursor int
+	)
+
+	rest := data
+	for len(rest) >= MaxChunkSize {
+		size := MaxChunkSize
+		if next := len(rest) - MaxChunkSize; next > 0 && next < MinChunkSize {
+			size = (len(rest) + 1) / 2
+		}
+
+		chunks = append(chunks, Chunk{
+			DataHash:     hash(rest[:size]),
+			MinByteRange: cursor,
+			MaxByteRange: cursor + size,
+		})
+		cursor += size
+		rest = rest[size:]
+	}
+
+	return append(chunks, Chunk{
+		DataHash:     hash(rest),
+		MinByteRange: cursor,
+		MaxByteRange: cursor + len(rest),
+	})
+}
+
+func buildLayers(nodes []*merkleNode) *merkleNode {
+	for len(nodes) > 1 {
+		next := make([]*merkleNode, 0, (len(nodes)+1)/2)
+		for i := 0; i < len(nodes); i += 2 {
+			if i+1 == len(nodes) {
+				next = append(next, nodes[i])
+				continue
+			}
+
+			left, right := nodes[i], nodes[i+1]
+			next = append(next, &merkleNode{
+				id:           hash(hash(left.id), hash(right.id), hash(note(left.maxByteRange))),
+				byteRange:    left.maxByteRange,
+				maxByteRange: right.maxByteRange,
+				left:         left,
+				right:        right,
+			})
+		}
+		nodes = next
+	}
+	return nodes[0]
+}
+
+func resolveProofs(node *merkleNode, proof []byte) []Proof {
+	if node.left == nil {
+		return []Proof{{
+			Offset: node.maxByteRange - 1,
+			Path:   concat(proof, node.dataHash, note(node.maxByteRange)),
+		}}
+	}
+
+	partial := concat(proof, node.left.id, node.right.id, note(node.byteRange))
+	return append(resolveProofs(node.left, partial), resolveProofs(node.right, partial)...)
+}
+
+// note encode offset as 32 bytes big endian
+func note(n int) []byte {
+	return big.NewInt(int64(n)).FillBytes(make([]byte, _noteSize))
+}
+
+func hash(data ...[]byte) []byte {
+	h := sha256.New()
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+func concat(data ...[]byte) []byte {
+	var out []byte
+	for _, d := range data {
+		out = append(out, d...)
+	}
+	return out
+}
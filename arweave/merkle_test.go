@@ -0,0 +1,79 @@
+package arweave
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// validatePath check merkle path of dest offset against root id, return chunk range
+func validatePath(id []byte, dest, left, right int, path []byte) (int, int, bool) {
+	if right <= 0 {
+		return 0, 0, false
+	}
+	if dest >= right {
+		return validatePath(id, 0, right-1, right, path)
+	}
+
+	if len(path) == 2*_noteSize {
+		if !bytes.Equal(id, hash(hash(path[:32]), hash(path[32:64]))) {
+			return 0, 0, false
+		}
+		return left, right, true
+	}
+
+	if len(path) < 3*_noteSize {
+		return 0, 0, false
+	}
+
+	l, r, offsetNote := path[:32], path[32:64], path[64:96]
+	if !bytes.Equal(id, hash(hash(l), hash(r), hash(offsetNote))) {
+		return 0, 0, false
+	}
+
+	offset := int(new(big.Int).SetBytes(offsetNote).Int64())
+	if dest < offset {
+		if offset < right {
+			right = offset
+		}
+		return validatePath(l, dest, left, right, path[96:])
+	}
+	if offset > left {
+		left = offset
+	}
+	return validatePath(r, dest, left, right, path[96:])
+}
+
+func TestGenerateChunks(t *testing.T) {
+	for _, tc := range []struct {
+		size  int
+		sizes []int
+	}{
+		{size: 1, sizes: []int{1}},
+		{size: MaxChunkSize, sizes: []int{MaxChunkSize}},
+		{size: MaxChunkSize + MinChunkSize, sizes: []int{MaxChunkSize, MinChunkSize}},
+		{size: MaxChunkSize + 10, sizes: []int{MaxChunkSize/2 + 5, MaxChunkSize/2 + 5}},
+		{size: 3*MaxChunkSize + 101, sizes: []int{MaxChunkSize, MaxChunkSize, MaxChunkSize/2 + 51, MaxChunkSize/2 + 50}},
+	} {
+		data := bytes.Repeat([]byte{0xab}, tc.size)
+		for i := range data {
+			data[i] = byte(i)
+		}
+
+		root, chunks, proofs := GenerateChunks(data)
+		require.Len(t, chunks, len(tc.sizes), "size %d", tc.size)
+		require.Len(t, proofs, len(chunks))
+
+		for i, chunk := range chunks {
+			require.Equal(t, tc.sizes[i], chunk.MaxByteRange-chunk.MinByteRange)
+			require.Equal(t, hash(data[chunk.MinByteRange:chunk.MaxByteRange]), chunk.DataHash)
+
+			left, right, ok := validatePath(root, proofs[i].Offset, 0, tc.size, proofs[i].Path)
+			require.True(t, ok, "size %d chunk %d", tc.size, i)
+			require.Equal(t, chunk.MinByteRange, left)
+			require.Equal(t, chunk.MaxByteRange, right)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// Package arweave build and sign arweave L1 (format 2) transactions, used for post data directly
+// to arweave when bundler is not available.
+package arweave
+
+import (
+	"crypto/sha256"
+	"strconv"
+
+	"github.com/Ja7ad/irys/signer"
+	"github.com/Ja7ad/irys/types"
+)
+
+const _format = 2
+
+type Tag struct {
+	Name  types.Base64String `json:"name"`
+	Value types.Base64String `json:"value"`
+}
+
+// Transaction is arweave format 2 transaction, Data is empty when data uploaded by chunks
+type Transaction struct {
+	Format    int                `json:"format"`
+	ID        types.Base64String `json:"id"`
+	LastTx    types.Base64String `json:"last_tx"`
+	Owner     types.Base64String `json:"owner"`
+	Tags      []Tag              `json:"tags"`
+	Target    types.Base64String `json:"target"`
+	Quantity  string             `json:"quantity"`
+	Data      types.Base64String `json:"data"`
+	DataSize  string             `json:"data_size"`
+	DataRoot  types.Base64String `json:"data_root"`
+	Reward    string             `json:"reward"`
+	Signature types.Base64String `json:"signature"`
+
+	chunks []Chunk
+	proofs []Proof
+}
+
+// ChunkUpload is body of arweave /chunk request
+type ChunkUpload struct {
+	DataRoot types.Base64String `json:"data_root"`
+	DataSize string             `json:"data_size"`
+	DataPath types.Base64String `json:"data_path"`
+	Offset   string             `json:"offset"`
+	Chunk    types.Base64String `json:"chunk"`
+}
+
+// NewTransaction create unsigned data transaction with reward in winston and anchor (last_tx)
+func NewTransaction(data []byte, reward string, lastTx []byte, tags ...types.Tag) *Transaction {
+	root, chunks, proofs := GenerateChunks(data)
+
+	tx := &Transaction{
+		Format:   _format,
+		LastTx:   lastTx,
+		Tags:     make([]Tag, len(tags)),
+		Quantity: "0",
+		Data:     data,
+		DataSize: strconv.Itoa(len(data)),
+		Reward:   reward,
+		chunks:   chunks,
+		proofs:   proofs,
+	}
+
+	if len(data) != 0 {
+		tx.DataRoot = root
+	}
+
+	for i, tag := range tags {
+		tx.Tags[i] = Tag{Name: types.Base64String(tag.Name), Value: types.Base64String(tag.Value)}
+	}
+
+	return tx
+}
+
+// Sign set owner, signature and id of transaction
+func (tx *Transaction) Sign(s *signer.ArweaveSigner) error {
+	owner, err := s.GetOwner()
+	if err != nil {
+		return err
+	}
+	tx.Owner = owner
+
+	signature, err := s.Sign(tx.SignatureData())
+	if err != nil {
+		return err
+	}
+
+	id := sha256.Sum256(signature)
+	tx.Signature = signature
+	tx.ID = id[:]
+	return nil
+}
+
+// SignatureData return deep hash of transaction fields signed by owner
+func (tx *Transaction) SignatureData() []byte {
+	tags := make([]any, len(tx.Tags))
+	for i, tag := range tx.Tags {
+		tags[i] = [][]byte{tag.Name, tag.Value}
+	}
+
+	hash := types.DeepHash([]any{
+		strconv.Itoa(tx.Format),
+		tx.Owner,
+		tx.Target,
+		tx.Quantity,
+		tx.Reward,
+		tx.LastTx,
+		tags,
+		tx.DataSize,
+		tx.DataRoot,
+	})
+	return hash[:]
+}
+
+// Chunks return number of data chunks
+func (tx *Transaction) Chunks() int {
+	return len(tx.chunks)
+}
+
+// Chunk return upload request of chunk i with its merkle proof
+func (tx *Transaction) Chunk(i int, data []byte) ChunkUpload {
+	chunk := tx.chunks[i]
+	return ChunkUpload{
+		DataRoot: tx.DataRoot,
+		DataSize: tx.DataSize,
+		DataPath: tx.proofs[i].Path,
+		Offset:   strconv.Itoa(tx.proofs[i].Offset),
+		Chunk:    data[chunk.MinByteRange:chunk.MaxByteRange],
+	}
+}
+
+// WithoutData return copy of transaction without inline data, data uploaded by chunks
+func (tx *Transaction) WithoutData() *Transaction {
+	cp := *tx
+	cp.Data = nil
+	return &cp
+}
@@ -0,0 +1,41 @@
+package arweave
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/json"
+	"testing"
+
+	"github.com/Ja7ad/irys/signer"
+	"github.com/Ja7ad/irys/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionSign(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 4096)
+	require.NoError(t, err)
+	s := &signer.ArweaveSigner{PrivateKey: key, Owner: key.N.Bytes()}
+
+	data := make([]byte, MaxChunkSize+MinChunkSize)
+	tx := NewTransaction(data, "1000", []byte("anchor"), types.Tag{Name: "Content-Type", Value: "text/plain"})
+	require.Equal(t, 2, tx.Chunks())
+	require.NoError(t, tx.Sign(s))
+
+	require.NoError(t, s.Verify(tx.SignatureData(), tx.Signature))
+	id := sha256.Sum256(tx.Signature)
+	require.Equal(t, types.Base64String(id[:]), tx.ID)
+
+	chunk := tx.Chunk(1, data)
+	require.Len(t, chunk.Chunk, MinChunkSize)
+	require.Equal(t, "294911", chunk.Offset)
+
+	b, err := json.Marshal(tx.WithoutData())
+	require.NoError(t, err)
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(b, &body))
+	require.Equal(t, "", body["data"])
+	require.Equal(t, "294912", body["data_size"])
+	require.Equal(t, []any{map[string]any{"name": "Q29udGVudC1UeXBl", "value": "dGV4dC9wbGFpbg"}}, body["tags"])
+}
@@ -0,0 +1,148 @@
+package irys
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/signer"
+	"github.com/Ja7ad/irys/types"
+)
+
+// Tags of attestation added by UploadWithAttestation
+const (
+	AttestationHashTag          = "Attestation-Content-Sha256"
+	AttestationSignatureTypeTag = "Attestation-Signature-Type"
+	AttestationOwnerTag         = "Attestation-Owner"
+	AttestationSignatureTag     = "Attestation-Signature"
+	AttestationClaimTagPrefix   = "Attestation-Claim-"
+)
+
+func (c *Client) UploadWithAttestation(ctx context.Context, file []byte, attestor signer.Signer, claims map[string]string, tags ...types.Tag) (types.Transaction, error) {
+	attestation, err := Attest(file, attestor, claims)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+
+	// copy so caller tags slice not modified by append
+	return c.Upload(ctx, file, append(append([]types.Tag(nil), tags...), attestation...)...)
+}
+
+// Attest sign sha256 hash of data and claims with attestor and return attestation tags
+func Attest(file []byte, attestor signer.Signer, claims map[string]string) ([]types.Tag, error) {
+	hash := sha256.Sum256(file)
+	contentHash := hex.EncodeToString(hash[:])
+
+	message, err := attestationMessage(contentHash, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := attestor.Sign(message)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := attestor.GetOwner()
+	if err != nil {
+		return nil, err
+	}
+
+	tags := []types.Tag{
+		{Name: AttestationHashTag, Value: contentHash},
+		{Name: AttestationSignatureTypeTag, Value: strconv.Itoa(int(attestor.GetType()))},
+		{Name: AttestationOwnerTag, Value: types.Base64String(owner).Base64()},
+		{Name: AttestationSignatureTag, Value: types.Base64String(signature).Base64()},
+	}
+
+	for _, k := range sortedKeys(claims) {
+		tags = append(tags, types.Tag{Name: AttestationClaimTagPrefix + k, Value: claims[k]})
+	}
+
+	return tags, nil
+}
+
+// VerifyAttestation verify attestation tags of data added by UploadWithAttestation
+func VerifyAttestation(file []byte, tags []types.Tag) error {
+	var (
+		contentHash, sigType, owner, signature string
+		claims                                 = make(map[string]string)
+	)
+
+	for _, tag := range tags {
+		switch {
+		case tag.Name == AttestationHashTag:
+			contentHash = tag.Value
+		case tag.Name == AttestationSignatureTypeTag:
+			sigType = tag.Value
+		case tag.Name == AttestationOwnerTag:
+			owner = tag.Value
+		case tag.Name == AttestationSignatureTag:
+			signature = tag.Value
+		case strings.HasPrefix(tag.Name, AttestationClaimTagPrefix):
+			claims[strings.TrimPrefix(tag.Name, AttestationClaimTagPrefix)] = tag.Value
+		}
+	}
+
+	if len(contentHash) == 0 || len(signature) == 0 {
+		return errors.ErrAttestationNotFound
+	}
+
+	hash := sha256.Sum256(file)
+	if hex.EncodeToString(hash[:]) != contentHash {
+		return errors.ErrAttestationHashMismatch
+	}
+
+	t, err := strconv.Atoi(sigType)
+	if err != nil {
+		return err
+	}
+
+	var ownerBytes, sigBytes types.Base64String
+	if err := ownerBytes.Decode(owner); err != nil {
+		return err
+	}
+	if err := sigBytes.Decode(signature); err != nil {
+		return err
+	}
+
+	s, err := signer.GetSigner(signer.SignatureType(t), ownerBytes)
+	if err != nil {
+		return err
+	}
+
+	message, err := attestationMessage(contentHash, claims)
+	if err != nil {
+		return err
+	}
+
+	return s.Verify(message, sigBytes)
+}
+
+// attestationMessage is signed message of attestation, claims encoded as json object with sorted keys so
+// keys and values with any characters (e.g. "=", newline) encoded unambiguously
+func attestationMessage(contentHash string, claims map[string]string) ([]byte, error) {
+	if claims == nil {
+		claims = map[string]string{}
+	}
+
+	encoded, err := json.Marshal(claims)
+	if err != nil {
+		return nil, err
+	}
+	return []byte("irys-attestation\n" + contentHash + "\n" + string(encoded)), nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,46 @@
+package irys
+
+import (
+	"testing"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/signer"
+	"github.com/Ja7ad/irys/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttestation(t *testing.T) {
+	attestor, err := signer.NewEthereumSigner("0xf4a2b939592564feb35ab10a8e04f6f2fe0943579fb3c9c33505298978b74893")
+	require.NoError(t, err)
+
+	data := []byte("notarized document")
+	claims := map[string]string{"Author": "irys-go", "Issued": "2023-10-01"}
+
+	tags, err := Attest(data, attestor, claims)
+	require.NoError(t, err)
+	require.NoError(t, VerifyAttestation(data, tags))
+
+	require.ErrorIs(t, VerifyAttestation([]byte("tampered"), tags), errors.ErrAttestationHashMismatch)
+
+	tags[len(tags)-1].Value = "tampered"
+	require.Error(t, VerifyAttestation(data, tags))
+}
+
+func TestAttestationClaimsEncoding(t *testing.T) {
+	attestor, err := signer.NewEthereumSigner("0xf4a2b939592564feb35ab10a8e04f6f2fe0943579fb3c9c33505298978b74893")
+	require.NoError(t, err)
+
+	data := []byte("notarized document")
+
+	// claims which encoded same by key=value lines
+	tags, err := Attest(data, attestor, map[string]string{"a": "b\nc=d"})
+	require.NoError(t, err)
+	require.NoError(t, VerifyAttestation(data, tags))
+
+	forged := append([]types.Tag(nil), tags[:4]...)
+	forged = append(forged,
+		types.Tag{Name: AttestationClaimTagPrefix + "a", Value: "b"},
+		types.Tag{Name: AttestationClaimTagPrefix + "c", Value: "d"},
+	)
+	require.Error(t, VerifyAttestation(data, forged))
+}
@@ -0,0 +1,66 @@
+package irys
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+)
+
+// PreflightUpload quote upload of size against balance before spend, quote is returned with ErrNotEnoughBalance
+// when balance not cover price and auto funding is disabled, or ErrAutoFundLimitExceeded when shortfall exceed limit.
+func (c *Client) PreflightUpload(ctx context.Context, size int) (types.Quote, error) {
+	price, err := c.GetPrice(ctx, size)
+	if err != nil {
+		return types.Quote{}, err
+	}
+
+	balance, err := c.GetBalance(ctx)
+	if err != nil {
+		return types.Quote{}, err
+	}
+
+	quote := types.Quote{
+		Size:      size,
+		Price:     price,
+		Balance:   balance,
+		Shortfall: new(big.Int),
+	}
+
+	if price.Cmp(balance) <= 0 {
+		return quote, nil
+	}
+
+	quote.Shortfall.Sub(price, balance)
+
+	if c.autoFundLimit == nil {
+		return quote, fmt.Errorf("%w: shortfall %s, balance %s", errors.ErrNotEnoughBalance, quote.Shortfall, balance)
+	}
+
+	if quote.Shortfall.Cmp(c.autoFundLimit) > 0 {
+		return quote, fmt.Errorf("%w: shortfall %s, balance %s, limit %s",
+			errors.ErrAutoFundLimitExceeded, quote.Shortfall, balance, c.autoFundLimit)
+	}
+
+	quote.AutoFund = true
+	return quote, nil
+}
+
+// autoFund report upload retried after top up of shortfall on 402 (WithAutoFundOn402), never in dry run
+// since dry run must not send funding transaction
+func (c *Client) autoFund() bool {
+	return c.autoFundLimit != nil && !c.dryRun
+}
+
+// fundShortfall top up difference of upload price and balance, error if shortfall exceed auto fund limit
+func (c *Client) fundShortfall(ctx context.Context, size int) error {
+	quote, err := c.PreflightUpload(ctx, size)
+	if err != nil || !quote.AutoFund {
+		return err
+	}
+
+	c.debugMsg("[AutoFund] top up shortfall %s", quote.Shortfall.String())
+	return c.TopUpBalance(ctx, quote.Shortfall)
+}
@@ -0,0 +1,90 @@
+package irys
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Ja7ad/irys/errors"
+)
+
+// Endpoint is kind of node endpoint with own response body size limit
+type Endpoint uint8
+
+const (
+	EndpointInfo        Endpoint = iota // EndpointInfo is node info
+	EndpointPrice                       // EndpointPrice is price of upload
+	EndpointBalance                     // EndpointBalance is account balance
+	EndpointTransaction                 // EndpointTransaction is transaction metadata and upload response
+	EndpointGraphql                     // EndpointGraphql is graphql queries (receipts, tag search)
+	EndpointChunk                       // EndpointChunk is chunked upload id
+	EndpointApproval                    // EndpointApproval is balance approvals
+	EndpointManifest                    // EndpointManifest is path manifest download
+)
+
+const _maxErrorBodySize = 64 << 10
+
+var _defaultBodyLimits = map[Endpoint]int64{
+	EndpointInfo:        1 << 20,
+	EndpointPrice:       4 << 10,
+	EndpointBalance:     4 << 10,
+	EndpointTransaction: 1 << 20,
+	EndpointGraphql:     8 << 20,
+	EndpointChunk:       64 << 10,
+	EndpointApproval:    1 << 20,
+	EndpointManifest:    32 << 20,
+}
+
+func (e Endpoint) String() string {
+	switch e {
+	case EndpointInfo:
+		return "info"
+	case EndpointPrice:
+		return "price"
+	case EndpointBalance:
+		return "balance"
+	case EndpointTransaction:
+		return "transaction"
+	case EndpointGraphql:
+		return "graphql"
+	case EndpointChunk:
+		return "chunk"
+	case EndpointApproval:
+		return "approval"
+	case EndpointManifest:
+		return "manifest"
+	}
+	return "unknown"
+}
+
+// limitBody wrap response body with size limit of endpoint, reading more than limit fail with ErrResponseTooLarge
+func (c *Client) limitBody(endpoint Endpoint, body io.Reader) io.Reader {
+	limit, ok := c.bodyLimits[endpoint]
+	if !ok {
+		limit = _defaultBodyLimits[endpoint]
+	}
+	return &limitedReader{r: body, endpoint: endpoint, limit: limit, remaining: limit}
+}
+
+type limitedReader struct {
+	r         io.Reader
+	endpoint  Endpoint
+	limit     int64
+	remaining int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	// read one byte more than remaining to detect body bigger than limit
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+
+	n, err := l.r.Read(p)
+	if int64(n) > l.remaining {
+		n = int(l.remaining)
+		l.remaining = 0
+		return n, fmt.Errorf("%w: %s response exceeds %d bytes", errors.ErrResponseTooLarge, l.endpoint, l.limit)
+	}
+
+	l.remaining -= int64(n)
+	return n, err
+}
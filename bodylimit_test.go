@@ -0,0 +1,27 @@
+package irys
+
+import (
+	"strings"
+	"testing"
+
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimitBody(t *testing.T) {
+	body := `{"balance":"1000000"}`
+
+	c := &Client{}
+	b, err := decodeBody[types.BalanceResponse](c.codec, c.limitBody(EndpointBalance, strings.NewReader(body)))
+	require.NoError(t, err)
+	require.Equal(t, "1000000", b.Balance)
+
+	WithMaxBodySize(EndpointBalance, int64(len(body)))(c)
+	_, err = decodeBody[types.BalanceResponse](c.codec, c.limitBody(EndpointBalance, strings.NewReader(body)))
+	require.NoError(t, err)
+
+	WithMaxBodySize(EndpointBalance, 10)(c)
+	_, err = decodeBody[types.BalanceResponse](c.codec, c.limitBody(EndpointBalance, strings.NewReader(body)))
+	require.ErrorIs(t, err, errs.ErrResponseTooLarge)
+}
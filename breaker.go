@@ -0,0 +1,124 @@
+package irys
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+type breakerState uint8
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// circuitBreaker wrap http transport, after threshold consecutive failures open circuit
+// and fail fast until cooldown passed, then allow one probe request (half-open).
+type circuitBreaker struct {
+	mu        sync.Mutex
+	next      http.RoundTripper
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	state     breakerState
+	openedAt  time.Time
+	probing   bool
+}
+
+func newCircuitBreaker(next http.RoundTripper, threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		next:      next,
+		threshold: threshold,
+		cooldown:  cooldown,
+	}
+}
+
+func (b *circuitBreaker) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := b.allow(); err != nil {
+		return nil, err
+	}
+
+	resp, err := b.next.RoundTrip(req)
+	if req.Context().Err() != nil {
+		// canceled or timed out by caller, not failure of node
+		b.release()
+		return resp, err
+	}
+	b.record(err != nil || (resp != nil && resp.StatusCode >= http.StatusInternalServerError))
+
+	return resp, err
+}
+
+// CloseIdleConnections pass to wrapped transport for Client.Close
+func (b *circuitBreaker) CloseIdleConnections() {
+	type closeIdler interface {
+		CloseIdleConnections()
+	}
+	if tr, ok := b.next.(closeIdler); ok {
+		tr.CloseIdleConnections()
+	}
+}
+
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			return errs.ErrCircuitOpen
+		}
+		b.state = breakerHalfOpen
+		b.probing = true
+		return nil
+	case breakerHalfOpen:
+		if b.probing {
+			return errs.ErrCircuitOpen
+		}
+		b.probing = true
+	}
+
+	return nil
+}
+
+// release end probe of half-open circuit without outcome, so next request probe node
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+func (b *circuitBreaker) record(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+
+	if !failed {
+		b.failures = 0
+		b.state = breakerClosed
+		return
+	}
+
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.threshold {
+		b.state = breakerOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// breakerRetryPolicy stop retrying when circuit is open, so dead node fail fast
+func breakerRetryPolicy(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	if errors.Is(err, errs.ErrCircuitOpen) {
+		return false, err
+	}
+	return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+}
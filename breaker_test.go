@@ -0,0 +1,69 @@
+package irys
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	fail := true
+	calls := 0
+	b := newCircuitBreaker(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		if fail {
+			return nil, errors.New("connection refused")
+		}
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	}), 2, 50*time.Millisecond)
+
+	req, err := http.NewRequest(http.MethodGet, "http://localhost", nil)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err = b.RoundTrip(req)
+		require.Error(t, err)
+	}
+
+	_, err = b.RoundTrip(req)
+	require.ErrorIs(t, err, errs.ErrCircuitOpen)
+	require.Equal(t, 2, calls)
+
+	time.Sleep(60 * time.Millisecond)
+	fail = false
+
+	resp, err := b.RoundTrip(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, breakerClosed, b.state)
+}
+
+func TestCircuitBreakerCanceled(t *testing.T) {
+	b := newCircuitBreaker(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return nil, req.Context().Err()
+	}), 1, time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://localhost", nil)
+	require.NoError(t, err)
+
+	// requests canceled by caller not open circuit
+	for i := 0; i < 3; i++ {
+		_, err = b.RoundTrip(req)
+		require.ErrorIs(t, err, context.Canceled)
+	}
+	require.Equal(t, breakerClosed, b.state)
+	require.Zero(t, b.failures)
+}
@@ -0,0 +1,42 @@
+// Package bundle write and read ANS-104 bundles of signed data items as stream, for offline bundling pipelines
+// https://github.com/ArweaveTeam/arweave-standards/blob/master/ans/ANS-104.md#12-bundle-format
+package bundle
+
+import (
+	"encoding/binary"
+
+	"github.com/Ja7ad/irys/errors"
+)
+
+const (
+	// _wordSize is size of little endian number in bundle header
+	_wordSize = 32
+	// _idSize is size of data item id in bundle header
+	_idSize = 32
+)
+
+// Header is size and id of data item in bundle header
+type Header struct {
+	Size int
+	ID   []byte
+}
+
+func putWord(v int) []byte {
+	buf := make([]byte, _wordSize)
+	binary.LittleEndian.PutUint64(buf, uint64(v))
+	return buf
+}
+
+// parseWord parse 32 bytes little endian number, values larger than max int are rejected
+func parseWord(buf []byte) (int, error) {
+	for _, b := range buf[8:] {
+		if b != 0 {
+			return 0, errors.ErrInvalidBundle
+		}
+	}
+	v := binary.LittleEndian.Uint64(buf[:8])
+	if v > uint64(^uint(0)>>1) {
+		return 0, errors.ErrInvalidBundle
+	}
+	return int(v), nil
+}
@@ -0,0 +1,92 @@
+package bundle
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/signer"
+	"github.com/Ja7ad/irys/types"
+	"github.com/stretchr/testify/require"
+)
+
+func signedItems(t *testing.T) []*types.BundleItem {
+	t.Helper()
+
+	s, err := signer.NewEd25519Signer("c5338cd251c22daa8c9c9cc94f498cc8a5c7e1d2e75287a5dda91096fe64efa5")
+	require.NoError(t, err)
+
+	items := []*types.BundleItem{
+		{Data: []byte("first item"), Tags: types.Tags{{Name: "Content-Type", Value: "text/plain"}}},
+		{Data: []byte("second item"), Anchor: bytes.Repeat([]byte{7}, 32)},
+		{Data: bytes.Repeat([]byte{1}, 4096)},
+	}
+	for _, item := range items {
+		require.NoError(t, item.Sign(s))
+	}
+	return items
+}
+
+func TestWriterReader(t *testing.T) {
+	items := signedItems(t)
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, t.TempDir())
+	require.NoError(t, err)
+	for _, item := range items {
+		require.NoError(t, w.Add(item))
+	}
+	require.Equal(t, len(items), w.Len())
+	require.NoError(t, w.Close())
+	require.ErrorIs(t, w.Add(items[0]), errs.ErrBundleClosed)
+
+	var inMemory bytes.Buffer
+	require.NoError(t, Write(&inMemory, items...))
+	require.Equal(t, inMemory.Bytes(), buf.Bytes())
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, len(items), r.Len())
+	require.Equal(t, w.Headers(), r.Headers())
+
+	for _, want := range items {
+		got, err := r.Next()
+		require.NoError(t, err)
+		require.Equal(t, []byte(want.Id), []byte(got.Id))
+		require.Equal(t, []byte(want.Data), []byte(got.Data))
+		require.Equal(t, len(want.Tags), len(got.Tags))
+		require.NoError(t, got.Verify())
+	}
+
+	_, err = r.Next()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestReaderInvalid(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, signedItems(t)...))
+
+	_, err := ReadAll(bytes.NewReader(buf.Bytes()[:buf.Len()-1]))
+	require.ErrorIs(t, err, errs.ErrInvalidBundle)
+
+	_, err = NewReader(bytes.NewReader(buf.Bytes()[:10]))
+	require.ErrorIs(t, err, errs.ErrInvalidBundle)
+
+	// item count larger than 64 bits
+	header := make([]byte, _wordSize)
+	header[20] = 1
+	_, err = NewReader(bytes.NewReader(header))
+	require.ErrorIs(t, err, errs.ErrInvalidBundle)
+
+	items, err := ReadAll(bytes.NewReader(putWord(0)))
+	require.NoError(t, err)
+	require.Empty(t, items)
+}
+
+func TestWriterUnsigned(t *testing.T) {
+	w, err := NewWriter(io.Discard, t.TempDir())
+	require.NoError(t, err)
+	require.ErrorIs(t, w.Add(&types.BundleItem{Data: []byte("data")}), errs.ErrNotSigned)
+	require.NoError(t, w.Abort())
+}
@@ -0,0 +1,116 @@
+package bundle
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+)
+
+// MaxItemSize limit size of single data item read from bundle, items are read into memory
+var MaxItemSize = 1 << 30
+
+// Reader read data items of bundle in order, header is parsed on NewReader
+type Reader struct {
+	r       io.Reader
+	headers []Header
+	next    int
+}
+
+// NewReader parse bundle header from r
+func NewReader(r io.Reader) (*Reader, error) {
+	word := make([]byte, _wordSize)
+	if _, err := io.ReadFull(r, word); err != nil {
+		return nil, errors.ErrInvalidBundle
+	}
+
+	count, err := parseWord(word)
+	if err != nil {
+		return nil, err
+	}
+
+	// headers are appended while read to not trust count for allocation
+	var headers []Header
+	for i := 0; i < count; i++ {
+		if _, err := io.ReadFull(r, word); err != nil {
+			return nil, errors.ErrInvalidBundle
+		}
+
+		size, err := parseWord(word)
+		if err != nil {
+			return nil, err
+		}
+
+		id := make([]byte, _idSize)
+		if _, err := io.ReadFull(r, id); err != nil {
+			return nil, errors.ErrInvalidBundle
+		}
+
+		headers = append(headers, Header{Size: size, ID: id})
+	}
+
+	return &Reader{
+		r:       r,
+		headers: headers,
+	}, nil
+}
+
+// Len return number of items in bundle
+func (b *Reader) Len() int {
+	return len(b.headers)
+}
+
+// Headers return size and id of items in bundle order
+func (b *Reader) Headers() []Header {
+	return append([]Header{}, b.headers...)
+}
+
+// Next read next data item of bundle, return io.EOF after last item
+func (b *Reader) Next() (*types.BundleItem, error) {
+	if b.next >= len(b.headers) {
+		return nil, io.EOF
+	}
+
+	h := b.headers[b.next]
+	if h.Size > MaxItemSize {
+		return nil, errors.ErrInvalidBundle
+	}
+
+	raw := make([]byte, h.Size)
+	if _, err := io.ReadFull(b.r, raw); err != nil {
+		return nil, errors.ErrInvalidBundle
+	}
+	b.next++
+
+	item := new(types.BundleItem)
+	if err := item.Unmarshal(raw); err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(item.Id, h.ID) {
+		return nil, errors.ErrVerifyIdSignatureMismatch
+	}
+
+	return item, nil
+}
+
+// ReadAll read and return all data items of bundle
+func ReadAll(r io.Reader) ([]*types.BundleItem, error) {
+	br, err := NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*types.BundleItem, 0, br.Len())
+	for {
+		item, err := br.Next()
+		if err == io.EOF {
+			return items, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+}
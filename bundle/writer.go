@@ -0,0 +1,141 @@
+package bundle
+
+import (
+	"io"
+	"os"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+)
+
+// Writer stream signed data items into bundle, bundle header precede items so items are spooled
+// to temporary file and written to destination after header on Close.
+type Writer struct {
+	w       io.Writer
+	spool   *os.File
+	headers []Header
+	closed  bool
+}
+
+// NewWriter create bundle writer to w, items are spooled in tempDir (os.TempDir if empty)
+func NewWriter(w io.Writer, tempDir string) (*Writer, error) {
+	spool, err := os.CreateTemp(tempDir, "irys-bundle-*")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Writer{
+		w:     w,
+		spool: spool,
+	}, nil
+}
+
+// Add append signed data item to bundle
+func (b *Writer) Add(item *types.BundleItem) error {
+	if b.closed {
+		return errors.ErrBundleClosed
+	}
+	if !item.IsSigned() {
+		return errors.ErrNotSigned
+	}
+
+	raw, err := item.Marshal()
+	if err != nil {
+		return err
+	}
+
+	if _, err := b.spool.Write(raw); err != nil {
+		return err
+	}
+
+	b.headers = append(b.headers, Header{Size: len(raw), ID: append([]byte{}, item.Id...)})
+	return nil
+}
+
+// Len return number of items added to bundle
+func (b *Writer) Len() int {
+	return len(b.headers)
+}
+
+// Headers return size and id of added items in bundle order
+func (b *Writer) Headers() []Header {
+	return append([]Header{}, b.headers...)
+}
+
+// Close write bundle header and spooled items to destination and remove spool file,
+// destination writer is not closed.
+func (b *Writer) Close() error {
+	if b.closed {
+		return errors.ErrBundleClosed
+	}
+	b.closed = true
+	defer os.Remove(b.spool.Name())
+	defer b.spool.Close()
+
+	if _, err := b.w.Write(putWord(len(b.headers))); err != nil {
+		return err
+	}
+
+	for _, h := range b.headers {
+		if _, err := b.w.Write(putWord(h.Size)); err != nil {
+			return err
+		}
+		if _, err := b.w.Write(h.ID); err != nil {
+			return err
+		}
+	}
+
+	if _, err := b.spool.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	_, err := io.Copy(b.w, b.spool)
+	return err
+}
+
+// Abort discard added items and remove spool file without write bundle
+func (b *Writer) Abort() error {
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+	defer os.Remove(b.spool.Name())
+	return b.spool.Close()
+}
+
+// Write write bundle of items to w in memory, use Writer for large bundles
+func Write(w io.Writer, items ...*types.BundleItem) error {
+	raws := make([][]byte, len(items))
+	for i, item := range items {
+		if !item.IsSigned() {
+			return errors.ErrNotSigned
+		}
+
+		raw, err := item.Marshal()
+		if err != nil {
+			return err
+		}
+		raws[i] = raw
+	}
+
+	if _, err := w.Write(putWord(len(items))); err != nil {
+		return err
+	}
+
+	for i, item := range items {
+		if _, err := w.Write(putWord(len(raws[i]))); err != nil {
+			return err
+		}
+		if _, err := w.Write(item.Id); err != nil {
+			return err
+		}
+	}
+
+	for _, raw := range raws {
+		if _, err := w.Write(raw); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
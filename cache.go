@@ -0,0 +1,286 @@
+package irys
+
+import (
+	"bytes"
+	"container/list"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Ja7ad/irys/types"
+)
+
+const (
+	_cacheMetaExt = ".meta"
+	_cacheDataExt = ".data"
+)
+
+// downloadCache store downloaded files by txId, implemented in memory (lru) and on disk
+type downloadCache interface {
+	get(txId string) (*cacheEntry, bool)
+	put(txId string, entry *cacheEntry)
+	maxBytes() int64
+}
+
+type cacheEntry struct {
+	ETag   string      `json:"etag"`
+	Header http.Header `json:"header"`
+	Stored time.Time   `json:"stored,omitempty"`
+	Data   []byte      `json:"-"`
+}
+
+func (e *cacheEntry) file() *types.File {
+	return &types.File{
+		Data:          io.NopCloser(bytes.NewReader(e.Data)),
+		Header:        e.Header.Clone(),
+		ContentLength: int64(len(e.Data)),
+		ContentType:   e.Header.Get("Content-Type"),
+	}
+}
+
+// cachedDownload serve download from cache, when cached entry has etag it revalidated with If-None-Match
+func (c *Client) cachedDownload(req *http.Request, txId string) (*cacheEntry, bool) {
+	if c.downloadCache == nil {
+		return nil, false
+	}
+
+	entry, ok := c.downloadCache.get(txId)
+	if !ok {
+		return nil, false
+	}
+
+	if len(entry.ETag) == 0 {
+		// irys data is immutable, entry without etag served without revalidation
+		return entry, true
+	}
+
+	req.Header.Set("If-None-Match", entry.ETag)
+	return entry, false
+}
+
+// cacheResponse store response body in cache when fit in cache, returned file read from stored bytes
+func (c *Client) cacheResponse(txId string, resp *http.Response) (*types.File, error) {
+	file := &types.File{
+		Data:          resp.Body,
+		Header:        resp.Header,
+		ContentLength: resp.ContentLength,
+		ContentType:   resp.Header.Get("Content-Type"),
+	}
+
+	if c.downloadCache == nil || resp.ContentLength > c.downloadCache.maxBytes() {
+		return file, nil
+	}
+
+	limit := c.downloadCache.maxBytes()
+	b, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+
+	if int64(len(b)) > limit {
+		// unknown length bigger than cache, stream rest of body
+		file.Data = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(b), resp.Body), resp.Body}
+		return file, nil
+	}
+	resp.Body.Close()
+
+	entry := &cacheEntry{ETag: resp.Header.Get("ETag"), Header: resp.Header, Data: b}
+	c.downloadCache.put(txId, entry)
+
+	return entry.file(), nil
+}
+
+// memoryCache is lru cache of downloads limited by total size of data
+type memoryCache struct {
+	mu    sync.Mutex
+	max   int64
+	size  int64
+	order *list.List
+	items map[string]*list.Element
+}
+
+type memoryItem struct {
+	txId  string
+	entry *cacheEntry
+}
+
+func newMemoryCache(maxBytes int64) *memoryCache {
+	return &memoryCache{
+		max:   maxBytes,
+		order: list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+func (m *memoryCache) maxBytes() int64 {
+	return m.max
+}
+
+func (m *memoryCache) get(txId string) (*cacheEntry, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	el, ok := m.items[txId]
+	if !ok {
+		return nil, false
+	}
+	m.order.MoveToFront(el)
+	return el.Value.(*memoryItem).entry, true
+}
+
+func (m *memoryCache) put(txId string, entry *cacheEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.items[txId]; ok {
+		m.size -= int64(len(el.Value.(*memoryItem).entry.Data))
+		m.order.Remove(el)
+		delete(m.items, txId)
+	}
+
+	m.items[txId] = m.order.PushFront(&memoryItem{txId: txId, entry: entry})
+	m.size += int64(len(entry.Data))
+
+	for m.size > m.max {
+		el := m.order.Back()
+		item := el.Value.(*memoryItem)
+		m.order.Remove(el)
+		delete(m.items, item.txId)
+		m.size -= int64(len(item.entry.Data))
+	}
+}
+
+// diskCache store downloads in dir, least recently used files (by modification time) removed over max size
+type diskCache struct {
+	mu  sync.Mutex
+	dir string
+	max int64
+}
+
+func newDiskCache(dir string, maxBytes int64) (*diskCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &diskCache{dir: dir, max: maxBytes}, nil
+}
+
+func (d *diskCache) maxBytes() int64 {
+	return d.max
+}
+
+func (d *diskCache) get(txId string) (*cacheEntry, bool) {
+	if !validCacheKey(txId) {
+		return nil, false
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	meta, err := os.ReadFile(d.path(txId, _cacheMetaExt))
+	if err != nil {
+		return nil, false
+	}
+
+	entry := new(cacheEntry)
+	if err := json.Unmarshal(meta, entry); err != nil {
+		return nil, false
+	}
+
+	if entry.Data, err = os.ReadFile(d.path(txId, _cacheDataExt)); err != nil {
+		return nil, false
+	}
+
+	now := time.Now()
+	_ = os.Chtimes(d.path(txId, _cacheDataExt), now, now)
+
+	return entry, true
+}
+
+func (d *diskCache) put(txId string, entry *cacheEntry) {
+	if !validCacheKey(txId) {
+		return
+	}
+
+	meta, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if err := os.WriteFile(d.path(txId, _cacheDataExt), entry.Data, 0o600); err != nil {
+		return
+	}
+	if err := os.WriteFile(d.path(txId, _cacheMetaExt), meta, 0o600); err != nil {
+		_ = os.Remove(d.path(txId, _cacheDataExt))
+		return
+	}
+
+	d.evict()
+}
+
+func (d *diskCache) evict() {
+	files, err := filepath.Glob(filepath.Join(d.dir, "*"+_cacheDataExt))
+	if err != nil {
+		return
+	}
+
+	type cached struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+
+	var (
+		items []cached
+		total int64
+	)
+	for _, f := range files {
+		info, err := os.Stat(f)
+		if err != nil {
+			continue
+		}
+		items = append(items, cached{path: f, size: info.Size(), modTime: info.ModTime()})
+		total += info.Size()
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].modTime.Before(items[j].modTime)
+	})
+
+	for _, item := range items {
+		if total <= d.max {
+			return
+		}
+		_ = os.Remove(item.path)
+		_ = os.Remove(item.path[:len(item.path)-len(_cacheDataExt)] + _cacheMetaExt)
+		total -= item.size
+	}
+}
+
+func (d *diskCache) path(txId, ext string) string {
+	return filepath.Join(d.dir, txId+ext)
+}
+
+// validCacheKey check txId is safe file name, tx ids are base64url
+func validCacheKey(txId string) bool {
+	if len(txId) == 0 {
+		return false
+	}
+	for _, r := range txId {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,156 @@
+package irys
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCache(t *testing.T) {
+	m := newMemoryCache(10)
+	m.put("a", &cacheEntry{Data: []byte("aaaa")})
+	m.put("b", &cacheEntry{Data: []byte("bbbb")})
+
+	_, ok := m.get("a")
+	require.True(t, ok)
+
+	m.put("c", &cacheEntry{Data: []byte("cccc")})
+
+	_, ok = m.get("b")
+	require.False(t, ok)
+	_, ok = m.get("a")
+	require.True(t, ok)
+	_, ok = m.get("c")
+	require.True(t, ok)
+}
+
+func TestDiskCache(t *testing.T) {
+	d, err := newDiskCache(t.TempDir(), 10)
+	require.NoError(t, err)
+
+	d.put("a", &cacheEntry{ETag: `"a"`, Header: http.Header{"Content-Type": {"text/plain"}}, Data: []byte("aaaaaa")})
+
+	entry, ok := d.get("a")
+	require.True(t, ok)
+	require.Equal(t, `"a"`, entry.ETag)
+	require.Equal(t, "text/plain", entry.Header.Get("Content-Type"))
+	require.Equal(t, []byte("aaaaaa"), entry.Data)
+
+	past := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(d.path("a", _cacheDataExt), past, past))
+
+	d.put("b", &cacheEntry{Data: []byte("bbbbbb")})
+	_, ok = d.get("a")
+	require.False(t, ok)
+
+	d.put("../escape", &cacheEntry{Data: []byte("x")})
+	_, ok = d.get("../escape")
+	require.False(t, ok)
+}
+
+func TestDownloadCache(t *testing.T) {
+	requests, notModified := 0, 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = io.WriteString(w, "hello irys")
+	}))
+	defer srv.Close()
+
+	c := &Client{client: retryablehttp.NewClient(), gateway: srv.URL, downloadCache: newMemoryCache(1 << 10)}
+
+	for i := 0; i < 2; i++ {
+		file, err := c.Download(context.Background(), "tx")
+		require.NoError(t, err)
+
+		b, err := io.ReadAll(file.Data)
+		require.NoError(t, err)
+		require.Equal(t, "hello irys", string(b))
+		require.Equal(t, "text/plain", file.ContentType)
+	}
+
+	require.Equal(t, 2, requests)
+	require.Equal(t, 1, notModified)
+}
+
+func TestDownloadConditional(t *testing.T) {
+	modified := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		since, _ := http.ParseTime(r.Header.Get("If-Modified-Since"))
+		if r.Header.Get("If-None-Match") == `"v1"` || (!since.IsZero() && !modified.After(since)) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = io.WriteString(w, "hello irys")
+	}))
+	defer srv.Close()
+
+	// cached entry not served for conditional download
+	c := &Client{client: retryablehttp.NewClient(), gateway: srv.URL, downloadCache: newMemoryCache(1 << 10)}
+
+	_, err := c.Download(context.Background(), "tx", WithIfNoneMatch(`"v1"`))
+	require.ErrorIs(t, err, errs.ErrNotModified)
+
+	_, err = c.Download(context.Background(), "tx", WithIfModifiedSince(modified))
+	require.ErrorIs(t, err, errs.ErrNotModified)
+
+	file, err := c.Download(context.Background(), "tx", WithIfNoneMatch(`"v0"`))
+	require.NoError(t, err)
+	b, err := io.ReadAll(file.Data)
+	require.NoError(t, err)
+	require.Equal(t, "hello irys", string(b))
+	require.Equal(t, `"v1"`, file.Header.Get("ETag"))
+}
+
+func TestMetadataCache(t *testing.T) {
+	requests := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		if r.URL.Path == "/graphql" {
+			b, _ := io.ReadAll(r.Body)
+			if strings.Contains(string(b), "pending") {
+				_, _ = io.WriteString(w, `{"data":{"transactions":{"edges":[]}}}`)
+				return
+			}
+			_, _ = io.WriteString(w, `{"data":{"transactions":{"edges":[{"node":{"id":"tx","receipt":{"signature":"sig"}}}]}}}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"id":"tx","tags":[{"name":"Content-Type","value":"text/plain"}]}`)
+	}))
+	defer srv.Close()
+
+	c := &Client{client: retryablehttp.NewClient(), network: Node(srv.URL), gateway: srv.URL, metaCache: newMemoryCache(1 << 10)}
+
+	for i := 0; i < 2; i++ {
+		tx, err := c.GetMetaData(context.Background(), "tx")
+		require.NoError(t, err)
+		require.Equal(t, "tx", tx.ID)
+		require.Len(t, tx.Tags, 1)
+
+		receipt, err := c.GetReceipt(context.Background(), "tx")
+		require.NoError(t, err)
+		require.Equal(t, "sig", receipt.Signature)
+
+		_, err = c.GetReceipt(context.Background(), "pending")
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, 1, requests["/tx/tx"])
+	require.Equal(t, 3, requests["/graphql"])
+}
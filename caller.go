@@ -3,12 +3,19 @@ package irys
 import (
 	"bytes"
 	"context"
-	"encoding/json"
+	stderrors "errors"
 	"fmt"
+	"io"
 	"math/big"
 	"net/http"
+	"net/http/httptrace"
 	"strings"
+	"sync/atomic"
+	"time"
 
+	"github.com/Ja7ad/irys/currency"
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/signer"
 	"github.com/Ja7ad/irys/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/hashicorp/go-retryablehttp"
@@ -16,16 +23,32 @@ import (
 
 const (
 	_pricePath       = "%s/price/%s/%v"
+	_priceChunkSize  = 256 * 1024
 	_uploadPath      = "%s/tx/%s"
 	_txPath          = "%s/tx/%s"
 	_downloadPath    = "%s/%s"
-	_sendTxToBalance = "%s/account/balance/matic"
-	_getBalance      = "%s/account/balance/matic?address=%s"
+	_sendTxToBalance = "%s/account/balance/%s"
+	_getBalance      = "%s/account/balance/%s?address=%s"
 	_chunkUpload     = "%s/chunks/%s/%v/%v"
 	_graphql         = "%s/graphql"
 )
 
 func (c *Client) GetPrice(ctx context.Context, fileSize int) (*big.Int, error) {
+	ctx, cancel := withTimeout(ctx, c.timeouts.Price)
+	defer cancel()
+
+	if c.pricing != nil {
+		rate, err := c.priceRate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return rate.price(fileSize), nil
+	}
+	return c.fetchPrice(ctx, fileSize)
+}
+
+// fetchPrice get price of file size from node
+func (c *Client) fetchPrice(ctx context.Context, fileSize int) (*big.Int, error) {
 	url := fmt.Sprintf(_pricePath, c.network, c.currency.GetName(), fileSize)
 	req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -45,13 +68,75 @@ func (c *Client) GetPrice(ctx context.Context, fileSize int) (*big.Int, error) {
 		if err := statusCheck(resp); err != nil {
 			return nil, err
 		}
-		return decodeBody[*big.Int](resp.Body)
+		return decodeBody[*big.Int](c.codec, c.limitBody(EndpointPrice, resp.Body))
 	}
 }
 
+// GetBulkPrice price many items with two price requests, node price is base fee plus
+// linear rate per 256 KiB chunk, so rate is computed from one and two chunks price.
+func (c *Client) GetBulkPrice(ctx context.Context, sizes []int) (types.BulkPrice, error) {
+	rate, err := c.priceRate(ctx)
+	if err != nil {
+		return types.BulkPrice{}, err
+	}
+
+	price := types.BulkPrice{
+		Total: new(big.Int),
+		Items: make([]*big.Int, len(sizes)),
+	}
+
+	for i, size := range sizes {
+		price.Items[i] = rate.price(size)
+		price.Total.Add(price.Total, price.Items[i])
+	}
+
+	return price, nil
+}
+
 func (c *Client) GetBalance(ctx context.Context) (*big.Int, error) {
-	pbKey := c.currency.GetPublicKey()
-	url := fmt.Sprintf(_getBalance, c.network, crypto.PubkeyToAddress(*pbKey).Hex())
+	return c.GetBalanceOf(ctx, c.Address())
+}
+
+// uploaderAddress return address of owner of items signed by upload signer
+func (c *Client) uploaderAddress() (string, error) {
+	s := c.uploadSigner()
+	owner, err := s.GetOwner()
+	if err != nil {
+		return "", err
+	}
+	return signer.OwnerAddress(s.GetType(), owner)
+}
+
+// Address return wallet address of client currency on its chain
+func (c *Client) Address() string {
+	if a, ok := c.currency.(currency.Addresser); ok {
+		return a.GetAddress()
+	}
+
+	s := c.currency.GetSinger()
+	if owner, err := s.GetOwner(); err == nil {
+		if address, err := signer.OwnerAddress(s.GetType(), owner); err == nil {
+			return address
+		}
+	}
+	return crypto.PubkeyToAddress(*c.currency.GetPublicKey()).Hex()
+}
+
+// Currency return name of client currency (e.g. matic)
+func (c *Client) Currency() string {
+	return c.currency.GetName()
+}
+
+// Node return url of node client bound to
+func (c *Client) Node() string {
+	return string(c.network)
+}
+
+func (c *Client) GetBalanceOf(ctx context.Context, address string) (*big.Int, error) {
+	ctx, cancel := withTimeout(ctx, c.timeouts.Balance)
+	defer cancel()
+
+	url := fmt.Sprintf(_getBalance, c.network, c.currency.GetName(), address)
 
 	req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -71,7 +156,7 @@ func (c *Client) GetBalance(ctx context.Context) (*big.Int, error) {
 		if err := statusCheck(resp); err != nil {
 			return nil, err
 		}
-		b, err := decodeBody[types.BalanceResponse](resp.Body)
+		b, err := decodeBody[types.BalanceResponse](c.codec, c.limitBody(EndpointBalance, resp.Body))
 		if err != nil {
 			return nil, err
 		}
@@ -79,15 +164,74 @@ func (c *Client) GetBalance(ctx context.Context) (*big.Int, error) {
 	}
 }
 
+// AccountSummary return balance with unspent credit of canceled uploads. Credit is used first by later uploads
+// of known price (BasicUpload, Plan) and never more than balance, since any upload spend same balance.
+// Automatic withdraw of credit past threshold is out of scope until client has withdraw api (README todo),
+// credit stay in balance and is reported only.
+func (c *Client) AccountSummary(ctx context.Context) (types.AccountSummary, error) {
+	balance, err := c.GetBalance(ctx)
+	if err != nil {
+		return types.AccountSummary{}, err
+	}
+
+	c.mu.Lock()
+	if c.unspentCredit.Cmp(balance) > 0 {
+		c.unspentCredit.Set(balance)
+	}
+	unspent := new(big.Int).Set(c.unspentCredit)
+	c.mu.Unlock()
+
+	return types.AccountSummary{
+		Address:       c.Address(),
+		Balance:       balance,
+		UnspentCredit: unspent,
+	}, nil
+}
+
 func (c *Client) TopUpBalance(ctx context.Context, amount *big.Int) error {
-	urlConfirm := fmt.Sprintf(_sendTxToBalance, c.network)
+	_, err := c.topUp(ctx, amount)
+	return err
+}
+
+// topUp is TopUpBalance which return chain tx hash of funding
+func (c *Client) topUp(ctx context.Context, amount *big.Int) (string, error) {
+	ctx, cancel := withTimeout(ctx, c.timeouts.Fund)
+	defer cancel()
+
+	hash, err := c.topUpBalance(ctx, amount)
+	c.fundingCompleted(ctx, amount, hash, err)
+	return hash, err
+}
+
+func (c *Client) topUpBalance(ctx context.Context, amount *big.Int) (string, error) {
+	urlConfirm := fmt.Sprintf(_sendTxToBalance, c.network, c.currency.GetName())
+
+	if err := c.validateFunding(ctx, amount); err != nil {
+		return "", err
+	}
+
+	release, err := c.reserveFunding(amount)
+	if err != nil {
+		return "", err
+	}
 
 	hash, err := c.createTx(ctx, amount)
 	if err != nil {
-		return err
+		// funding not sent, amount not counted in daily limit
+		release()
+		return "", err
+	}
+
+	// funds sent on chain, hash returned with error so caller can confirm funding later
+	if err := c.confirmFunding(ctx, urlConfirm, hash); err != nil {
+		return hash, fmt.Errorf("confirm funding tx %s: %w", hash, err)
 	}
+	return hash, nil
+}
 
-	b, err := json.Marshal(&types.TxToBalanceRequest{
+// confirmFunding send chain tx hash of funding to node to credit balance
+func (c *Client) confirmFunding(ctx context.Context, urlConfirm, hash string) error {
+	b, err := c.marshal(&types.TxToBalanceRequest{
 		TxId: hash,
 	})
 	if err != nil {
@@ -116,14 +260,59 @@ func (c *Client) TopUpBalance(ctx context.Context, amount *big.Int) error {
 	}
 }
 
-func (c *Client) Download(ctx context.Context, txId string) (*types.File, error) {
-	url := fmt.Sprintf(_downloadPath, _defaultGateway, txId)
+func (c *Client) Download(ctx context.Context, txId string, opts ...DownloadOption) (*types.File, error) {
+	ctx, cancel := withTimeout(ctx, c.timeouts.Download)
+
+	file, err := c.download(ctx, txId, opts...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	file, err = c.applyDownloadHandlers(ctx, txId, file)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// timeout cover read of data, canceled when data closed
+	file.Data = &cancelOnClose{ReadCloser: file.Data, cancel: cancel}
+	return file, nil
+}
+
+// download get raw data of transaction without download handlers
+func (c *Client) download(ctx context.Context, txId string, opts ...DownloadOption) (*types.File, error) {
+	url := fmt.Sprintf(_downloadPath, c.gateway, txId)
+	options := newDownloadOptions(opts...)
 
 	req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
 
+	if c.authValue != nil {
+		value, err := c.authValue()
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set(c.authHeader, value)
+	}
+
+	var (
+		cached *cacheEntry
+		fresh  bool
+	)
+	if options.conditional() {
+		// caller revalidate own copy, so response not served from client cache
+		options.setHeaders(req.Request)
+	} else {
+		cached, fresh = c.cachedDownload(req.Request, txId)
+	}
+	if fresh {
+		c.debugMsg("[Download] serve %s from cache", txId)
+		return cached.file(), nil
+	}
+
 	resp, err := c.client.Do(req)
 	if err != nil {
 		return nil, err
@@ -131,23 +320,79 @@ func (c *Client) Download(ctx context.Context, txId string) (*types.File, error)
 
 	select {
 	case <-ctx.Done():
+		resp.Body.Close()
 		return nil, ctx.Err()
 	default:
+		if resp.StatusCode == http.StatusNotModified && options.conditional() {
+			resp.Body.Close()
+			return nil, errors.ErrNotModified
+		}
+
+		if resp.StatusCode == http.StatusNotModified && cached != nil {
+			resp.Body.Close()
+			c.debugMsg("[Download] cached %s not modified", txId)
+			return cached.file(), nil
+		}
+
 		if err := statusCheck(resp); err != nil {
+			resp.Body.Close()
 			return nil, err
 		}
 
-		return &types.File{
-			Data:          resp.Body,
-			Header:        resp.Header,
-			ContentLength: resp.ContentLength,
-			ContentType:   resp.Header.Get("Content-Type"),
-		}, nil
+		return c.cacheResponse(txId, resp)
+	}
+}
+
+func (c *Client) Exists(ctx context.Context, txId string) (bool, error) {
+	ctx, cancel := withTimeout(ctx, c.timeouts.Query)
+	defer cancel()
+
+	url := fmt.Sprintf(_downloadPath, c.gateway, txId)
+
+	req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return false, err
+	}
+
+	if c.authValue != nil {
+		value, err := c.authValue()
+		if err != nil {
+			return false, err
+		}
+		req.Header.Set(c.authHeader, value)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	select {
+	case <-ctx.Done():
+		return false, ctx.Err()
+	default:
+		if resp.StatusCode == http.StatusNotFound {
+			return false, nil
+		}
+
+		if err := statusCheck(resp); err != nil {
+			return false, err
+		}
+
+		return true, nil
 	}
 }
 
 func (c *Client) GetMetaData(ctx context.Context, txId string) (types.Transaction, error) {
-	url := fmt.Sprintf(_txPath, _defaultGateway, txId)
+	ctx, cancel := withTimeout(ctx, c.timeouts.Query)
+	defer cancel()
+
+	if tx, ok := cachedMeta[types.Transaction](c, _metaCacheTxPrefix+txId); ok {
+		return tx, nil
+	}
+
+	url := fmt.Sprintf(_txPath, c.gateway, txId)
 
 	req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -168,78 +413,228 @@ func (c *Client) GetMetaData(ctx context.Context, txId string) (types.Transactio
 		if err := statusCheck(resp); err != nil {
 			return types.Transaction{}, err
 		}
-		return decodeBody[types.Transaction](resp.Body)
+		tx, err := decodeBody[types.Transaction](c.codec, c.limitBody(EndpointTransaction, resp.Body))
+		if err != nil {
+			return types.Transaction{}, err
+		}
+		c.cacheMeta(_metaCacheTxPrefix+txId, tx)
+		return tx, nil
+	}
+}
+
+func (c *Client) GetTags(ctx context.Context, txId string) ([]types.Tag, error) {
+	tx, err := c.GetMetaData(ctx, txId)
+	if err != nil {
+		return nil, err
+	}
+	return tx.DecodedTags()
+}
+
+func (c *Client) GetOwner(ctx context.Context, txId string) (types.Owner, error) {
+	tx, err := c.GetMetaData(ctx, txId)
+	if err != nil {
+		return types.Owner{}, err
 	}
+	return ownerOf(tx)
+}
+
+func ownerOf(tx types.Transaction) (types.Owner, error) {
+	var owner types.Base64String
+	if err := owner.Decode(tx.Owner); err != nil {
+		return types.Owner{}, err
+	}
+
+	sigType, err := signer.TypeByOwner(owner)
+	if err != nil {
+		return types.Owner{}, err
+	}
+
+	address, err := signer.OwnerAddress(sigType, owner)
+	if err != nil {
+		return types.Owner{}, err
+	}
+
+	return types.Owner{
+		SignatureType: sigType,
+		PublicKey:     owner,
+		Address:       address,
+	}, nil
+}
+
+// ResolveAddress resolve address of transaction owner, address of master for item signed by session key
+// with delegation valid at time of item (WithDelegation)
+func (c *Client) ResolveAddress(ctx context.Context, txId string) (string, error) {
+	tx, err := c.GetMetaData(ctx, txId)
+	if err != nil {
+		return "", err
+	}
+
+	owner, err := ownerOf(tx)
+	if err != nil {
+		return "", err
+	}
+
+	tags, err := tx.DecodedTags()
+	if err != nil {
+		return "", err
+	}
+
+	at := time.Now()
+	if tx.Timestamp > 0 {
+		at = time.UnixMilli(tx.Timestamp)
+	}
+
+	master, err := VerifyDelegation(owner.PublicKey, tags, at)
+	switch {
+	case stderrors.Is(err, errors.ErrDelegationNotFound):
+		return owner.Address, nil
+	case err != nil:
+		return "", err
+	}
+	return master.Address, nil
 }
 
 func (c *Client) GetReceipt(ctx context.Context, txId string) (types.Receipt, error) {
-	url := fmt.Sprintf(_graphql, c.network)
+	ctx, cancel := withTimeout(ctx, c.timeouts.Query)
+	defer cancel()
 
-	body := strings.NewReader(fmt.Sprintf("{\"query\":\"query {\\n      "+
-		"transactions(ids: [\\\"%s\\\"]) {\\n        edges {\\n         "+
-		" node {\\n            receipt {\\n              signature\\n              "+
-		"timestamp\\n              version\\n              deadlineHeight\\n           "+
-		" }\\n          }\\n        }\\n      }\\n    }\",\"variables\":{}}", txId))
+	if receipt, ok := cachedMeta[types.Receipt](c, _metaCacheReceiptPrefix+txId); ok {
+		return receipt, nil
+	}
 
-	req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodPost, url, body)
+	query, err := receiptQuery([]string{txId}, c.receiptFields)
 	if err != nil {
 		return types.Receipt{}, err
 	}
 
-	req.Header.Add("Content-Type", "application/json")
-
-	resp, err := c.client.Do(req)
+	data, err := graphqlQuery[types.TransactionsData](ctx, c, query)
 	if err != nil {
 		return types.Receipt{}, err
 	}
 
-	defer resp.Body.Close()
+	if len(data.Transactions.Edges) != 0 {
+		receipt := receiptOf(data.Transactions.Edges[0].Node)
+		c.cacheMeta(_metaCacheReceiptPrefix+txId, receipt)
+		return receipt, nil
+	}
 
-	select {
-	case <-ctx.Done():
-		return types.Receipt{}, ctx.Err()
-	default:
-		if err := statusCheck(resp); err != nil {
-			return types.Receipt{}, err
+	// transaction without receipt not cached, receipt may be available later
+	return types.Receipt{}, nil
+}
+
+// GetReceipts get receipts of many transactions with one query per 100 ids, receipts keyed by
+// transaction id and transactions without receipt not in map.
+func (c *Client) GetReceipts(ctx context.Context, txIds []string) (map[string]types.Receipt, error) {
+	ctx, cancel := withTimeout(ctx, c.timeouts.Query)
+	defer cancel()
+
+	fields := c.receiptFields
+	if !containsReceiptField(fields, types.ReceiptFieldID) {
+		// id required to key receipts
+		fields = append(fields[:len(fields):len(fields)], types.ReceiptFieldID)
+	}
+
+	receipts := make(map[string]types.Receipt, len(txIds))
+	for start := 0; start < len(txIds); start += _receiptBatchSize {
+		end := start + _receiptBatchSize
+		if end > len(txIds) {
+			end = len(txIds)
+		}
+
+		query, err := receiptQuery(txIds[start:end], fields)
+		if err != nil {
+			return nil, err
 		}
 
-		response, err := decodeBody[types.ReceiptResponse](resp.Body)
+		data, err := graphqlQuery[types.TransactionsData](ctx, c, query)
 		if err != nil {
-			return types.Receipt{}, err
+			return nil, err
 		}
 
-		if response.Data.Transactions.Edges != nil {
-			return response.Data.Transactions.Edges[0].Node.Receipt, nil
+		for _, edge := range data.Transactions.Edges {
+			receipts[edge.Node.ID] = receiptOf(edge.Node)
 		}
+	}
+
+	return receipts, nil
+}
+
+func receiptOf(node types.TransactionNode) types.Receipt {
+	receipt := node.Receipt
+	receipt.ID = node.ID
+	receipt.Address = node.Address
+	receipt.Currency = node.Currency
+	receipt.TransactionTimestamp = node.Timestamp
+	return receipt
+}
 
-		return types.Receipt{}, nil
+func containsReceiptField(fields []types.ReceiptField, field types.ReceiptField) bool {
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
 	}
+	return false
 }
 
-func (c *Client) BasicUpload(ctx context.Context, file []byte, tags ...types.Tag) (types.Transaction, error) {
+func (c *Client) BasicUpload(ctx context.Context, file []byte, tags ...types.Tag) (types.UploadResult, error) {
 	url := fmt.Sprintf(_uploadPath, c.network, c.currency.GetName())
 
+	if c.IsFreeUpload(c.itemSize(len(file), tags)) {
+		c.debugMsg("[BasicUpload] free upload of %d bytes, skip funding", len(file))
+		tx, err := c.upload(ctx, url, file, tags...)
+		// tags added on upload may take item over free limit, which node charge
+		if !stderrors.Is(err, errors.ErrNotEnoughBalance) {
+			return types.UploadResult{Transaction: tx}, err
+		}
+		c.debugMsg("[BasicUpload] upload of %d bytes not free on node, fund and retry", len(file))
+	}
+
 	price, err := c.GetPrice(ctx, len(file))
 	if err != nil {
-		return types.Transaction{}, err
+		return types.UploadResult{}, err
 	}
 	c.debugMsg("[BasicUpload] get price %s", price.String())
 
 	balance, err := c.GetBalance(ctx)
 	if err != nil {
-		return types.Transaction{}, err
+		return types.UploadResult{}, err
 	}
 	c.debugMsg("[BasicUpload] get balance %s", balance.String())
 
-	if balance.Cmp(price) < 0 {
-		err := c.TopUpBalance(ctx, price)
+	// check limits before funding, spend is recorded by upload
+	if err := c.checkSpend(price); err != nil {
+		return types.UploadResult{}, err
+	}
+
+	var result types.UploadResult
+	if balance.Cmp(price) < 0 && !c.dryRun {
+		hash, err := c.topUp(ctx, price)
+		if len(hash) != 0 {
+			// funds sent on chain also when node not confirmed funding
+			result.Funded = true
+			result.FundedAmount = price
+			result.FundingTxHash = hash
+		}
 		if err != nil {
-			return types.Transaction{}, err
+			return result, err
 		}
-		c.debugMsg("[BasicUpload] topUp balance")
+		c.debugMsg("[BasicUpload] topUp balance with transaction %s", hash)
 	}
 
-	return c.upload(ctx, url, file, tags...)
+	// funding outcome returned on upload error too, so caller know about spent funds
+	result.Transaction, err = c.upload(ctx, url, file, tags...)
+	switch {
+	case err == nil && !result.Funded:
+		// upload paid by existing balance, which credit of canceled uploads is part of
+		c.useUnspentCredit(price)
+	case err != nil && result.Funded && ctx.Err() != nil:
+		// upload canceled after funding, keep track of credit for AccountSummary
+		c.addUnspentCredit(price)
+		c.debugMsg("[BasicUpload] upload canceled after topUp, unspent credit %s", price.String())
+	}
+
+	return result, err
 }
 
 func (c *Client) Upload(ctx context.Context, file []byte, tags ...types.Tag) (types.Transaction, error) {
@@ -247,23 +642,137 @@ func (c *Client) Upload(ctx context.Context, file []byte, tags ...types.Tag) (ty
 	return c.upload(ctx, url, file, tags...)
 }
 
+// UploadWithSigner upload file signed by s instead of currency signer (e.g. threshold signer of
+// signer.NewMultiAptosSigner), upload fail without post when s can not sign (threshold not met).
+func (c *Client) UploadWithSigner(ctx context.Context, s signer.Signer, file []byte, tags ...types.Tag) (types.Transaction, error) {
+	url := fmt.Sprintf(_uploadPath, c.network, c.currency.GetName())
+	return c.uploadWith(ctx, url, s, file, tags...)
+}
+
 func (c *Client) upload(ctx context.Context, url string, file []byte, tags ...types.Tag) (types.Transaction, error) {
-	b, err := signFile(file, c.currency.GetSinger(), false, tags...)
+	return c.uploadWith(ctx, url, c.uploadSigner(), file, tags...)
+}
+
+func (c *Client) uploadWith(ctx context.Context, url string, s signer.Signer, file []byte, tags ...types.Tag) (types.Transaction, error) {
+	ctx, cancel := withTimeout(ctx, c.timeouts.Upload)
+	defer cancel()
+
+	c.uploadStarted(ctx, len(file), tags)
+	if c.uploadStrategy == StrategyArweave {
+		tx, err := c.l1Upload(ctx, file, tags...)
+		c.uploadCompleted(ctx, "Upload", tx, err)
+		return tx, err
+	}
+
+	release, err := c.reserveSpend(ctx, len(file), tags)
 	if err != nil {
+		c.uploadCompleted(ctx, "Upload", types.Transaction{}, err)
 		return types.Transaction{}, err
 	}
 
-	req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(b))
+	tx, err := c.doUpload(ctx, url, s, file, tags...)
+	if stderrors.Is(err, errors.ErrNotEnoughBalance) && c.autoFund() {
+		if err = c.fundShortfall(ctx, len(file)); err == nil {
+			tx, err = c.doUpload(ctx, url, s, file, tags...)
+		}
+	}
+	if err != nil && c.fallbackToL1(ctx, err) {
+		c.debugMsg("[Upload] bundler upload failed, post to arweave: %v", err)
+		// spend of bundler upload replaced by spend of L1 post
+		release()
+		release = func() {}
+		tx, err = c.l1Upload(ctx, file, tags...)
+	}
+	if err == nil {
+		c.storeReceipt(ctx, tx)
+		c.indexUpload(ctx, tx, int64(len(file)))
+		c.recordUpload(ctx, tx, int64(len(file)))
+	} else {
+		release()
+	}
+	c.uploadCompleted(ctx, "Upload", tx, err)
+	return tx, err
+}
+
+func (c *Client) doUpload(ctx context.Context, url string, s signer.Signer, file []byte, tags ...types.Tag) (types.Transaction, error) {
+	ctx, timer := c.startStats(ctx)
+
+	item, err := signItem(file, s, false, c.uploadTags(file, tags...)...)
 	if err != nil {
 		return types.Transaction{}, err
 	}
+	timer.signDone()
+
+	if c.dryRun {
+		return c.dryRunUpload(ctx, item, len(item.Data))
+	}
+
+	if err := c.journal.begin(item, file); err != nil {
+		return types.Transaction{}, err
+	}
+
+	body, err := newItemBody(item)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+	// data of item not read by transport after upload returned (e.g. unmapped file of UploadFile)
+	defer body.close()
+
+	tx, err := c.postBody(ctx, url, item.Id.Base64(), body.reader, body.size)
+	if err != nil {
+		if rejectedByNode(err) {
+			// item not accepted, so not posted again by Recover
+			if jerr := c.journal.fail(item.Id.Base64(), err); jerr != nil {
+				c.debugMsg("[Upload] journal rejection of %s: %v", item.Id.Base64(), jerr)
+			}
+		}
+		return types.Transaction{}, err
+	}
+
+	if err := c.journal.complete(item.Id.Base64()); err != nil {
+		return types.Transaction{}, err
+	}
+
+	tx.Stats = timer.finish(item.Size())
+	return withItemTags(tx, item), nil
+}
+
+// postItem post signed data item to node, post retried since node accept item of id once
+func (c *Client) postItem(ctx context.Context, url, id string, item []byte) (types.Transaction, error) {
+	body := func() (io.Reader, error) {
+		return bytes.NewReader(item), nil
+	}
+	return c.postBody(ctx, url, id, body, int64(len(item)))
+}
+
+// postBody post serialized data item of size read from body (called for each attempt) to node
+func (c *Client) postBody(ctx context.Context, url, id string, body retryablehttp.ReaderFunc, size int64) (types.Transaction, error) {
+	// item may be accepted by node when request of any attempt fully written
+	var sent int32
+	trace := &httptrace.ClientTrace{
+		WroteRequest: func(info httptrace.WroteRequestInfo) {
+			if info.Err == nil {
+				atomic.StoreInt32(&sent, 1)
+			}
+		},
+	}
+	traceCtx := httptrace.WithClientTrace(withIdempotencyKey(ctx, id), trace)
+
+	req, err := retryablehttp.NewRequestWithContext(traceCtx, http.MethodPost, url, body)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+	req.ContentLength = size
 
 	req.Header.Set("Content-Type", "application/octet-stream")
+	if len(c.paidBy) != 0 {
+		req.Header.Set(_paidByHeader, strings.Join(c.paidBy, ","))
+	}
 	c.debugMsg("[Upload] create upload request")
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return types.Transaction{}, err
+		return types.Transaction{}, &nodeError{err: err, sent: atomic.LoadInt32(&sent) == 1}
 	}
 	defer resp.Body.Close()
 
@@ -274,6 +783,22 @@ func (c *Client) upload(ctx context.Context, url string, file []byte, tags ...ty
 		if err := statusCheck(resp); err != nil {
 			return types.Transaction{}, err
 		}
-		return decodeBody[types.Transaction](resp.Body)
+		return decodeBody[types.Transaction](c.codec, c.limitBody(EndpointTransaction, resp.Body))
+	}
+}
+
+func (c *Client) addUnspentCredit(amount *big.Int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.unspentCredit.Add(c.unspentCredit, amount)
+}
+
+// useUnspentCredit decrease unspent credit by amount spent from balance
+func (c *Client) useUnspentCredit(amount *big.Int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.unspentCredit.Sub(c.unspentCredit, amount)
+	if c.unspentCredit.Sign() < 0 {
+		c.unspentCredit.SetInt64(0)
 	}
 }
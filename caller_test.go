@@ -0,0 +1,369 @@
+package irys
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ja7ad/irys/currency"
+	"github.com/Ja7ad/irys/currency/simulated"
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/signer"
+	"github.com/Ja7ad/irys/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBulkPrice(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		size, err := strconv.Atoi(r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+		require.NoError(t, err)
+		// base fee 100 and 10 per chunk
+		fmt.Fprint(w, 100+10*size/_priceChunkSize)
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	c := &Client{
+		client:   retryablehttp.NewClient(),
+		network:  Node(srv.URL),
+		currency: matic,
+	}
+
+	price, err := c.GetBulkPrice(context.Background(), []int{0, 1, _priceChunkSize, _priceChunkSize + 1, 10 * _priceChunkSize})
+	require.NoError(t, err)
+	require.Equal(t, 2, calls)
+	require.Equal(t, []*big.Int{
+		big.NewInt(110), big.NewInt(110), big.NewInt(110), big.NewInt(120), big.NewInt(200),
+	}, price.Items)
+	require.Equal(t, big.NewInt(650), price.Total)
+}
+
+func TestUploadAutoFundLimit(t *testing.T) {
+	uploads := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/price/"):
+			fmt.Fprint(w, 150)
+		case r.URL.Path == "/account/balance/matic":
+			fmt.Fprint(w, `{"balance":"100"}`)
+		case r.URL.Path == "/tx/matic":
+			uploads++
+			w.WriteHeader(http.StatusPaymentRequired)
+		}
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	c := &Client{
+		client:        retryablehttp.NewClient(),
+		network:       Node(srv.URL),
+		currency:      matic,
+		autoFundLimit: big.NewInt(10),
+	}
+
+	_, err = c.Upload(context.Background(), []byte("hello irys"))
+	require.ErrorIs(t, err, errs.ErrAutoFundLimitExceeded)
+	require.Equal(t, 1, uploads)
+}
+
+func TestUploadAutoFundDryRun(t *testing.T) {
+	var posts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			posts++
+		}
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/price/"):
+			fmt.Fprint(w, 150)
+		case r.URL.Path == "/account/balance/matic":
+			fmt.Fprint(w, `{"balance":"100"}`)
+		}
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	backend := simulated.New(new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18)), key)
+	defer backend.Close()
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), backend)
+	require.NoError(t, err)
+
+	c := &Client{
+		client:        retryablehttp.NewClient(),
+		network:       Node(srv.URL),
+		mu:            new(sync.Mutex),
+		currency:      matic,
+		contract:      "0x853758425e953739F5438fd6fd0Efe04A477b039",
+		autoFundLimit: big.NewInt(100),
+		dryRun:        true,
+	}
+
+	// shortfall of dry run reported, not funded
+	_, err = c.Upload(context.Background(), []byte("hello irys"))
+	require.ErrorIs(t, err, errs.ErrNotEnoughBalance)
+	_, err = c.UploadReader(context.Background(), strings.NewReader("hello irys"), 10)
+	require.ErrorIs(t, err, errs.ErrNotEnoughBalance)
+	require.Zero(t, posts)
+
+	nonce, err := backend.PendingNonceAt(context.Background(), crypto.PubkeyToAddress(key.PublicKey))
+	require.NoError(t, err)
+	require.Zero(t, nonce)
+}
+
+func TestPreflightUpload(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/price/"):
+			fmt.Fprint(w, 150)
+		case r.URL.Path == "/account/balance/matic":
+			fmt.Fprint(w, `{"balance":"100"}`)
+		}
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	c := &Client{
+		client:   retryablehttp.NewClient(),
+		network:  Node(srv.URL),
+		currency: matic,
+	}
+
+	quote, err := c.PreflightUpload(context.Background(), 1024)
+	require.ErrorIs(t, err, errs.ErrNotEnoughBalance)
+	require.Equal(t, big.NewInt(150), quote.Price)
+	require.Equal(t, big.NewInt(100), quote.Balance)
+	require.Equal(t, big.NewInt(50), quote.Shortfall)
+	require.False(t, quote.AutoFund)
+
+	c.autoFundLimit = big.NewInt(10)
+	quote, err = c.PreflightUpload(context.Background(), 1024)
+	require.ErrorIs(t, err, errs.ErrAutoFundLimitExceeded)
+	require.False(t, quote.AutoFund)
+
+	c.autoFundLimit = big.NewInt(50)
+	quote, err = c.PreflightUpload(context.Background(), 1024)
+	require.NoError(t, err)
+	require.True(t, quote.AutoFund)
+	require.Equal(t, big.NewInt(50), quote.Shortfall)
+}
+
+func TestFundToTargetReached(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"balance":"1000"}`)
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	c := &Client{client: retryablehttp.NewClient(), network: Node(srv.URL), currency: matic}
+
+	funded, err := c.FundToTarget(context.Background(), big.NewInt(500))
+	require.NoError(t, err)
+	require.Zero(t, funded.Sign())
+
+	require.ErrorIs(t, c.TopUpStandard(context.Background(), "0"), errs.ErrInvalidAmount)
+}
+
+func TestClientIntrospection(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	c := &Client{network: DefaultDevNet, currency: matic}
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey).Hex(), c.Address())
+	require.Equal(t, "matic", c.Currency())
+	require.Equal(t, "https://devnet.irys.xyz", c.Node())
+}
+
+func TestNewWithContractAddress(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	// unreachable node, New must not call it
+	c, err := New(Node("http://127.0.0.1:1"), matic, false, WithContractAddress("0x1"), WithCustomRetryMax(0))
+	require.NoError(t, err)
+	require.Equal(t, "0x1", c.(*Client).contract)
+	require.Equal(t, DefaultGateway, c.Gateway())
+	c.Close()
+}
+
+func TestUploadWithSigner(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		item := new(types.BundleItem)
+		require.NoError(t, item.Unmarshal(b))
+		require.Equal(t, signer.MultiAptos, item.SignatureType)
+		require.NoError(t, item.VerifySignature())
+		fmt.Fprintf(w, `{"id":%q}`, item.Id.Base64())
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	c := &Client{client: retryablehttp.NewClient(), network: Node(srv.URL), currency: matic}
+
+	pub1, priv1, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	pub2, priv2, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	approvals := 2
+	multisig, err := signer.NewMultiAptosSigner([]ed25519.PublicKey{pub1, pub2}, 2, func(message []byte) ([]signer.PartialSignature, error) {
+		partials := []signer.PartialSignature{{Index: 0, Signature: ed25519.Sign(priv1, message)}}
+		if approvals == 2 {
+			partials = append(partials, signer.PartialSignature{Index: 1, Signature: ed25519.Sign(priv2, message)})
+		}
+		return partials, nil
+	})
+	require.NoError(t, err)
+
+	tx, err := c.UploadWithSigner(context.Background(), multisig, []byte("dao proposal"))
+	require.NoError(t, err)
+	require.NotEmpty(t, tx.ID)
+
+	approvals = 1
+	_, err = c.UploadWithSigner(context.Background(), multisig, []byte("dao proposal"))
+	require.ErrorIs(t, err, errs.ErrSignatureThresholdNotMet)
+}
+
+func TestAccountSummaryUnspentCredit(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	backend := simulated.New(new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18)), key)
+	defer backend.Close()
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), backend)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	price, balance := "150", "100"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/price/"):
+			fmt.Fprint(w, price)
+		case r.URL.Path == "/account/balance/matic" && r.Method == http.MethodPost:
+		case r.URL.Path == "/account/balance/matic":
+			fmt.Fprintf(w, `{"balance":"%s"}`, balance)
+		case r.URL.Path == "/tx/matic" && balance == "100":
+			// caller give up after funding
+			cancel()
+			time.Sleep(50 * time.Millisecond)
+		case r.URL.Path == "/tx/matic":
+			fmt.Fprint(w, `{"id":"tx1"}`)
+		}
+	}))
+	defer srv.Close()
+
+	c := &Client{
+		client:        retryablehttp.NewClient(),
+		network:       Node(srv.URL),
+		mu:            new(sync.Mutex),
+		currency:      matic,
+		contract:      "0x853758425e953739F5438fd6fd0Efe04A477b039",
+		unspentCredit: new(big.Int),
+	}
+	c.client.RetryMax = 0
+
+	result, err := c.BasicUpload(ctx, []byte("hello irys"))
+	require.ErrorIs(t, err, context.Canceled)
+	require.True(t, result.Funded)
+
+	balance = "1000"
+	summary, err := c.AccountSummary(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey).Hex(), summary.Address)
+	require.Equal(t, big.NewInt(150), summary.UnspentCredit)
+
+	// upload paid by balance use credit first
+	price = "40"
+	_, err = c.BasicUpload(context.Background(), []byte("hello irys"))
+	require.NoError(t, err)
+
+	summary, err = c.AccountSummary(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(110), summary.UnspentCredit)
+
+	// credit never more than balance, spent by uploads of unknown price
+	balance = "60"
+	summary, err = c.AccountSummary(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(60), summary.UnspentCredit)
+}
+
+func TestGetBalanceOf(t *testing.T) {
+	var path, address string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		address = r.URL.Query().Get("address")
+		fmt.Fprint(w, `{"balance":"42"}`)
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	c := &Client{client: retryablehttp.NewClient(), network: Node(srv.URL), currency: matic}
+
+	balance, err := c.GetBalanceOf(context.Background(), "0x853758425e953739F5438fd6fd0Efe04A477b039")
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(42), balance)
+	require.Equal(t, "/account/balance/matic", path)
+	require.Equal(t, "0x853758425e953739F5438fd6fd0Efe04A477b039", address)
+
+	_, err = c.GetBalance(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, c.Address(), address)
+}
@@ -0,0 +1,94 @@
+package irys
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// Capabilities return features of node (currencies, max upload size, chunked upload) learned from node info
+// and chunk endpoint, cached for 10 minutes. Node itself stays a url string, features are not part of Node.
+// Chunk endpoint of client currency probed by OPTIONS so no upload session created, chunk size limits
+// reported once learned by chunked upload.
+func (c *Client) Capabilities(ctx context.Context) (types.NodeCapabilities, error) {
+	if c.capabilities != nil {
+		if caps, ok := c.capabilities.get(); ok {
+			return caps, nil
+		}
+	}
+
+	info, err := c.cachedNodeInfo(ctx)
+	if err != nil {
+		return types.NodeCapabilities{}, err
+	}
+
+	caps := types.NodeCapabilities{
+		Version:       info.Version,
+		Currencies:    make([]string, 0, len(info.Addresses)),
+		MaxUploadSize: info.MaxUploadSize,
+	}
+	for name := range info.Addresses {
+		caps.Currencies = append(caps.Currencies, name)
+	}
+	sort.Strings(caps.Currencies)
+
+	if limits, ok := c.learnedChunkLimits(); ok {
+		caps.Chunking = true
+		caps.MinChunkSize = limits.Min
+		caps.MaxChunkSize = limits.Max
+	} else {
+		caps.Chunking, err = probeChunking(ctx, c)
+		if err != nil {
+			return types.NodeCapabilities{}, err
+		}
+	}
+
+	if c.capabilities != nil {
+		c.capabilities.set(caps)
+	}
+	return caps, nil
+}
+
+// learnedChunkLimits return chunk size limits of node learned by last chunked upload
+func (c *Client) learnedChunkLimits() (types.ChunkResponse, bool) {
+	if c.chunkLimits == nil {
+		return types.ChunkResponse{}, false
+	}
+	return c.chunkLimits.get()
+}
+
+// probeChunking check chunk endpoint of currency exist by OPTIONS, unlike GET of upload id it not
+// create upload session on node
+func probeChunking(ctx context.Context, c *Client) (bool, error) {
+	url := fmt.Sprintf(_chunkUpload, c.network, c.currency.GetName(), -1, -1)
+	req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodOptions, url, nil)
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set("x-chunking-version", "2")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	var respErr *errors.ResponseError
+	err = statusCheck(resp)
+	switch {
+	case stderrors.As(err, &respErr):
+		// node reject chunked upload (e.g. legacy node or currency without chunking)
+		c.debugMsg("[Capabilities] chunked upload not available: %v", err)
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+	return true, nil
+}
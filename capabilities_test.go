@@ -0,0 +1,88 @@
+package irys
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Ja7ad/irys/currency"
+	"github.com/Ja7ad/irys/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapabilities(t *testing.T) {
+	chunking := true
+	requests, sessions := 0, 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/info":
+			fmt.Fprint(w, `{"version":"0.2.0","addresses":{"matic":"0x1","arweave":"addr"},"maxUploadSize":1000}`)
+		case "/chunks/matic/-1/-1":
+			if !chunking {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			if r.Method == http.MethodOptions {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			sessions++
+			fmt.Fprint(w, `{"id":"up","min":100,"max":500}`)
+		}
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	c := &Client{
+		client:       retryablehttp.NewClient(),
+		network:      Node(srv.URL),
+		currency:     matic,
+		nodeInfo:     newTTLCache[types.NodeInfo](_nodeInfoTTL),
+		capabilities: newTTLCache[types.NodeCapabilities](_nodeInfoTTL),
+		chunkLimits:  newTTLCache[types.ChunkResponse](_nodeInfoTTL),
+	}
+
+	for i := 0; i < 2; i++ {
+		caps, err := c.Capabilities(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, types.NodeCapabilities{
+			Version:       "0.2.0",
+			Currencies:    []string{"arweave", "matic"},
+			MaxUploadSize: 1000,
+			Chunking:      true,
+		}, caps)
+		require.True(t, caps.SupportsCurrency("matic"))
+		require.False(t, caps.SupportsCurrency("solana"))
+	}
+	require.Equal(t, 2, requests)
+	// probe not create upload session
+	require.Zero(t, sessions)
+
+	// chunk size limits learned by chunked upload
+	_, err = generateChunkID(context.Background(), c)
+	require.NoError(t, err)
+	c.capabilities = nil
+	caps, err := c.Capabilities(context.Background())
+	require.NoError(t, err)
+	require.True(t, caps.Chunking)
+	require.Equal(t, 100, caps.MinChunkSize)
+	require.Equal(t, 500, caps.MaxChunkSize)
+	require.Equal(t, 1, sessions)
+
+	chunking = false
+	c.chunkLimits = nil
+	caps, err = c.Capabilities(context.Background())
+	require.NoError(t, err)
+	require.False(t, caps.Chunking)
+}
@@ -9,7 +9,9 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"os"
 	"sync"
+	"sync/atomic"
 
 	errs "github.com/Ja7ad/irys/errors"
 	"github.com/Ja7ad/irys/types"
@@ -17,39 +19,116 @@ import (
 )
 
 const (
-	_maxRetries      = 3 // define the maximum number of retries for a timeout error
+	_maxRetries      = 3 // define the default maximum number of retries for a timeout error
 	_defaultMinChunk = 500000
 	_defaultMaxChunk = 95000000
+	_maxChunkSize    = 25000000 // define the default upper bound of single chunk request size
 )
 
+// ChunkUpload upload data item of file by chunked upload protocol, item read in parts of chunk size so memory
+// depend on chunk size and concurrency, not size of file. File which is io.ReaderAt and io.Seeker (e.g. *os.File,
+// *bytes.Reader) read in place from current offset, other readers copied to temp file first. Node must report
+// all chunks received and finish upload with id of signed item. Non-empty chunkId resume upload of chunkId with
+// chunk size of chunks received by node.
 func (c *Client) ChunkUpload(ctx context.Context, file io.Reader, chunkId string, tags ...types.Tag) (types.Transaction, error) {
+	ctx, cancel := withTimeout(ctx, c.timeouts.Upload)
+	defer cancel()
+
 	var (
-		wg   sync.WaitGroup
-		once sync.Once
+		tx      types.Transaction
+		release func()
 	)
-	jobsCh := make(chan types.Job)
-	errCh := make(chan error)
+	data, size, cleanup, err := chunkSource(file)
+	if err == nil {
+		defer cleanup()
+		release, err = c.reserveSpend(ctx, int(size), tags)
+	}
+	if err == nil {
+		if tx, err = c.chunkUpload(ctx, data, size, chunkId, tags...); err != nil {
+			release()
+		}
+	}
+	if err == nil {
+		c.storeReceipt(ctx, tx)
+		c.indexUpload(ctx, tx, size)
+		c.recordUpload(ctx, tx, size)
+	}
+	c.uploadCompleted(ctx, "ChunkUpload", tx, err)
+	return tx, err
+}
+
+// chunkSource return file as reader at with size of data, readers without random access spooled to temp
+// file removed by cleanup
+func chunkSource(file io.Reader) (io.ReaderAt, int64, func(), error) {
+	if r, ok := file.(io.ReaderAt); ok {
+		if s, ok := file.(io.Seeker); ok {
+			offset, err := s.Seek(0, io.SeekCurrent)
+			if err != nil {
+				return nil, 0, nil, err
+			}
+			end, err := s.Seek(0, io.SeekEnd)
+			if err != nil {
+				return nil, 0, nil, err
+			}
+			if _, err := s.Seek(offset, io.SeekStart); err != nil {
+				return nil, 0, nil, err
+			}
+			return io.NewSectionReader(r, offset, end-offset), end - offset, func() {}, nil
+		}
+	}
+
+	tmp, err := os.CreateTemp("", "irys-chunk-*")
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	cleanup := func() {
+		tmp.Close()
+		os.Remove(tmp.Name())
+	}
+
+	size, err := io.Copy(tmp, file)
+	if err != nil {
+		cleanup()
+		return nil, 0, nil, err
+	}
+	return tmp, size, cleanup, nil
+}
+
+func (c *Client) chunkUpload(ctx context.Context, data io.ReaderAt, size int64, chunkId string, tags ...types.Tag) (types.Transaction, error) {
+	var wg sync.WaitGroup
+	ctx, timer := c.startStats(ctx)
 	workerNum := 1
 	chunkSize := 0
 	chunkUUID := chunkId
 
-	payload, err := io.ReadAll(file)
+	c.uploadStarted(ctx, int(size), tags)
+
+	item, err := c.signItemReader(data, size, true, tags...)
 	if err != nil {
 		return types.Transaction{}, err
 	}
 
-	b, err := signFile(payload, c.currency.GetSinger(), true, tags...)
+	timer.signDone()
+
+	if c.dryRun {
+		return c.dryRunUpload(ctx, item, int(size))
+	}
+
+	body, err := newStreamItemBody(item, data, size)
 	if err != nil {
 		return types.Transaction{}, err
 	}
+	defer body.close()
 
-	fileSize := len(b)
+	fileSize := int(body.size)
 
 	if fileSize < _defaultMinChunk {
 		return types.Transaction{}, errs.ErrNotAllowedChunkSize
 	}
 
 	switch {
+	case c.chunkConcurrency > 0:
+		workerNum = c.chunkConcurrency
 	case fileSize >= 1000000 && fileSize < 10000000:
 		workerNum = 2
 	case fileSize >= 10000000 && fileSize < _defaultMaxChunk:
@@ -59,6 +138,12 @@ func (c *Client) ChunkUpload(ctx context.Context, file io.Reader, chunkId string
 	}
 
 	chunkSize = fileSize / workerNum
+	if chunkSize > _maxChunkSize {
+		chunkSize = _maxChunkSize
+	}
+	if c.chunkSize > 0 {
+		chunkSize = c.chunkSize
+	}
 
 	if len(chunkUUID) == 0 {
 		chunkInfo, err := generateChunkID(ctx, c)
@@ -66,55 +151,102 @@ func (c *Client) ChunkUpload(ctx context.Context, file io.Reader, chunkId string
 			return types.Transaction{}, err
 		}
 		chunkUUID = chunkInfo.ID
+
+		// keep chunk size in range accepted by node
+		chunkSize = clampChunkSize(chunkSize, chunkInfo)
 	} else {
-		// TODO: implement exists chunkId for resume
+		chunkSize, err = resumeChunkSize(ctx, c, chunkUUID, fileSize, chunkSize)
+		if err != nil {
+			return types.Transaction{}, err
+		}
 	}
 
+	workerCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobsCh := make(chan types.Job)
+	errCh := make(chan error, workerNum+1)
+	var uploaded int64
+
 	for w := 0; w < workerNum; w++ {
+		wg.Add(1)
 		go func(workerId int) {
+			defer wg.Done()
 			c.debugMsg("[ChunkUpload] create worker %v", workerId)
-			if err := worker(ctx, c, workerId, &wg, jobsCh); err != nil {
+			if err := worker(workerCtx, c, workerId, jobsCh, &uploaded); err != nil {
 				errCh <- err
+				cancel()
 			}
 		}(w)
 	}
 
 	index := 0
 
+produce:
 	for start := 0; start < fileSize; start += chunkSize {
 		end := start + chunkSize
 		if end > fileSize {
 			end = fileSize
 		}
 
-		chunkData := b[start:end]
-		chunk := types.Chunk{ID: chunkUUID, Offset: int64(index * chunkSize), Data: chunkData}
-		job := types.Job{Chunk: chunk, Index: index}
-		jobsCh <- job
-		index++
-		wg.Add(1)
+		// part read when worker ready, so only parts in flight are in memory
+		part := make([]byte, end-start)
+		if err := body.readPart(part, int64(start)); err != nil {
+			errCh <- err
+			cancel()
+			break
+		}
+
+		chunk := types.Chunk{ID: chunkUUID, Offset: int64(start), Data: part}
+		select {
+		case <-workerCtx.Done():
+			break produce
+		case jobsCh <- types.Job{Chunk: chunk, Index: index}:
+		}
 		c.debugMsg("[ChunkUpload] create job with index %v", index)
+		index++
+	}
+
+	close(jobsCh)
+	wg.Wait()
+	close(errCh)
+
+	if err := <-errCh; err != nil {
+		return types.Transaction{}, err
 	}
 
-	go func() {
-		wg.Wait()
-		close(jobsCh)
-		close(errCh)
-	}()
+	if err := ctx.Err(); err != nil {
+		return types.Transaction{}, err
+	}
 
-	for e := range errCh {
-		once.Do(func() {
-			close(jobsCh)
-			close(errCh)
-		})
-		return types.Transaction{}, e
+	// all chunks must be uploaded before finishing, otherwise node assemble broken data item
+	if uploaded != int64(fileSize) {
+		return types.Transaction{}, errs.ErrChunkReassembly
+	}
+	c.debugMsg("[ChunkUpload] uploaded %d bytes in %d chunks", uploaded, index)
+
+	// chunks uploaded concurrently in any order and node assemble them by offset,
+	// so chunks not stored by node re-uploaded before finishing
+	if err := reuploadMissingChunks(ctx, c, chunkUUID, body, chunkSize); err != nil {
+		return types.Transaction{}, err
 	}
 
 	select {
 	case <-ctx.Done():
 		return types.Transaction{}, ctx.Err()
 	default:
-		return finishChunk(ctx, c, chunkUUID)
+		tx, err := finishChunk(ctx, c, chunkUUID)
+		if err != nil {
+			return types.Transaction{}, err
+		}
+
+		// node assemble chunks and verify signature of item, so id of assembled item is id of signed item
+		if tx.ID != item.Id.Base64() {
+			return types.Transaction{}, fmt.Errorf("%w: node finished upload with id %s, signed item %s",
+				errs.ErrChunkReassembly, tx.ID, item.Id.Base64())
+		}
+		tx.Stats = timer.finish(fileSize)
+		return withItemTags(tx, item), nil
 	}
 }
 
@@ -138,37 +270,217 @@ func generateChunkID(ctx context.Context, c *Client) (types.ChunkResponse, error
 		return types.ChunkResponse{}, err
 	}
 
-	return decodeBody[types.ChunkResponse](resp.Body)
+	chunk, err := decodeBody[types.ChunkResponse](c.codec, c.limitBody(EndpointChunk, resp.Body))
+	if err != nil {
+		return types.ChunkResponse{}, err
+	}
+
+	// chunk size limits of node learned by uploads for Capabilities
+	if c.chunkLimits != nil {
+		c.chunkLimits.set(types.ChunkResponse{Min: chunk.Min, Max: chunk.Max})
+	}
+	return chunk, nil
 }
 
-func getChunkID(ctx context.Context, c *Client, chunkId string) (types.ChunkInfoResponse, error) {
-	panic("implement me")
+// clampChunkSize keep chunk size in min and max of node, limits not reported by node are zero
+func clampChunkSize(chunkSize int, limits types.ChunkResponse) int {
+	if limits.Max > 0 && chunkSize > limits.Max {
+		chunkSize = limits.Max
+	}
+	if limits.Min > 0 && chunkSize < limits.Min {
+		chunkSize = limits.Min
+	}
+	return chunkSize
 }
 
-func worker(ctx context.Context, c *Client, id int, wg *sync.WaitGroup, jobs <-chan types.Job) error {
-	defer wg.Done()
+// resumeChunkSize return chunk size of resumed upload of chunkId. Item signed again on resume, so all chunks
+// posted again over chunks received by node and their offsets must match received chunks, otherwise stale
+// chunks assembled with new ones. Chunk size taken from full chunk received by node, when node report no
+// chunk sizes it kept in limits of node learned by previous uploads.
+func resumeChunkSize(ctx context.Context, c *Client, chunkId string, size, chunkSize int) (int, error) {
+	if c.chunkLimits != nil {
+		if limits, ok := c.chunkLimits.get(); ok {
+			chunkSize = clampChunkSize(chunkSize, limits)
+		}
+	}
+
+	info, err := getChunkInfo(ctx, c, chunkId)
+	if err != nil {
+		var urlErr *url.Error
+		if ctx.Err() != nil || errors.As(err, &urlErr) {
+			return 0, err
+		}
+
+		c.warnMsg("[ChunkUpload] unknown chunk info of resumed upload %s, chunks not validated: %v", chunkId, err)
+		return chunkSize, nil
+	}
+
+	// only last chunk is shorter than chunk size, so largest chunk not ending data is chunk size
+	received := 0
+	for _, offset := range info.Chunks {
+		if n := info.Sizes[offset]; n > received && offset+n < size {
+			received = n
+		}
+	}
+	if received > 0 {
+		chunkSize = received
+	}
+
+	for _, offset := range info.Chunks {
+		if offset >= size || offset%chunkSize != 0 {
+			return 0, fmt.Errorf("%w: chunk of offset %d received for resumed upload %s not match chunk size %d of %d bytes",
+				errs.ErrChunkReassembly, offset, chunkId, chunkSize, size)
+		}
+	}
+
+	c.debugMsg("[ChunkUpload] resume upload %s with chunk size %d, %d chunks received by node", chunkId, chunkSize, len(info.Chunks))
+	return chunkSize, nil
+}
+
+// getChunkInfo get offsets of chunks and total size received by node for chunked upload of id
+func getChunkInfo(ctx context.Context, c *Client, chunkId string) (types.ChunkInfoResponse, error) {
+	url := fmt.Sprintf(_chunkUpload, c.network, c.currency.GetName(), chunkId, -1)
+
+	req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return types.ChunkInfoResponse{}, err
+	}
+
+	req.Header.Set("x-chunking-version", "2")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return types.ChunkInfoResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	select {
+	case <-ctx.Done():
+		return types.ChunkInfoResponse{}, ctx.Err()
+	default:
+		if err := statusCheck(resp); err != nil {
+			return types.ChunkInfoResponse{}, err
+		}
+		return decodeBody[types.ChunkInfoResponse](c.codec, c.limitBody(EndpointChunk, resp.Body))
+	}
+}
+
+// reuploadMissingChunks validate chunks received by node cover all offsets of data and re-upload missing
+// chunks, fail with ErrChunkReassembly when chunks still missing after max retries. Validation skipped
+// with warning when node not serve chunk info or chunk info unknown, finish verify id of assembled item anyway.
+func reuploadMissingChunks(ctx context.Context, c *Client, chunkId string, body *itemBody, chunkSize int) error {
+	maxRetries := c.maxChunkRetries()
+	size := int(body.size)
+
+	for attempt := 1; ; attempt++ {
+		info, err := getChunkInfo(ctx, c, chunkId)
+		if err != nil {
+			var urlErr *url.Error
+			if ctx.Err() != nil || errors.As(err, &urlErr) {
+				return err
+			}
+
+			var respErr *errs.ResponseError
+			if errors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound {
+				c.debugMsg("[ChunkUpload] node not serve chunk info, skip assembly validation")
+				return nil
+			}
+
+			c.warnMsg("[ChunkUpload] unknown chunk info of node, skip assembly validation: %v", err)
+			return nil
+		}
+
+		missing := missingChunkOffsets(info, size, chunkSize)
+		if len(missing) == 0 {
+			return nil
+		}
+
+		if attempt > maxRetries {
+			return fmt.Errorf("%w: %d chunks missing on node, node reported %d of %d bytes",
+				errs.ErrChunkReassembly, len(missing), info.Total, size)
+		}
+
+		c.debugMsg("[ChunkUpload] %d chunks missing on node, re-uploading... (Attempt %d of %d)", len(missing), attempt, maxRetries)
+		for _, offset := range missing {
+			end := offset + chunkSize
+			if end > size {
+				end = size
+			}
+
+			part := make([]byte, end-offset)
+			if err := body.readPart(part, int64(offset)); err != nil {
+				return err
+			}
+
+			chunk := types.Chunk{ID: chunkId, Offset: int64(offset), Data: part}
+			if err := createChunkRequest(ctx, c, chunk, offset/chunkSize, -1); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// missingChunkOffsets return offsets of chunks of size not received or received short by node. Node which
+// report total without offsets validated by total only, total not matching size when no offset missing
+// mean chunks not locatable so all chunks missing.
+func missingChunkOffsets(info types.ChunkInfoResponse, size, chunkSize int) []int {
+	if len(info.Chunks) == 0 && info.Total == size {
+		return nil
+	}
+
+	received := make(map[int]struct{}, len(info.Chunks))
+	for _, offset := range info.Chunks {
+		received[offset] = struct{}{}
+	}
+
+	var missing []int
+	for offset := 0; offset < size; offset += chunkSize {
+		want := chunkSize
+		if offset+want > size {
+			want = size - offset
+		}
+
+		_, ok := received[offset]
+		if n, reported := info.Sizes[offset]; !ok || (reported && n < want) {
+			missing = append(missing, offset)
+		}
+	}
+
+	if len(missing) == 0 && info.Total > 0 && info.Total != size {
+		for offset := 0; offset < size; offset += chunkSize {
+			missing = append(missing, offset)
+		}
+	}
+	return missing
+}
+
+// maxChunkRetries return max attempts of chunk upload (WithChunkMaxRetries)
+func (c *Client) maxChunkRetries() int {
+	if c.chunkMaxRetries > 0 {
+		return c.chunkMaxRetries
+	}
+	return _maxRetries
+}
+
+func worker(ctx context.Context, c *Client, id int, jobs <-chan types.Job, uploaded *int64) error {
+	maxRetries := c.maxChunkRetries()
+
 	for job := range jobs {
-		numTries := 0
-		for numTries < _maxRetries {
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			default:
-				err := createChunkRequest(ctx, c, job.Chunk, job.Index, id)
-				// if we have a network timeout error, retry the request
-				var urlErr *url.Error
-				if errors.As(err, &urlErr) {
-					var netErr net.Error
-					if errors.As(urlErr.Err, &netErr) && netErr.Timeout() {
-						numTries++
-						c.debugMsg("[ChunkUpload] timeout occurred during execution chunk upload, retrying... (Attempt %d of %d)", numTries, _maxRetries)
-						continue
-					}
-				} else {
-					return err
-				}
+		for numTries := 1; ; numTries++ {
+			err := createChunkRequest(ctx, c, job.Chunk, job.Index, id)
+			if err == nil {
+				atomic.AddInt64(uploaded, int64(len(job.Chunk.Data)))
 				break
 			}
+
+			// if we have a network timeout error, retry the request
+			var urlErr *url.Error
+			var netErr net.Error
+			if !errors.As(err, &urlErr) || !errors.As(urlErr.Err, &netErr) || !netErr.Timeout() || numTries >= maxRetries {
+				return err
+			}
+
+			c.debugMsg("[ChunkUpload] timeout occurred during execution chunk upload, retrying... (Attempt %d of %d)", numTries, maxRetries)
 		}
 	}
 	return nil
@@ -177,7 +489,8 @@ func worker(ctx context.Context, c *Client, id int, wg *sync.WaitGroup, jobs <-c
 func createChunkRequest(ctx context.Context, c *Client, chunk types.Chunk, index, workerID int) error {
 	url := fmt.Sprintf(_chunkUpload, c.network, c.currency.GetName(), chunk.ID, chunk.Offset)
 
-	req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(chunk.Data))
+	// chunk of upload stored by offset, so repost is idempotent
+	req, err := retryablehttp.NewRequestWithContext(withIdempotencyKey(ctx, url), http.MethodPost, url, bytes.NewBuffer(chunk.Data))
 	if err != nil {
 		return err
 	}
@@ -204,7 +517,7 @@ func createChunkRequest(ctx context.Context, c *Client, chunk types.Chunk, index
 func finishChunk(ctx context.Context, c *Client, uuid string) (types.Transaction, error) {
 	url := fmt.Sprintf(_chunkUpload, c.network, c.currency.GetName(), uuid, -1)
 
-	req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	req, err := retryablehttp.NewRequestWithContext(withIdempotencyKey(ctx, uuid), http.MethodPost, url, nil)
 	if err != nil {
 		return types.Transaction{}, err
 	}
@@ -227,6 +540,6 @@ func finishChunk(ctx context.Context, c *Client, uuid string) (types.Transaction
 			return types.Transaction{}, err
 		}
 
-		return decodeBody[types.Transaction](resp.Body)
+		return decodeBody[types.Transaction](c.codec, c.limitBody(EndpointTransaction, resp.Body))
 	}
 }
@@ -0,0 +1,242 @@
+package irys
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/Ja7ad/irys/currency"
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+// chunkNode is node of chunked upload protocol, it assemble received chunks by offset and finish upload with
+// id of assembled item when signature of item is valid
+type chunkNode struct {
+	mu       sync.Mutex
+	received map[int][]byte
+	drop     func(offset int) bool // drop return true when chunk of offset accepted but lost by node
+	info     string                // info override chunk info response
+	finishID string                // finishID override id of finished upload
+}
+
+func (n *chunkNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.received == nil {
+		n.received = make(map[int][]byte)
+	}
+
+	switch {
+	case r.URL.Path == "/chunks/matic/-1/-1":
+		fmt.Fprint(w, `{"id":"up","min":1,"max":1000000}`)
+	case r.URL.Path == "/chunks/matic/up/-1" && r.Method == http.MethodGet:
+		if len(n.info) != 0 {
+			fmt.Fprint(w, n.info)
+			return
+		}
+		// v2 schema of chunk info
+		var total int
+		chunks := make([][]any, 0, len(n.received))
+		for offset, b := range n.received {
+			chunks = append(chunks, []any{strconv.Itoa(offset), len(b)})
+			total += len(b)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": "up", "size": total, "chunks": chunks})
+	case r.URL.Path == "/chunks/matic/up/-1":
+		offsets := make([]int, 0, len(n.received))
+		for offset := range n.received {
+			offsets = append(offsets, offset)
+		}
+		sort.Ints(offsets)
+
+		var assembled []byte
+		for _, offset := range offsets {
+			assembled = append(assembled, n.received[offset]...)
+		}
+
+		item := new(types.BundleItem)
+		if err := item.Unmarshal(assembled); err != nil || item.Verify() != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		id := item.Id.Base64()
+		if len(n.finishID) != 0 {
+			id = n.finishID
+		}
+		_ = json.NewEncoder(w).Encode(types.Transaction{ID: id})
+	default:
+		offset, err := strconv.Atoi(path.Base(r.URL.Path))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if n.drop != nil && n.drop(offset) {
+			return
+		}
+		n.received[offset] = b
+	}
+}
+
+func newChunkClient(t *testing.T, node string, chunkSize int) *Client {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	return &Client{
+		client:           retryablehttp.NewClient(),
+		network:          Node(node),
+		currency:         matic,
+		chunkSize:        chunkSize,
+		chunkConcurrency: 2,
+	}
+}
+
+func TestChunkUploadReuploadMissing(t *testing.T) {
+	const chunkSize = 200000
+
+	dropped := false
+	node := &chunkNode{drop: func(offset int) bool {
+		// chunk accepted but lost by node once
+		if offset == chunkSize && !dropped {
+			dropped = true
+			return true
+		}
+		return false
+	}}
+	srv := httptest.NewServer(node)
+	defer srv.Close()
+
+	c := newChunkClient(t, srv.URL, chunkSize)
+
+	tx, err := c.ChunkUpload(context.Background(), bytes.NewReader(make([]byte, 3*chunkSize)), "")
+	require.NoError(t, err)
+	require.NotEmpty(t, tx.ID)
+	require.True(t, dropped)
+
+	info := types.ChunkInfoResponse{Chunks: []int{0, 2 * chunkSize}, Total: 2 * chunkSize}
+	require.Equal(t, []int{chunkSize}, missingChunkOffsets(info, 3*chunkSize, chunkSize))
+	require.Empty(t, missingChunkOffsets(types.ChunkInfoResponse{Total: 10}, 10, chunkSize))
+
+	// chunk received short by node
+	info = types.ChunkInfoResponse{Chunks: []int{0, chunkSize}, Sizes: map[int]int{0: chunkSize, chunkSize: 10}, Total: chunkSize + 10}
+	require.Equal(t, []int{chunkSize}, missingChunkOffsets(info, 2*chunkSize, chunkSize))
+
+	// total checked also when all offsets reported
+	info = types.ChunkInfoResponse{Chunks: []int{0, chunkSize}, Total: chunkSize}
+	require.Equal(t, []int{0, chunkSize}, missingChunkOffsets(info, 2*chunkSize, chunkSize))
+}
+
+func TestChunkUploadUnknownChunkInfo(t *testing.T) {
+	const chunkSize = 200000
+
+	node := &chunkNode{info: `{"chunks":{"0":true}}`}
+	srv := httptest.NewServer(node)
+	defer srv.Close()
+
+	c := newChunkClient(t, srv.URL, chunkSize)
+
+	// assembly validation skipped with warning, upload not aborted
+	tx, err := c.ChunkUpload(context.Background(), bytes.NewReader(make([]byte, 3*chunkSize)), "")
+	require.NoError(t, err)
+	require.NotEmpty(t, tx.ID)
+}
+
+func TestChunkUploadStream(t *testing.T) {
+	const chunkSize = 100000
+
+	node := &chunkNode{}
+	srv := httptest.NewServer(node)
+	defer srv.Close()
+
+	c := newChunkClient(t, srv.URL, chunkSize)
+
+	data := make([]byte, 5*chunkSize+123)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+
+	// reader without random access spooled to temp file
+	tx, err := c.ChunkUpload(context.Background(), io.MultiReader(bytes.NewReader(data)), "")
+	require.NoError(t, err)
+
+	node.mu.Lock()
+	last := node.received[len(node.received)*chunkSize-chunkSize]
+	node.received = nil
+	node.mu.Unlock()
+	require.Equal(t, data[len(data)-10:], last[len(last)-10:])
+
+	// seekable reader read in place from current offset
+	r := bytes.NewReader(append([]byte("skipped"), data...))
+	_, err = r.Seek(int64(len("skipped")), io.SeekStart)
+	require.NoError(t, err)
+
+	tx2, err := c.ChunkUpload(context.Background(), r, "")
+	require.NoError(t, err)
+	require.NotEqual(t, tx.ID, tx2.ID)
+
+	// node finished other item than signed
+	node.received = nil
+	node.finishID = "other"
+	_, err = c.ChunkUpload(context.Background(), bytes.NewReader(data), "")
+	require.ErrorIs(t, err, errs.ErrChunkReassembly)
+}
+
+func TestChunkUploadResume(t *testing.T) {
+	const chunkSize = 200000
+
+	node := &chunkNode{}
+	srv := httptest.NewServer(node)
+	defer srv.Close()
+
+	data := make([]byte, 3*chunkSize)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+
+	// chunks of previous upload session received by node
+	node.received = map[int][]byte{0: make([]byte, chunkSize), chunkSize: make([]byte, chunkSize)}
+
+	// chunk size taken from chunks received by node, not from client
+	c := newChunkClient(t, srv.URL, 300000)
+	tx, err := c.ChunkUpload(context.Background(), bytes.NewReader(data), "up")
+	require.NoError(t, err)
+	require.NotEmpty(t, tx.ID)
+
+	// stale chunks replaced by chunks of signed item
+	node.mu.Lock()
+	offsets := make([]int, 0, len(node.received))
+	for offset := range node.received {
+		offsets = append(offsets, offset)
+	}
+	sort.Ints(offsets)
+	require.Equal(t, []int{0, chunkSize, 2 * chunkSize, 3 * chunkSize}, offsets)
+	require.Len(t, node.received[chunkSize], chunkSize)
+	node.received = map[int][]byte{0: make([]byte, chunkSize), 100: make([]byte, 10)}
+	node.mu.Unlock()
+
+	// received chunk not on boundary of chunk size
+	_, err = c.ChunkUpload(context.Background(), bytes.NewReader(data), "up")
+	require.ErrorIs(t, err, errs.ErrChunkReassembly)
+}
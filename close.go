@@ -0,0 +1,210 @@
+package irys
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// backgroundWorker is background worker of client (e.g. UploadQueue) stopped on Shutdown
+type backgroundWorker interface {
+	Close(ctx context.Context) error
+}
+
+// drainTransport track in-flight requests (until response body closed) and background workers for
+// graceful Shutdown, after close new requests fail with ErrClientClosed.
+type drainTransport struct {
+	mu      sync.Mutex
+	next    http.RoundTripper
+	wg      sync.WaitGroup
+	closed  bool
+	done    chan struct{}
+	workers map[backgroundWorker]struct{}
+	shared  *drainTransport // shared is transport tracking requests of wrapped transport
+}
+
+func newDrainTransport(next http.RoundTripper) *drainTransport {
+	return &drainTransport{
+		next:    next,
+		done:    make(chan struct{}),
+		workers: make(map[backgroundWorker]struct{}),
+	}
+}
+
+// track register worker stopped on close, false when transport closed, nil transport track nothing
+func (t *drainTransport) track(w backgroundWorker) bool {
+	if t == nil {
+		return true
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return false
+	}
+	t.workers[w] = struct{}{}
+	return true
+}
+
+// untrack remove worker stopped by itself
+func (t *drainTransport) untrack(w backgroundWorker) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.workers, w)
+}
+
+func (t *drainTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.roundTrip(t.next, req)
+}
+
+// wrap return transport of next tracked by t, so requests of other clients (e.g. external client) drained
+// and rejected together with requests of t
+func (t *drainTransport) wrap(next http.RoundTripper) http.RoundTripper {
+	return &drainTransport{next: next, shared: t}
+}
+
+func (t *drainTransport) roundTrip(next http.RoundTripper, req *http.Request) (*http.Response, error) {
+	if t.shared != nil {
+		return t.shared.roundTrip(next, req)
+	}
+
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return nil, errs.ErrClientClosed
+	}
+	t.wg.Add(1)
+	t.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(req.Context())
+	stop := make(chan struct{})
+	go func() {
+		select {
+		case <-t.done:
+			cancel()
+		case <-stop:
+		}
+	}()
+
+	var once sync.Once
+	finish := func() {
+		once.Do(func() {
+			close(stop)
+			cancel()
+			t.wg.Done()
+		})
+	}
+
+	resp, err := next.RoundTrip(req.WithContext(ctx))
+	if err != nil || resp.Body == nil {
+		finish()
+		return resp, err
+	}
+
+	resp.Body = &drainBody{ReadCloser: resp.Body, finish: finish}
+	return resp, nil
+}
+
+// CloseIdleConnections pass to wrapped transport
+func (t *drainTransport) CloseIdleConnections() {
+	type closeIdler interface {
+		CloseIdleConnections()
+	}
+	if tr, ok := t.next.(closeIdler); ok {
+		tr.CloseIdleConnections()
+	}
+}
+
+// close reject new requests, stop workers and wait in-flight requests. When ctx is done in-flight requests
+// canceled and close return without wait response bodies not closed by caller.
+func (t *drainTransport) close(ctx context.Context) error {
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return errs.ErrClientClosed
+	}
+	t.closed = true
+	workers := t.workers
+	t.workers = nil
+	t.mu.Unlock()
+
+	var err error
+	for w := range workers {
+		if werr := w.Close(ctx); werr != nil && err == nil {
+			err = werr
+		}
+	}
+
+	drained := make(chan struct{})
+	go func() {
+		t.wg.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return err
+	case <-ctx.Done():
+		close(t.done)
+		return ctx.Err()
+	}
+}
+
+type drainBody struct {
+	io.ReadCloser
+	finish func()
+}
+
+func (b *drainBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.finish()
+	return err
+}
+
+// stopOnClosed stop retrying when client closed
+func stopOnClosed(next retryablehttp.CheckRetry) retryablehttp.CheckRetry {
+	return func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		if errors.Is(err, errs.ErrClientClosed) {
+			return false, err
+		}
+		return next(ctx, resp, err)
+	}
+}
+
+// Shutdown reject new calls with ErrClientClosed, stop upload queues of client and wait in-flight requests
+// (and response bodies) to finish, when ctx done or drain timeout (WithDrainTimeout) passed in-flight
+// requests canceled and Shutdown return error of ctx.
+func (c *Client) Shutdown(ctx context.Context) error {
+	if c.drainTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.drainTimeout)
+		defer cancel()
+	}
+
+	err := c.drain.close(ctx)
+
+	c.client.HTTPClient.CloseIdleConnections()
+
+	return err
+}
+
+// Close stop client, in-flight requests canceled after drain timeout (WithDrainTimeout) or immediately
+// when not set. Use Shutdown for wait in-flight requests.
+func (c *Client) Close() {
+	ctx, cancel := context.WithCancel(context.Background())
+	if c.drainTimeout == 0 {
+		cancel()
+	}
+	defer cancel()
+
+	_ = c.Shutdown(ctx)
+}
@@ -0,0 +1,120 @@
+package irys
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientClose(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tx/tx":
+			// metadata request released by test
+			<-release
+			_, _ = w.Write([]byte(`{"id":"tx"}`))
+		case "/leak":
+			_, _ = w.Write([]byte("data"))
+		default:
+			// others block until canceled
+			<-r.Context().Done()
+		}
+	}))
+	defer srv.Close()
+
+	newClient := func() *Client {
+		c := &Client{client: retryablehttp.NewClient(), gateway: srv.URL}
+		c.client.RetryMax = 0
+		c.drain = newDrainTransport(http.DefaultTransport.(*http.Transport).Clone())
+		c.client.HTTPClient.Transport = c.drain
+		c.client.CheckRetry = stopOnClosed(c.client.CheckRetry)
+		return c
+	}
+
+	t.Run("drain", func(t *testing.T) {
+		c := newClient()
+
+		errCh := make(chan error, 1)
+		go func() {
+			_, err := c.GetMetaData(context.Background(), "tx")
+			errCh <- err
+		}()
+		time.Sleep(50 * time.Millisecond)
+
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			close(release)
+		}()
+
+		require.NoError(t, c.Shutdown(context.Background()))
+		require.NoError(t, <-errCh)
+
+		_, err := c.GetMetaData(context.Background(), "tx")
+		require.ErrorIs(t, err, errs.ErrClientClosed)
+		require.ErrorIs(t, c.Shutdown(context.Background()), errs.ErrClientClosed)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		c := newClient()
+		c.drainTimeout = 50 * time.Millisecond
+
+		errCh := make(chan error, 1)
+		go func() {
+			_, err := c.Exists(context.Background(), "tx")
+			errCh <- err
+		}()
+		time.Sleep(50 * time.Millisecond)
+
+		require.ErrorIs(t, c.Shutdown(context.Background()), context.DeadlineExceeded)
+		require.Error(t, <-errCh)
+	})
+
+	t.Run("leaked body", func(t *testing.T) {
+		c := newClient()
+
+		// body never closed by caller
+		resp, err := c.client.Get(srv.URL + "/leak")
+		require.NoError(t, err)
+		require.NotNil(t, resp.Body)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		done := make(chan error, 1)
+		go func() {
+			done <- c.Shutdown(ctx)
+		}()
+
+		select {
+		case err := <-done:
+			require.ErrorIs(t, err, context.DeadlineExceeded)
+		case <-time.After(time.Second):
+			t.Fatal("shutdown not returned after ctx done")
+		}
+	})
+
+	t.Run("close", func(t *testing.T) {
+		c := newClient()
+
+		q, err := c.NewUploadQueue(t.TempDir(), 1, 1)
+		require.NoError(t, err)
+
+		c.Close()
+
+		_, err = q.Enqueue([]byte("data"))
+		require.ErrorIs(t, err, errs.ErrQueueClosed)
+
+		_, err = c.Exists(context.Background(), "tx")
+		require.ErrorIs(t, err, errs.ErrClientClosed)
+
+		_, err = c.NewUploadQueue(t.TempDir(), 1, 1)
+		require.ErrorIs(t, err, errs.ErrClientClosed)
+	})
+}
@@ -0,0 +1,49 @@
+package irys
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// Codec marshal and unmarshal json of node, gateway and arweave requests, faster implementation
+// (e.g. json-iterator or segmentio/encoding) set by WithCodec for indexers decoding many transactions.
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// StdCodec is Codec of encoding/json, used by default
+type StdCodec struct{}
+
+var _ Codec = StdCodec{}
+
+func (StdCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (StdCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+// decodeBody decode json body with codec, nil codec stream body to encoding/json decoder
+func decodeBody[T any](codec Codec, body io.Reader) (T, error) {
+	var resp T
+	if codec == nil {
+		d := json.NewDecoder(body)
+		return resp, d.Decode(&resp)
+	}
+
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return resp, err
+	}
+	return resp, codec.Unmarshal(b, &resp)
+}
+
+// marshal encode request body with codec of client
+func (c *Client) marshal(v any) ([]byte, error) {
+	if c.codec == nil {
+		return json.Marshal(v)
+	}
+	return c.codec.Marshal(v)
+}
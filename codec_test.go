@@ -0,0 +1,36 @@
+package irys
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Ja7ad/irys/types"
+	"github.com/stretchr/testify/require"
+)
+
+type countingCodec struct {
+	StdCodec
+	unmarshals int
+}
+
+func (c *countingCodec) Unmarshal(data []byte, v any) error {
+	c.unmarshals++
+	return c.StdCodec.Unmarshal(data, v)
+}
+
+func TestDecodeBody(t *testing.T) {
+	body := `{"id":"tx1","address":"0x1"}`
+
+	tx, err := decodeBody[types.Transaction](nil, strings.NewReader(body))
+	require.NoError(t, err)
+	require.Equal(t, "tx1", tx.ID)
+
+	codec := new(countingCodec)
+	c := &Client{}
+	WithCodec(codec)(c)
+
+	tx, err = decodeBody[types.Transaction](c.codec, strings.NewReader(body))
+	require.NoError(t, err)
+	require.Equal(t, "tx1", tx.ID)
+	require.Equal(t, 1, codec.unmarshals)
+}
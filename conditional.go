@@ -0,0 +1,50 @@
+package irys
+
+import (
+	"net/http"
+	"time"
+)
+
+type downloadOptions struct {
+	ifNoneMatch     string
+	ifModifiedSince time.Time
+}
+
+// DownloadOption customize download request, e.g. conditional download of edge cache revalidation
+type DownloadOption func(opts *downloadOptions)
+
+// WithIfNoneMatch download only when etag of content not match etag, otherwise Download return ErrNotModified
+func WithIfNoneMatch(etag string) DownloadOption {
+	return func(opts *downloadOptions) {
+		opts.ifNoneMatch = etag
+	}
+}
+
+// WithIfModifiedSince download only when content modified after t, otherwise Download return ErrNotModified
+func WithIfModifiedSince(t time.Time) DownloadOption {
+	return func(opts *downloadOptions) {
+		opts.ifModifiedSince = t
+	}
+}
+
+func newDownloadOptions(opts ...DownloadOption) *downloadOptions {
+	options := new(downloadOptions)
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
+// conditional report download is revalidation of caller content
+func (o *downloadOptions) conditional() bool {
+	return len(o.ifNoneMatch) != 0 || !o.ifModifiedSince.IsZero()
+}
+
+func (o *downloadOptions) setHeaders(req *http.Request) {
+	if len(o.ifNoneMatch) != 0 {
+		req.Header.Set("If-None-Match", o.ifNoneMatch)
+	}
+	if !o.ifModifiedSince.IsZero() {
+		req.Header.Set("If-Modified-Since", o.ifModifiedSince.UTC().Format(http.TimeFormat))
+	}
+}
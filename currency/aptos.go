@@ -0,0 +1,295 @@
+package currency
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/signer"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	_aptos_name   = "aptos"
+	_aptos_chain  = "aptos"
+	_aptos_symbol = "apt"
+
+	// AptosMainnetRPC is default aptos full node rest api
+	AptosMainnetRPC = "https://fullnode.mainnet.aptoslabs.com"
+
+	_aptosMaxGasAmount      = 2000
+	_aptosExpiration        = 10 * time.Minute
+	_aptosSignedTxMediaType = "application/x.aptos.signed_transaction+bcs"
+	_aptosRawTxSalt         = "APTOS::RawTransaction"
+
+	// bcs enum variants
+	_aptosPayloadEntryFunction = 2
+	_aptosAuthenticatorEd25519 = 0
+)
+
+// Transferer is implemented by non-evm currencies which create and submit native transfer
+// of funding transaction by itself, return hash of submitted transaction.
+type Transferer interface {
+	Transfer(ctx context.Context, to string, amount *big.Int) (string, error)
+}
+
+// FeeEstimator is implemented by Transferer currencies which estimate max fee of transfer in atomic unit
+type FeeEstimator interface {
+	EstimateTransferFee(ctx context.Context) (*big.Int, error)
+}
+
+// Addresser is implemented by currencies which address is not evm address of public key
+type Addresser interface {
+	GetAddress() string
+}
+
+// Aptos is aptos currency, data items signed with ed25519 key and funded by aptos_account::transfer
+type Aptos struct {
+	unimplementedEther
+	chain     string
+	symbol    string
+	name      string
+	rpc       string
+	tokenType CurrencyType
+	client    *http.Client
+	signer    *signer.Ed25519Signer
+}
+
+var (
+	_ Currency     = (*Aptos)(nil)
+	_ Transferer   = (*Aptos)(nil)
+	_ FeeEstimator = (*Aptos)(nil)
+	_ Addresser    = (*Aptos)(nil)
+)
+
+// NewAptos create aptos currency from hex private key (32 bytes ed25519 seed) and full node rest api
+// url (e.g. AptosMainnetRPC)
+func NewAptos(privateKey, rpc string) (Currency, error) {
+	return NewAptosWithClient(privateKey, rpc, nil)
+}
+
+// NewAptosWithClient is NewAptos with http client of rest api calls (e.g. with proxy or timeout of
+// application), nil client use client with 30s timeout
+func NewAptosWithClient(privateKey, rpc string, client *http.Client) (Currency, error) {
+	if len(privateKey) == 0 {
+		return nil, errors.ErrPrivateKeyIsEmpty
+	}
+
+	s, err := signer.NewEd25519Signer(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Aptos{
+		chain:     _aptos_chain,
+		symbol:    _aptos_symbol,
+		name:      _aptos_name,
+		rpc:       strings.TrimSuffix(rpc, "/"),
+		tokenType: APTOS,
+		client:    restClient(client),
+		signer:    s,
+	}, nil
+}
+
+func (a *Aptos) GetChain() string {
+	return a.chain
+}
+
+func (a *Aptos) GetSymbol() string {
+	return a.symbol
+}
+
+func (a *Aptos) GetName() string {
+	return a.name
+}
+
+func (a *Aptos) GetSinger() signer.Signer {
+	return a.signer
+}
+
+func (a *Aptos) GetRPCAddr() string {
+	return a.rpc
+}
+
+func (a *Aptos) GetType() CurrencyType {
+	return a.tokenType
+}
+
+// GetAddress return 0x hex aptos account address
+func (a *Aptos) GetAddress() string {
+	return signer.AptosAddress(a.signer.Owner)
+}
+
+// EstimateTransferFee return max fee of transfer in octas, max gas amount at estimated gas unit price
+func (a *Aptos) EstimateTransferFee(ctx context.Context) (*big.Int, error) {
+	var gas struct {
+		GasEstimate uint64 `json:"gas_estimate"`
+	}
+	if err := getJSON(ctx, a.client, a.rpc+"/v1/estimate_gas_price", &gas); err != nil {
+		return nil, err
+	}
+	return new(big.Int).Mul(new(big.Int).SetUint64(gas.GasEstimate), big.NewInt(_aptosMaxGasAmount)), nil
+}
+
+// Transfer send amount of octas to address by 0x1::aptos_account::transfer
+func (a *Aptos) Transfer(ctx context.Context, to string, amount *big.Int) (string, error) {
+	if amount == nil || amount.Sign() <= 0 || !amount.IsUint64() {
+		return "", errors.ErrInvalidAmount
+	}
+
+	sender, err := aptosAccountAddress(a.GetAddress())
+	if err != nil {
+		return "", err
+	}
+
+	receiver, err := aptosAccountAddress(to)
+	if err != nil {
+		return "", err
+	}
+
+	var ledger struct {
+		ChainID uint8 `json:"chain_id"`
+	}
+	if err := getJSON(ctx, a.client, a.rpc+"/v1", &ledger); err != nil {
+		return "", err
+	}
+
+	var account struct {
+		SequenceNumber string `json:"sequence_number"`
+	}
+	if err := getJSON(ctx, a.client, fmt.Sprintf("%s/v1/accounts/%s", a.rpc, a.GetAddress()), &account); err != nil {
+		return "", err
+	}
+
+	sequence, err := strconv.ParseUint(account.SequenceNumber, 10, 64)
+	if err != nil {
+		return "", err
+	}
+
+	var gas struct {
+		GasEstimate uint64 `json:"gas_estimate"`
+	}
+	if err := getJSON(ctx, a.client, a.rpc+"/v1/estimate_gas_price", &gas); err != nil {
+		return "", err
+	}
+
+	raw := aptosTransferTx(aptosRawTx{
+		sender:         sender,
+		sequenceNumber: sequence,
+		receiver:       receiver,
+		amount:         amount.Uint64(),
+		maxGasAmount:   _aptosMaxGasAmount,
+		gasUnitPrice:   gas.GasEstimate,
+		expiration:     uint64(time.Now().Add(_aptosExpiration).Unix()),
+		chainID:        ledger.ChainID,
+	})
+
+	signed, err := a.signRawTx(raw)
+	if err != nil {
+		return "", err
+	}
+
+	var pending struct {
+		Hash string `json:"hash"`
+	}
+	if err := postJSON(ctx, a.client, a.rpc+"/v1/transactions", _aptosSignedTxMediaType, bytes.NewReader(signed), &pending); err != nil {
+		return "", err
+	}
+
+	return pending.Hash, nil
+}
+
+// signRawTx sign bcs raw transaction and return bcs of signed transaction with ed25519 authenticator
+func (a *Aptos) signRawTx(raw []byte) ([]byte, error) {
+	salt := sha3.Sum256([]byte(_aptosRawTxSalt))
+	signature, err := a.signer.Sign(append(salt[:], raw...))
+	if err != nil {
+		return nil, err
+	}
+
+	signed := append([]byte{}, raw...)
+	signed = appendUleb128(signed, _aptosAuthenticatorEd25519)
+	signed = appendBcsBytes(signed, a.signer.Owner)
+	signed = appendBcsBytes(signed, signature)
+	return signed, nil
+}
+
+type aptosRawTx struct {
+	sender         [32]byte
+	sequenceNumber uint64
+	receiver       [32]byte
+	amount         uint64
+	maxGasAmount   uint64
+	gasUnitPrice   uint64
+	expiration     uint64
+	chainID        uint8
+}
+
+// aptosTransferTx encode bcs raw transaction of entry function 0x1::aptos_account::transfer(receiver, amount)
+func aptosTransferTx(tx aptosRawTx) []byte {
+	var framework [32]byte
+	framework[31] = 0x01
+
+	buf := append([]byte{}, tx.sender[:]...)
+	buf = appendUint64LE(buf, tx.sequenceNumber)
+
+	buf = appendUleb128(buf, _aptosPayloadEntryFunction)
+	buf = append(buf, framework[:]...)
+	buf = appendBcsBytes(buf, []byte("aptos_account"))
+	buf = appendBcsBytes(buf, []byte("transfer"))
+	buf = appendUleb128(buf, 0) // type arguments
+	buf = appendUleb128(buf, 2) // arguments, each is bcs bytes of value
+	buf = appendBcsBytes(buf, tx.receiver[:])
+	buf = appendBcsBytes(buf, appendUint64LE(nil, tx.amount))
+
+	buf = appendUint64LE(buf, tx.maxGasAmount)
+	buf = appendUint64LE(buf, tx.gasUnitPrice)
+	buf = appendUint64LE(buf, tx.expiration)
+	return append(buf, tx.chainID)
+}
+
+// aptosAccountAddress parse 0x hex address, short addresses (e.g. 0x1) are left padded
+func aptosAccountAddress(address string) ([32]byte, error) {
+	var out [32]byte
+	s := strings.TrimPrefix(address, "0x")
+	if len(s) == 0 || len(s) > 64 {
+		return out, errors.ErrInvalidAddress
+	}
+	if len(s)%2 == 1 {
+		s = "0" + s
+	}
+
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return out, errors.ErrInvalidAddress
+	}
+	copy(out[32-len(b):], b)
+	return out, nil
+}
+
+func appendUint64LE(buf []byte, v uint64) []byte {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	return append(buf, b[:]...)
+}
+
+func appendUleb128(buf []byte, v uint64) []byte {
+	for v >= 0x80 {
+		buf = append(buf, byte(v)|0x80)
+		v >>= 7
+	}
+	return append(buf, byte(v))
+}
+
+// appendBcsBytes append length prefixed bytes, bcs encoding of vector<u8> and string
+func appendBcsBytes(buf, b []byte) []byte {
+	return append(appendUleb128(buf, uint64(len(b))), b...)
+}
@@ -0,0 +1,111 @@
+package currency
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+)
+
+const _aptosTestKey = "c5338cd251c22daa8c9c9cc94f498cc8a5c7e1d2e75287a5dda91096fe64efa5"
+
+func TestAptosAddress(t *testing.T) {
+	c, err := NewAptos(_aptosTestKey, AptosMainnetRPC)
+	require.NoError(t, err)
+	require.Equal(t, APTOS, c.GetType())
+	require.Equal(t, "0x978c213990c4833df71548df7ce49d54c759d6b6d932de22b24d56060b7af2aa", c.(Addresser).GetAddress())
+
+	address, err := aptosAccountAddress("0x1")
+	require.NoError(t, err)
+	require.Equal(t, byte(1), address[31])
+	require.Equal(t, make([]byte, 31), address[:31])
+
+	_, err = aptosAccountAddress("0xzz")
+	require.ErrorIs(t, err, errs.ErrInvalidAddress)
+}
+
+func TestAptosClient(t *testing.T) {
+	c, err := NewAptos(_aptosTestKey, "")
+	require.NoError(t, err)
+	require.Equal(t, _defaultRestTimeout, c.(*Aptos).client.Timeout)
+
+	client := &http.Client{Timeout: time.Second}
+	c, err = NewAptosWithClient(_aptosTestKey, "", client)
+	require.NoError(t, err)
+	require.Same(t, client, c.(*Aptos).client)
+}
+
+func TestAppendUleb128(t *testing.T) {
+	require.Equal(t, []byte{0x00}, appendUleb128(nil, 0))
+	require.Equal(t, []byte{0x7f}, appendUleb128(nil, 127))
+	require.Equal(t, []byte{0x80, 0x01}, appendUleb128(nil, 128))
+	require.Equal(t, []byte{0xe5, 0x8e, 0x26}, appendUleb128(nil, 624485))
+}
+
+func TestAptosTransfer(t *testing.T) {
+	c, err := NewAptos(_aptosTestKey, "")
+	require.NoError(t, err)
+	a := c.(*Aptos)
+
+	var submitted []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"chain_id": 1})
+		case "/v1/accounts/" + a.GetAddress():
+			_ = json.NewEncoder(w).Encode(map[string]any{"sequence_number": "7"})
+		case "/v1/estimate_gas_price":
+			_ = json.NewEncoder(w).Encode(map[string]any{"gas_estimate": 100})
+		case "/v1/transactions":
+			require.Equal(t, _aptosSignedTxMediaType, r.Header.Get("Content-Type"))
+			submitted, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusAccepted)
+			_ = json.NewEncoder(w).Encode(map[string]any{"hash": "0xabc"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	a.rpc = srv.URL
+
+	hash, err := a.Transfer(context.Background(), "0x2", big.NewInt(1000))
+	require.NoError(t, err)
+	require.Equal(t, "0xabc", hash)
+
+	// raw transaction is followed by ed25519 authenticator with public key and signature
+	authLen := 1 + 1 + ed25519.PublicKeySize + 1 + ed25519.SignatureSize
+	require.Greater(t, len(submitted), authLen)
+	raw, auth := submitted[:len(submitted)-authLen], submitted[len(submitted)-authLen:]
+
+	sender, err := aptosAccountAddress(a.GetAddress())
+	require.NoError(t, err)
+	require.Equal(t, sender[:], raw[:32])
+	require.Equal(t, uint64(7), binary.LittleEndian.Uint64(raw[32:40]))
+	require.True(t, bytes.Contains(raw, []byte("aptos_account")))
+	require.Equal(t, byte(1), raw[len(raw)-1])
+
+	receiver, err := aptosAccountAddress("0x2")
+	require.NoError(t, err)
+	require.True(t, bytes.Contains(raw, append([]byte{32}, receiver[:]...)))
+	require.True(t, bytes.Contains(raw, appendUint64LE([]byte{8}, 1000)))
+
+	require.Equal(t, byte(_aptosAuthenticatorEd25519), auth[0])
+	require.Equal(t, []byte(a.signer.Owner), auth[2:2+ed25519.PublicKeySize])
+
+	salt := sha3.Sum256([]byte(_aptosRawTxSalt))
+	require.True(t, ed25519.Verify(a.signer.Owner, append(salt[:], raw...), auth[len(auth)-ed25519.SignatureSize:]))
+
+	_, err = a.Transfer(context.Background(), "0x2", big.NewInt(0))
+	require.ErrorIs(t, err, errs.ErrInvalidAmount)
+}
@@ -0,0 +1,26 @@
+package currency
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+)
+
+// contractBackend adapt bind.ContractBackend to EthBackend with fixed chain id
+type contractBackend struct {
+	bind.ContractBackend
+	chainID *big.Int
+}
+
+// WithChainID adapt bind.ContractBackend (which has not ChainID) to EthBackend for NewWithBackend
+func WithChainID(backend bind.ContractBackend, chainID *big.Int) EthBackend {
+	return &contractBackend{
+		ContractBackend: backend,
+		chainID:         new(big.Int).Set(chainID),
+	}
+}
+
+func (b *contractBackend) ChainID(_ context.Context) (*big.Int, error) {
+	return new(big.Int).Set(b.chainID), nil
+}
@@ -0,0 +1,59 @@
+package currency_test
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/Ja7ad/irys/currency"
+	"github.com/Ja7ad/irys/errors"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithChainID(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	sim := backends.NewSimulatedBackend(core.GenesisAlloc{}, 30000000)
+	defer sim.Close()
+
+	backend := currency.WithChainID(sim, big.NewInt(137))
+
+	chainID, err := backend.ChainID(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(137), chainID)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), backend)
+	require.NoError(t, err)
+	require.Equal(t, backend, matic.GetRPCClient())
+
+	token, err := currency.NewERC20WithBackend("usdc-polygon", "0x3c499c542cEF5E3811e1192ce70d8cC03d5c3359",
+		hex.EncodeToString(crypto.FromECDSA(key)), backend)
+	require.NoError(t, err)
+	require.Equal(t, backend, token.GetRPCClient())
+}
+
+func TestChainIDValidation(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	sim := backends.NewSimulatedBackend(core.GenesisAlloc{}, 30000000)
+	defer sim.Close()
+
+	base, err := currency.NewWithBackend(currency.BASE, hex.EncodeToString(crypto.FromECDSA(key)),
+		currency.WithChainID(sim, big.NewInt(8453)))
+	require.NoError(t, err)
+	require.Equal(t, "base-eth", base.GetName())
+
+	_, err = currency.NewWithBackend(currency.ARBITRUM, hex.EncodeToString(crypto.FromECDSA(key)),
+		currency.WithChainID(sim, big.NewInt(137)))
+	require.ErrorIs(t, err, errors.ErrChainIDMismatch)
+
+	config, ok := currency.ChainConfigOf(currency.ARBITRUM)
+	require.True(t, ok)
+	require.NotZero(t, config.GasLimitBuffer)
+}
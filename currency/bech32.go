@@ -0,0 +1,124 @@
+package currency
+
+import (
+	"strings"
+
+	"github.com/Ja7ad/irys/errors"
+)
+
+// bech32 (BIP-173) encoding of cosmos-sdk addresses
+
+const _bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+var _bech32Generator = [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+
+func bech32Polymod(values []byte) uint32 {
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= _bech32Generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+func bech32HrpExpand(hrp string) []byte {
+	out := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]>>5)
+	}
+	out = append(out, 0)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]&31)
+	}
+	return out
+}
+
+// convertBits regroup bits of data from frombits to tobits groups
+func convertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, error) {
+	var (
+		acc  uint32
+		bits uint
+		out  []byte
+		mask = uint32(1)<<toBits - 1
+	)
+	for _, v := range data {
+		if uint32(v)>>fromBits != 0 {
+			return nil, errors.ErrInvalidAddress
+		}
+		acc = acc<<fromBits | uint32(v)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			out = append(out, byte(acc>>bits&mask))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			out = append(out, byte(acc<<(toBits-bits)&mask))
+		}
+	} else if bits >= fromBits || acc<<(toBits-bits)&mask != 0 {
+		return nil, errors.ErrInvalidAddress
+	}
+	return out, nil
+}
+
+func encodeBech32(hrp string, data []byte) (string, error) {
+	values, err := convertBits(data, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	return encodeBech32Words(hrp, values), nil
+}
+
+func encodeBech32Words(hrp string, values []byte) string {
+	polymod := bech32Polymod(append(append(bech32HrpExpand(hrp), values...), 0, 0, 0, 0, 0, 0)) ^ 1
+
+	var sb strings.Builder
+	sb.WriteString(hrp)
+	sb.WriteByte('1')
+	for _, v := range values {
+		sb.WriteByte(_bech32Charset[v])
+	}
+	for i := 0; i < 6; i++ {
+		sb.WriteByte(_bech32Charset[(polymod>>uint(5*(5-i)))&31])
+	}
+	return sb.String()
+}
+
+// decodeBech32 decode and verify checksum of bech32 string, return hrp and data bytes
+func decodeBech32(s string) (string, []byte, error) {
+	if strings.ToLower(s) != s && strings.ToUpper(s) != s {
+		return "", nil, errors.ErrInvalidAddress
+	}
+	s = strings.ToLower(s)
+
+	sep := strings.LastIndexByte(s, '1')
+	if sep < 1 || sep+7 > len(s) || len(s) > 90 {
+		return "", nil, errors.ErrInvalidAddress
+	}
+
+	hrp := s[:sep]
+	values := make([]byte, 0, len(s)-sep-1)
+	for i := sep + 1; i < len(s); i++ {
+		v := strings.IndexByte(_bech32Charset, s[i])
+		if v < 0 {
+			return "", nil, errors.ErrInvalidAddress
+		}
+		values = append(values, byte(v))
+	}
+
+	if bech32Polymod(append(bech32HrpExpand(hrp), values...)) != 1 {
+		return "", nil, errors.ErrInvalidAddress
+	}
+
+	data, err := convertBits(values[:len(values)-6], 5, 8, false)
+	if err != nil {
+		return "", nil, err
+	}
+	return hrp, data, nil
+}
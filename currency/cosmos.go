@@ -0,0 +1,290 @@
+package currency
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/signer"
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/crypto/ripemd160"
+)
+
+// CosmosDerivationPath is BIP-44 path of first cosmos-sdk account (coin type 118), use with DerivePrivateKey
+const CosmosDerivationPath = "m/44'/118'/0'/0/0"
+
+const (
+	_cosmosMsgSendType = "/cosmos.bank.v1beta1.MsgSend"
+	_cosmosPubKeyType  = "/cosmos.crypto.secp256k1.PubKey"
+	_cosmosSignDirect  = 1
+	_cosmosBroadcast   = "BROADCAST_MODE_SYNC"
+)
+
+// CosmosChain is config of cosmos-sdk chain accepted by irys
+type CosmosChain struct {
+	Name     string // currency name on irys node
+	Symbol   string
+	ChainID  string
+	Prefix   string // bech32 address prefix
+	Denom    string // atomic unit denom
+	Decimals int
+	GasLimit uint64
+	Fee      uint64 // fee of transfer in denom
+}
+
+// KyveChain is kyve mainnet config
+var KyveChain = CosmosChain{
+	Name:     "kyve",
+	Symbol:   "kyve",
+	ChainID:  "kyve-1",
+	Prefix:   "kyve",
+	Denom:    "ukyve",
+	Decimals: 6,
+	GasLimit: 200000,
+	Fee:      4000, // 0.02ukyve min gas price
+}
+
+// Cosmos is currency of cosmos-sdk chain, data items signed with secp256k1 key like evm currencies
+// and funded by bank MsgSend broadcast to chain rest api (lcd)
+type Cosmos struct {
+	unimplementedEther
+	chain      CosmosChain
+	rpc        string
+	client     *http.Client
+	privateKey *ecdsa.PrivateKey
+	signer     *signer.EthereumSigner
+}
+
+var (
+	_ Currency     = (*Cosmos)(nil)
+	_ Transferer   = (*Cosmos)(nil)
+	_ FeeEstimator = (*Cosmos)(nil)
+	_ Addresser    = (*Cosmos)(nil)
+)
+
+// NewCosmos create currency of cosmos-sdk chain from hex secp256k1 private key and rest api (lcd) url
+func NewCosmos(chain CosmosChain, privateKey, rpc string) (Currency, error) {
+	return NewCosmosWithClient(chain, privateKey, rpc, nil)
+}
+
+// NewCosmosWithClient is NewCosmos with http client of rest api calls (e.g. with proxy or timeout of
+// application), nil client use client with 30s timeout
+func NewCosmosWithClient(chain CosmosChain, privateKey, rpc string, client *http.Client) (Currency, error) {
+	if len(privateKey) == 0 {
+		return nil, errors.ErrPrivateKeyIsEmpty
+	}
+
+	privateKey = strings.TrimPrefix(privateKey, _0x_prefix)
+	s, err := signer.NewEthereumSigner(_0x_prefix + privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Cosmos{
+		chain:      chain,
+		rpc:        strings.TrimSuffix(rpc, "/"),
+		client:     restClient(client),
+		privateKey: s.PrivateKey,
+		signer:     s,
+	}, nil
+}
+
+// NewKyve create kyve currency from hex private key and rest api (lcd) url
+func NewKyve(privateKey, rpc string) (Currency, error) {
+	return NewCosmos(KyveChain, privateKey, rpc)
+}
+
+func (c *Cosmos) GetChain() string {
+	return c.chain.ChainID
+}
+
+func (c *Cosmos) GetSymbol() string {
+	return c.chain.Symbol
+}
+
+func (c *Cosmos) GetName() string {
+	return c.chain.Name
+}
+
+func (c *Cosmos) GetSinger() signer.Signer {
+	return c.signer
+}
+
+func (c *Cosmos) GetRPCAddr() string {
+	return c.rpc
+}
+
+func (c *Cosmos) GetType() CurrencyType {
+	return COSMOS
+}
+
+func (c *Cosmos) GetDecimals() int {
+	return c.chain.Decimals
+}
+
+func (c *Cosmos) GetPrivateKey() *ecdsa.PrivateKey {
+	return c.privateKey
+}
+
+func (c *Cosmos) GetPublicKey() *ecdsa.PublicKey {
+	return &c.privateKey.PublicKey
+}
+
+// GetAddress return bech32 account address of chain prefix
+func (c *Cosmos) GetAddress() string {
+	address, _ := CosmosAddress(c.chain.Prefix, &c.privateKey.PublicKey)
+	return address
+}
+
+// EstimateTransferFee return fixed fee of transfer in denom of chain config
+func (c *Cosmos) EstimateTransferFee(_ context.Context) (*big.Int, error) {
+	return new(big.Int).SetUint64(c.chain.Fee), nil
+}
+
+// Transfer send amount of denom to address by bank MsgSend signed in direct mode
+func (c *Cosmos) Transfer(ctx context.Context, to string, amount *big.Int) (string, error) {
+	if amount == nil || amount.Sign() <= 0 {
+		return "", errors.ErrInvalidAmount
+	}
+
+	var account struct {
+		Account struct {
+			AccountNumber string `json:"account_number"`
+			Sequence      string `json:"sequence"`
+		} `json:"account"`
+	}
+	from := c.GetAddress()
+	if err := getJSON(ctx, c.client, fmt.Sprintf("%s/cosmos/auth/v1beta1/accounts/%s", c.rpc, from), &account); err != nil {
+		return "", err
+	}
+
+	accountNumber, err := strconv.ParseUint(account.Account.AccountNumber, 10, 64)
+	if err != nil {
+		return "", err
+	}
+
+	sequence, err := strconv.ParseUint(account.Account.Sequence, 10, 64)
+	if err != nil {
+		return "", err
+	}
+
+	txBytes, err := c.signSend(from, to, amount, accountNumber, sequence)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"tx_bytes": base64.StdEncoding.EncodeToString(txBytes),
+		"mode":     _cosmosBroadcast,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var broadcast struct {
+		TxResponse struct {
+			TxHash string `json:"txhash"`
+			Code   uint32 `json:"code"`
+			RawLog string `json:"raw_log"`
+		} `json:"tx_response"`
+	}
+	if err := postJSON(ctx, c.client, c.rpc+"/cosmos/tx/v1beta1/txs", "application/json", bytes.NewReader(body), &broadcast); err != nil {
+		return "", err
+	}
+
+	if broadcast.TxResponse.Code != 0 {
+		return "", fmt.Errorf("%w: code %d: %s", errors.ErrChainTxFailed, broadcast.TxResponse.Code, broadcast.TxResponse.RawLog)
+	}
+
+	return broadcast.TxResponse.TxHash, nil
+}
+
+// signSend build protobuf TxRaw of MsgSend, signature is over sha256 of SignDoc
+func (c *Cosmos) signSend(from, to string, amount *big.Int, accountNumber, sequence uint64) ([]byte, error) {
+	if _, _, err := decodeBech32(to); err != nil {
+		return nil, err
+	}
+
+	msg := appendProtoString(nil, 1, from)
+	msg = appendProtoString(msg, 2, to)
+	msg = appendProtoBytes(msg, 3, cosmosCoin(c.chain.Denom, amount))
+
+	body := appendProtoBytes(nil, 1, protoAny(_cosmosMsgSendType, msg))
+
+	pubKey := appendProtoBytes(nil, 1, crypto.CompressPubkey(&c.privateKey.PublicKey))
+	single := appendProtoUint(nil, 1, _cosmosSignDirect)
+	signerInfo := appendProtoBytes(nil, 1, protoAny(_cosmosPubKeyType, pubKey))
+	signerInfo = appendProtoBytes(signerInfo, 2, appendProtoBytes(nil, 1, single))
+	signerInfo = appendProtoUint(signerInfo, 3, sequence)
+
+	fee := appendProtoBytes(nil, 1, cosmosCoin(c.chain.Denom, new(big.Int).SetUint64(c.chain.Fee)))
+	fee = appendProtoUint(fee, 2, c.chain.GasLimit)
+
+	authInfo := appendProtoBytes(nil, 1, signerInfo)
+	authInfo = appendProtoBytes(authInfo, 2, fee)
+
+	signDoc := appendProtoBytes(nil, 1, body)
+	signDoc = appendProtoBytes(signDoc, 2, authInfo)
+	signDoc = appendProtoString(signDoc, 3, c.chain.ChainID)
+	signDoc = appendProtoUint(signDoc, 4, accountNumber)
+
+	hash := sha256.Sum256(signDoc)
+	signature, err := crypto.Sign(hash[:], c.privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := appendProtoBytes(nil, 1, body)
+	raw = appendProtoBytes(raw, 2, authInfo)
+	raw = appendProtoBytes(raw, 3, signature[:64]) // r || s without recovery id
+	return raw, nil
+}
+
+// CosmosAddress return bech32 address of secp256k1 public key, ripemd160 of sha256 of compressed key
+func CosmosAddress(prefix string, pub *ecdsa.PublicKey) (string, error) {
+	sha := sha256.Sum256(crypto.CompressPubkey(pub))
+	h := ripemd160.New()
+	h.Write(sha[:])
+	return encodeBech32(prefix, h.Sum(nil))
+}
+
+func cosmosCoin(denom string, amount *big.Int) []byte {
+	coin := appendProtoString(nil, 1, denom)
+	return appendProtoString(coin, 2, amount.String())
+}
+
+// protoAny encode google.protobuf.Any
+func protoAny(typeURL string, value []byte) []byte {
+	return appendProtoBytes(appendProtoString(nil, 1, typeURL), 2, value)
+}
+
+// appendProtoUint append varint field, zero value is omitted like proto3 encoder
+func appendProtoUint(buf []byte, field int, v uint64) []byte {
+	if v == 0 {
+		return buf
+	}
+	buf = appendUleb128(buf, uint64(field)<<3)
+	return appendUleb128(buf, v)
+}
+
+// appendProtoBytes append length delimited field, empty value is omitted like proto3 encoder
+func appendProtoBytes(buf []byte, field int, b []byte) []byte {
+	if len(b) == 0 {
+		return buf
+	}
+	buf = appendUleb128(buf, uint64(field)<<3|2)
+	return appendBcsBytes(buf, b)
+}
+
+func appendProtoString(buf []byte, field int, s string) []byte {
+	return appendProtoBytes(buf, field, []byte(s))
+}
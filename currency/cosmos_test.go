@@ -0,0 +1,156 @@
+package currency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBech32(t *testing.T) {
+	// BIP-173 test vectors
+	words := make([]byte, 32)
+	for i := range words {
+		words[i] = byte(i)
+	}
+	require.Equal(t, "abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw", encodeBech32Words("abcdef", words))
+	require.Equal(t, "a12uel5l", encodeBech32Words("a", nil))
+
+	for _, valid := range []string{
+		"A12UEL5L",
+		"an83characterlonghumanreadablepartthatcontainsthenumber1andtheexcludedcharactersbio1tt5tgs",
+		"split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w",
+	} {
+		_, _, err := decodeBech32(valid)
+		require.NoError(t, err, valid)
+	}
+
+	for _, invalid := range []string{
+		"pzry9x0s0muk",
+		"1pzry9x0s0muk",
+		"x1b4n0q5v",
+		"li1dgmt3",
+		"A1G7SGD8",
+		"a12UEL5L",
+	} {
+		_, _, err := decodeBech32(invalid)
+		require.ErrorIs(t, err, errs.ErrInvalidAddress, invalid)
+	}
+
+	address, err := encodeBech32("kyve", []byte{0x01, 0x02, 0xff})
+	require.NoError(t, err)
+	hrp, data, err := decodeBech32(address)
+	require.NoError(t, err)
+	require.Equal(t, "kyve", hrp)
+	require.Equal(t, []byte{0x01, 0x02, 0xff}, data)
+}
+
+func TestCosmosTransfer(t *testing.T) {
+	c, err := NewKyve("f4a2b939592564feb35ab10a8e04f6f2fe0943579fb3c9c33505298978b74893", "")
+	require.NoError(t, err)
+	require.Equal(t, COSMOS, c.GetType())
+	require.Equal(t, "kyve", c.GetName())
+
+	k := c.(*Cosmos)
+	from := k.GetAddress()
+	require.True(t, strings.HasPrefix(from, "kyve1"))
+
+	to, err := encodeBech32("kyve", make([]byte, 20))
+	require.NoError(t, err)
+
+	var txBytes []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cosmos/auth/v1beta1/accounts/" + from:
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"account": map[string]any{"account_number": "42", "sequence": "3"},
+			})
+		case "/cosmos/tx/v1beta1/txs":
+			var req struct {
+				TxBytes string `json:"tx_bytes"`
+				Mode    string `json:"mode"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, _cosmosBroadcast, req.Mode)
+			txBytes, err = base64.StdEncoding.DecodeString(req.TxBytes)
+			require.NoError(t, err)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"tx_response": map[string]any{"txhash": "ABCD", "code": 0},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	k.rpc = srv.URL
+
+	hash, err := k.Transfer(context.Background(), to, big.NewInt(1500))
+	require.NoError(t, err)
+	require.Equal(t, "ABCD", hash)
+
+	raw := protoFields(t, txBytes)
+	require.Len(t, raw[1], 1)
+	require.Len(t, raw[2], 1)
+	require.Len(t, raw[3], 1)
+
+	msg := protoFields(t, protoFields(t, protoFields(t, raw[1][0])[1][0])[2][0])
+	require.Equal(t, from, string(msg[1][0]))
+	require.Equal(t, to, string(msg[2][0]))
+	coin := protoFields(t, msg[3][0])
+	require.Equal(t, "ukyve", string(coin[1][0]))
+	require.Equal(t, "1500", string(coin[2][0]))
+
+	signDoc := appendProtoBytes(nil, 1, raw[1][0])
+	signDoc = appendProtoBytes(signDoc, 2, raw[2][0])
+	signDoc = appendProtoString(signDoc, 3, KyveChain.ChainID)
+	signDoc = appendProtoUint(signDoc, 4, 42)
+	digest := sha256.Sum256(signDoc)
+	require.True(t, crypto.VerifySignature(crypto.CompressPubkey(k.GetPublicKey()), digest[:], raw[3][0]))
+
+	_, err = k.Transfer(context.Background(), "kyve1invalid", big.NewInt(1))
+	require.ErrorIs(t, err, errs.ErrInvalidAddress)
+}
+
+// protoFields decode length delimited fields of protobuf message by field number
+func protoFields(t *testing.T, b []byte) map[int][][]byte {
+	t.Helper()
+
+	readVarint := func() uint64 {
+		var v uint64
+		for shift := uint(0); ; shift += 7 {
+			require.NotEmpty(t, b)
+			c := b[0]
+			b = b[1:]
+			v |= uint64(c&0x7f) << shift
+			if c < 0x80 {
+				return v
+			}
+		}
+	}
+
+	fields := map[int][][]byte{}
+	for len(b) > 0 {
+		key := readVarint()
+		switch key & 7 {
+		case 0:
+			readVarint()
+		case 2:
+			n := int(readVarint())
+			require.LessOrEqual(t, n, len(b))
+			fields[int(key>>3)] = append(fields[int(key>>3)], b[:n])
+			b = b[n:]
+		default:
+			t.Fatalf("unexpected wire type %d", key&7)
+		}
+	}
+	return fields
+}
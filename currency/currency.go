@@ -1,10 +1,14 @@
 package currency
 
 import (
+	"context"
 	"crypto/ecdsa"
+	"math/big"
 
 	"github.com/Ja7ad/irys/signer"
-	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 const _0x_prefix = "0x"
@@ -19,6 +23,10 @@ const (
 	AVALANCHE
 	FANTOM
 	ARWEAVE
+	ERC20
+	APTOS
+	COSMOS
+	BASE
 )
 
 type Currency interface {
@@ -31,17 +39,32 @@ type Currency interface {
 	GetType() CurrencyType
 }
 
+// EthBackend is subset of go-ethereum client used for create and send funding transaction,
+// *ethclient.Client and simulated backend both implement it.
+type EthBackend interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+}
+
 type Ether interface {
-	GetRPCClient() *ethclient.Client
+	GetRPCClient() EthBackend
 	GetPrivateKey() *ecdsa.PrivateKey
 	GetPublicKey() *ecdsa.PublicKey
 }
 
+// Token is erc-20 currency funded by transfer on token contract
+type Token interface {
+	GetContractAddress() string
+}
+
 type unimplementedEther struct{}
 
 var _ Ether = (*unimplementedEther)(nil)
 
-func (u unimplementedEther) GetRPCClient() *ethclient.Client {
+func (u unimplementedEther) GetRPCClient() EthBackend {
 	panic("implement me")
 }
 
@@ -0,0 +1,87 @@
+package currency
+
+import (
+	"crypto/ecdsa"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/signer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+type ERC20Token struct {
+	Ethereum
+	contract string
+}
+
+var _ Token = (*ERC20Token)(nil)
+
+// NewERC20 create erc-20 token currency object (e.g. usdc-polygon, usdc-eth), tokenName is name of token in irys node
+func NewERC20(tokenName, contractAddr, privateKey, rpc string) (Currency, error) {
+	if len(privateKey) == 0 {
+		return nil, errors.ErrPrivateKeyIsEmpty
+	}
+
+	if !common.IsHexAddress(contractAddr) {
+		return nil, errors.ErrInvalidContractAddress
+	}
+
+	client, err := ethclient.Dial(rpc)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := NewERC20WithBackend(tokenName, contractAddr, privateKey, client)
+	if err != nil {
+		return nil, err
+	}
+
+	c.(*ERC20Token).rpc = rpc
+	return c, nil
+}
+
+// NewERC20WithBackend create erc-20 token currency object with own backend (e.g. *ethclient.Client with auth headers)
+func NewERC20WithBackend(tokenName, contractAddr, privateKey string, backend EthBackend) (Currency, error) {
+	if len(privateKey) == 0 {
+		return nil, errors.ErrPrivateKeyIsEmpty
+	}
+
+	if !common.IsHexAddress(contractAddr) {
+		return nil, errors.ErrInvalidContractAddress
+	}
+
+	s, err := signer.NewEthereumSigner(_0x_prefix + privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	prKey, err := crypto.HexToECDSA(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	pbKey := prKey.Public()
+	publicKeyECDSA, ok := pbKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.ErrAssertionPublicKey
+	}
+
+	return &ERC20Token{
+		Ethereum: Ethereum{
+			name:       tokenName,
+			chain:      tokenName,
+			symbol:     tokenName,
+			signer:     s,
+			tokenType:  ERC20,
+			client:     backend,
+			privateKey: prKey,
+			publicKey:  publicKeyECDSA,
+		},
+		contract: contractAddr,
+	}, nil
+}
+
+func (e *ERC20Token) GetContractAddress() string {
+	return e.contract
+}
@@ -1,7 +1,10 @@
 package currency
 
 import (
+	"context"
 	"crypto/ecdsa"
+	"fmt"
+	"math/big"
 
 	"github.com/Ja7ad/irys/errors"
 	"github.com/Ja7ad/irys/signer"
@@ -15,12 +18,115 @@ type Ethereum struct {
 	name       string
 	rpc        string
 	tokenType  CurrencyType
-	client     *ethclient.Client
+	client     EthBackend
 	privateKey *ecdsa.PrivateKey
 	publicKey  *ecdsa.PublicKey
 	signer     *signer.EthereumSigner
 }
 
+type evmMeta struct {
+	name   string
+	chain  string
+	symbol string
+}
+
+// ChainConfig is chain specific config of evm funding
+type ChainConfig struct {
+	// ChainIDs are accepted chain ids of rpc (mainnet and testnet), currency constructor fail with
+	// ErrChainIDMismatch when rpc serve other chain
+	ChainIDs []*big.Int
+	// GasLimitBuffer is percent added to estimated gas limit, on rollups estimate include l1 cost
+	// which can change until transaction included
+	GasLimitBuffer uint64
+}
+
+var _evmChains = map[CurrencyType]ChainConfig{
+	// arbitrum one and arbitrum sepolia, gas limit include l1 calldata cost priced at inclusion
+	ARBITRUM: {ChainIDs: []*big.Int{big.NewInt(42161), big.NewInt(421614)}, GasLimitBuffer: 20},
+	// base and base sepolia, l1 data fee charged from balance out of gas limit
+	BASE: {ChainIDs: []*big.Int{big.NewInt(8453), big.NewInt(84532)}},
+}
+
+// ChainConfigOf return chain config of evm currency type, false when chain has not specific config
+func ChainConfigOf(currencyType CurrencyType) (ChainConfig, bool) {
+	config, ok := _evmChains[currencyType]
+	return config, ok
+}
+
+// validateChainID check chain id of backend is accepted chain id of currency type
+func validateChainID(ctx context.Context, currencyType CurrencyType, backend EthBackend) error {
+	config, ok := _evmChains[currencyType]
+	if !ok || len(config.ChainIDs) == 0 || backend == nil {
+		return nil
+	}
+
+	chainID, err := backend.ChainID(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range config.ChainIDs {
+		if id.Cmp(chainID) == 0 {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: rpc chain id %s, expected %v", errors.ErrChainIDMismatch, chainID, config.ChainIDs)
+}
+
+var _evmCurrencies = map[CurrencyType]evmMeta{
+	ETHEREUM:  {name: "ethereum", chain: "ethereum", symbol: "eth"},
+	MATIC:     {name: "matic", chain: "polygon", symbol: "matic"},
+	BNB:       {name: "bnb", chain: "binance", symbol: "bnb"},
+	ARBITRUM:  {name: "arbitrum", chain: "arbitrum", symbol: "arb"},
+	AVALANCHE: {name: "avalanche", chain: "avalanche", symbol: "avax"},
+	FANTOM:    {name: "fantom", chain: "fantom", symbol: "ftm"},
+	BASE:      {name: "base-eth", chain: "base", symbol: "eth"},
+}
+
+// NewWithBackend create evm currency object with own backend, e.g. *ethclient.Client dialed with auth headers
+// or websocket, simulated chain for test or bind.ContractBackend adapted by WithChainID
+func NewWithBackend(currencyType CurrencyType, privateKey string, backend EthBackend) (Currency, error) {
+	meta, ok := _evmCurrencies[currencyType]
+	if !ok {
+		return nil, errors.ErrTokenNotSupported
+	}
+
+	if len(privateKey) == 0 {
+		return nil, errors.ErrPrivateKeyIsEmpty
+	}
+
+	s, err := signer.NewEthereumSigner(_0x_prefix + privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	prKey, err := crypto.HexToECDSA(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	pbKey := prKey.Public()
+	publicKeyECDSA, ok := pbKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.ErrAssertionPublicKey
+	}
+
+	if err := validateChainID(context.Background(), currencyType, backend); err != nil {
+		return nil, err
+	}
+
+	return &Ethereum{
+		name:       meta.name,
+		chain:      meta.chain,
+		symbol:     meta.symbol,
+		signer:     s,
+		tokenType:  currencyType,
+		client:     backend,
+		privateKey: prKey,
+		publicKey:  publicKeyECDSA,
+	}, nil
+}
+
 // NewEthereum create ethereum currency object
 func NewEthereum(privateKey, rpc string) (Currency, error) {
 	if len(privateKey) == 0 {
@@ -168,6 +274,10 @@ func NewArbitrum(privateKey, rpc string) (Currency, error) {
 		return nil, errors.ErrAssertionPublicKey
 	}
 
+	if err := validateChainID(context.Background(), ARBITRUM, client); err != nil {
+		return nil, err
+	}
+
 	return &Ethereum{
 		name:       "arbitrum",
 		chain:      "arbitrum",
@@ -181,6 +291,50 @@ func NewArbitrum(privateKey, rpc string) (Currency, error) {
 	}, nil
 }
 
+// NewBase create base object currency (eth on base l2)
+func NewBase(privateKey, rpc string) (Currency, error) {
+	if len(privateKey) == 0 {
+		return nil, errors.ErrPrivateKeyIsEmpty
+	}
+
+	s, err := signer.NewEthereumSigner(_0x_prefix + privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ethclient.Dial(rpc)
+	if err != nil {
+		return nil, err
+	}
+
+	prKey, err := crypto.HexToECDSA(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	pbKey := prKey.Public()
+	publicKeyECDSA, ok := pbKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.ErrAssertionPublicKey
+	}
+
+	if err := validateChainID(context.Background(), BASE, client); err != nil {
+		return nil, err
+	}
+
+	return &Ethereum{
+		name:       "base-eth",
+		chain:      "base",
+		symbol:     "eth",
+		signer:     s,
+		tokenType:  BASE,
+		rpc:        rpc,
+		client:     client,
+		privateKey: prKey,
+		publicKey:  publicKeyECDSA,
+	}, nil
+}
+
 // NewAvalanche create avalanche object currency
 func NewAvalanche(privateKey, rpc string) (Currency, error) {
 	if len(privateKey) == 0 {
@@ -281,7 +435,7 @@ func (e *Ethereum) GetRPCAddr() string {
 	return e.rpc
 }
 
-func (e *Ethereum) GetRPCClient() *ethclient.Client {
+func (e *Ethereum) GetRPCClient() EthBackend {
 	return e.client
 }
 
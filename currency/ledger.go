@@ -0,0 +1,95 @@
+package currency
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/signer"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// TxSigner is implemented by currencies which sign funding transactions without expose private key
+// (e.g. hardware wallet), client use it instead of GetPrivateKey.
+type TxSigner interface {
+	SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+}
+
+// Ledger is evm currency with keys on ledger device, data items and funding transactions approved on device
+type Ledger struct {
+	chain     string
+	symbol    string
+	name      string
+	tokenType CurrencyType
+	client    EthBackend
+	signer    *signer.LedgerSigner
+}
+
+var (
+	_ Currency = (*Ledger)(nil)
+	_ TxSigner = (*Ledger)(nil)
+)
+
+// NewLedger create evm currency of type signed by ledger signer and backend (e.g. *ethclient.Client)
+func NewLedger(currencyType CurrencyType, s *signer.LedgerSigner, backend EthBackend) (Currency, error) {
+	meta, ok := _evmCurrencies[currencyType]
+	if !ok {
+		return nil, errors.ErrTokenNotSupported
+	}
+
+	if err := validateChainID(context.Background(), currencyType, backend); err != nil {
+		return nil, err
+	}
+
+	return &Ledger{
+		name:      meta.name,
+		chain:     meta.chain,
+		symbol:    meta.symbol,
+		tokenType: currencyType,
+		client:    backend,
+		signer:    s,
+	}, nil
+}
+
+func (l *Ledger) GetChain() string {
+	return l.chain
+}
+
+func (l *Ledger) GetSymbol() string {
+	return l.symbol
+}
+
+func (l *Ledger) GetName() string {
+	return l.name
+}
+
+func (l *Ledger) GetSinger() signer.Signer {
+	return l.signer
+}
+
+func (l *Ledger) GetRPCAddr() string {
+	return ""
+}
+
+func (l *Ledger) GetRPCClient() EthBackend {
+	return l.client
+}
+
+// GetPrivateKey return nil, private key never leave device
+func (l *Ledger) GetPrivateKey() *ecdsa.PrivateKey {
+	return nil
+}
+
+func (l *Ledger) GetPublicKey() *ecdsa.PublicKey {
+	return l.signer.PublicKey()
+}
+
+func (l *Ledger) GetType() CurrencyType {
+	return l.tokenType
+}
+
+// SignTx sign funding transaction on device
+func (l *Ledger) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return l.signer.SignTx(tx, chainID)
+}
@@ -0,0 +1,19 @@
+package currency
+
+import (
+	"github.com/Ja7ad/irys/signer"
+)
+
+// SignMessage sign message with currency key, can be used for authentication (e.g. token gated download)
+func SignMessage(c Currency, msg []byte) ([]byte, error) {
+	return c.GetSinger().Sign(msg)
+}
+
+// VerifyMessage verify signature of message signed by SignMessage with owner public key of signer
+func VerifyMessage(signatureType signer.SignatureType, owner, msg, signature []byte) error {
+	s, err := signer.GetSigner(signatureType, owner)
+	if err != nil {
+		return err
+	}
+	return s.Verify(msg, signature)
+}
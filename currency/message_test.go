@@ -0,0 +1,23 @@
+package currency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerifyMessage(t *testing.T) {
+	c, err := NewWithBackend(MATIC, "f4a2b939592564feb35ab10a8e04f6f2fe0943579fb3c9c33505298978b74893", nil)
+	require.NoError(t, err)
+
+	msg := []byte("irys gated content")
+
+	signature, err := SignMessage(c, msg)
+	require.NoError(t, err)
+
+	owner, err := c.GetSinger().GetOwner()
+	require.NoError(t, err)
+
+	require.NoError(t, VerifyMessage(c.GetSinger().GetType(), owner, msg, signature))
+	require.Error(t, VerifyMessage(c.GetSinger().GetType(), owner, []byte("other"), signature))
+}
@@ -0,0 +1,216 @@
+package currency
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip39"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+// DefaultDerivationPath is BIP-44 path of first ethereum account, used by metamask and hardware wallets
+const DefaultDerivationPath = "m/44'/60'/0'/0/0"
+
+const (
+	_hardenedOffset = 0x80000000
+	_seedIterations = 2048
+)
+
+// DerivePrivateKey derive hex private key of BIP-44 path (e.g. m/44'/60'/0'/0/0) from BIP-39 mnemonic
+// and optional passphrase. Mnemonic and passphrase NFKD normalized, words and checksum of mnemonic checked
+// against english BIP-39 wordlist.
+func DerivePrivateKey(mnemonic, passphrase, path string) (string, error) {
+	indexes, err := parseDerivationPath(path)
+	if err != nil {
+		return "", err
+	}
+
+	seed, err := mnemonicSeed(mnemonic, passphrase)
+	if err != nil {
+		return "", err
+	}
+
+	key, chainCode, err := masterKey(seed)
+	if err != nil {
+		return "", err
+	}
+
+	for _, index := range indexes {
+		key, chainCode, err = childKey(key, chainCode, index)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return hex.EncodeToString(key), nil
+}
+
+// NewFromMnemonic create evm currency with key of mnemonic, optional BIP-39 passphrase and derivation path
+// (DefaultDerivationPath when empty) and own backend
+func NewFromMnemonic(currencyType CurrencyType, mnemonic, passphrase, path string, backend EthBackend) (Currency, error) {
+	key, err := derive(mnemonic, passphrase, path)
+	if err != nil {
+		return nil, err
+	}
+	return NewWithBackend(currencyType, key, backend)
+}
+
+// NewEthereumFromMnemonic create ethereum currency with key of mnemonic, passphrase and derivation path (DefaultDerivationPath when empty)
+func NewEthereumFromMnemonic(mnemonic, passphrase, path, rpc string) (Currency, error) {
+	key, err := derive(mnemonic, passphrase, path)
+	if err != nil {
+		return nil, err
+	}
+	return NewEthereum(key, rpc)
+}
+
+// NewMaticFromMnemonic create matic currency with key of mnemonic, passphrase and derivation path (DefaultDerivationPath when empty)
+func NewMaticFromMnemonic(mnemonic, passphrase, path, rpc string) (Currency, error) {
+	key, err := derive(mnemonic, passphrase, path)
+	if err != nil {
+		return nil, err
+	}
+	return NewMatic(key, rpc)
+}
+
+// NewBNBFromMnemonic create bnb currency with key of mnemonic, passphrase and derivation path (DefaultDerivationPath when empty)
+func NewBNBFromMnemonic(mnemonic, passphrase, path, rpc string) (Currency, error) {
+	key, err := derive(mnemonic, passphrase, path)
+	if err != nil {
+		return nil, err
+	}
+	return NewBNB(key, rpc)
+}
+
+// NewArbitrumFromMnemonic create arbitrum currency with key of mnemonic, passphrase and derivation path (DefaultDerivationPath when empty)
+func NewArbitrumFromMnemonic(mnemonic, passphrase, path, rpc string) (Currency, error) {
+	key, err := derive(mnemonic, passphrase, path)
+	if err != nil {
+		return nil, err
+	}
+	return NewArbitrum(key, rpc)
+}
+
+// NewBaseFromMnemonic create base currency with key of mnemonic, passphrase and derivation path (DefaultDerivationPath when empty)
+func NewBaseFromMnemonic(mnemonic, passphrase, path, rpc string) (Currency, error) {
+	key, err := derive(mnemonic, passphrase, path)
+	if err != nil {
+		return nil, err
+	}
+	return NewBase(key, rpc)
+}
+
+// NewAvalancheFromMnemonic create avalanche currency with key of mnemonic, passphrase and derivation path (DefaultDerivationPath when empty)
+func NewAvalancheFromMnemonic(mnemonic, passphrase, path, rpc string) (Currency, error) {
+	key, err := derive(mnemonic, passphrase, path)
+	if err != nil {
+		return nil, err
+	}
+	return NewAvalanche(key, rpc)
+}
+
+// NewFantomFromMnemonic create fantom currency with key of mnemonic, passphrase and derivation path (DefaultDerivationPath when empty)
+func NewFantomFromMnemonic(mnemonic, passphrase, path, rpc string) (Currency, error) {
+	key, err := derive(mnemonic, passphrase, path)
+	if err != nil {
+		return nil, err
+	}
+	return NewFantom(key, rpc)
+}
+
+func derive(mnemonic, passphrase, path string) (string, error) {
+	if len(path) == 0 {
+		path = DefaultDerivationPath
+	}
+	return DerivePrivateKey(mnemonic, passphrase, path)
+}
+
+// mnemonicSeed return BIP-39 seed of validated mnemonic and passphrase
+func mnemonicSeed(mnemonic, passphrase string) ([]byte, error) {
+	words := strings.Fields(norm.NFKD.String(mnemonic))
+	mnemonic = strings.Join(words, " ")
+
+	if _, err := bip39.EntropyFromMnemonic(mnemonic); err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrInvalidMnemonic, err)
+	}
+
+	salt := "mnemonic" + norm.NFKD.String(passphrase)
+	return pbkdf2.Key([]byte(mnemonic), []byte(salt), _seedIterations, 64, sha512.New), nil
+}
+
+// parseDerivationPath parse BIP-32 path, hardened index marked by ', h or H
+func parseDerivationPath(path string) ([]uint32, error) {
+	parts := strings.Split(strings.TrimSpace(path), "/")
+	if len(parts) < 2 || parts[0] != "m" {
+		return nil, fmt.Errorf("%w: %q", errors.ErrInvalidDerivationPath, path)
+	}
+
+	indexes := make([]uint32, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		offset := uint32(0)
+		if trimmed := strings.TrimRight(part, "'hH"); len(trimmed) == len(part)-1 {
+			part, offset = trimmed, _hardenedOffset
+		}
+
+		n, err := strconv.ParseUint(part, 10, 32)
+		if err != nil || n >= _hardenedOffset {
+			return nil, fmt.Errorf("%w: %q", errors.ErrInvalidDerivationPath, path)
+		}
+		indexes = append(indexes, uint32(n)+offset)
+	}
+
+	return indexes, nil
+}
+
+func masterKey(seed []byte) ([]byte, []byte, error) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	key := new(big.Int).SetBytes(sum[:32])
+	if key.Sign() == 0 || key.Cmp(crypto.S256().Params().N) >= 0 {
+		return nil, nil, errors.ErrInvalidMnemonic
+	}
+	return sum[:32], sum[32:], nil
+}
+
+// childKey derive BIP-32 child private key of secp256k1 parent
+func childKey(key, chainCode []byte, index uint32) ([]byte, []byte, error) {
+	var data []byte
+	if index >= _hardenedOffset {
+		data = append([]byte{0}, key...)
+	} else {
+		prKey, err := crypto.ToECDSA(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		data = crypto.CompressPubkey(&prKey.PublicKey)
+	}
+	data = append(data, byte(index>>24), byte(index>>16), byte(index>>8), byte(index))
+
+	mac := hmac.New(sha512.New, chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	n := crypto.S256().Params().N
+	il := new(big.Int).SetBytes(sum[:32])
+	if il.Cmp(n) >= 0 {
+		return nil, nil, errors.ErrInvalidDerivationPath
+	}
+
+	child := il.Add(il, new(big.Int).SetBytes(key))
+	child.Mod(child, n)
+	if child.Sign() == 0 {
+		return nil, nil, errors.ErrInvalidDerivationPath
+	}
+
+	return child.FillBytes(make([]byte, 32)), sum[32:], nil
+}
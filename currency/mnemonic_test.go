@@ -0,0 +1,76 @@
+package currency
+
+import (
+	"encoding/hex"
+	"testing"
+
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChildKey(t *testing.T) {
+	// BIP-32 test vector 1
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	require.NoError(t, err)
+
+	key, chainCode, err := masterKey(seed)
+	require.NoError(t, err)
+	require.Equal(t, "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35", hex.EncodeToString(key))
+
+	for _, want := range []struct {
+		index uint32
+		key   string
+	}{
+		{index: _hardenedOffset, key: "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{index: 1, key: "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368"},
+		{index: 2 + _hardenedOffset, key: "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca"},
+	} {
+		key, chainCode, err = childKey(key, chainCode, want.index)
+		require.NoError(t, err)
+		require.Equal(t, want.key, hex.EncodeToString(key))
+	}
+}
+
+func TestNewFromMnemonic(t *testing.T) {
+	mnemonic := "test test test test test test test test test test test junk"
+
+	c, err := NewFromMnemonic(MATIC, mnemonic, "", "", nil)
+	require.NoError(t, err)
+	require.Equal(t, "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", crypto.PubkeyToAddress(*c.GetPublicKey()).Hex())
+
+	c, err = NewFromMnemonic(MATIC, mnemonic, "", "m/44'/60'/0'/0/1", nil)
+	require.NoError(t, err)
+	require.Equal(t, "0x70997970C51812dc3A010C7d01b50e0d17dc79C8", crypto.PubkeyToAddress(*c.GetPublicKey()).Hex())
+
+	_, err = NewFromMnemonic(MATIC, "test test", "", "", nil)
+	require.ErrorIs(t, err, errs.ErrInvalidMnemonic)
+
+	// unknown word and bad checksum
+	_, err = NewFromMnemonic(MATIC, "test test test test test test test test test test test irys", "", "", nil)
+	require.ErrorIs(t, err, errs.ErrInvalidMnemonic)
+	_, err = NewFromMnemonic(MATIC, "test test test test test test test test test test test test", "", "", nil)
+	require.ErrorIs(t, err, errs.ErrInvalidMnemonic)
+
+	// passphrase change key, normalized forms of passphrase derive same key
+	c, err = NewFromMnemonic(MATIC, mnemonic, "caf\u00e9", "", nil)
+	require.NoError(t, err)
+	require.NotEqual(t, "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", crypto.PubkeyToAddress(*c.GetPublicKey()).Hex())
+
+	decomposed, err := NewFromMnemonic(MATIC, mnemonic, "cafe\u0301", "", nil)
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(*c.GetPublicKey()), crypto.PubkeyToAddress(*decomposed.GetPublicKey()))
+
+	for _, path := range []string{"44'/60'", "m/x", "m/44''", "m/2147483648"} {
+		_, err = NewFromMnemonic(MATIC, mnemonic, "", path, nil)
+		require.ErrorIs(t, err, errs.ErrInvalidDerivationPath, path)
+	}
+}
+
+func TestMnemonicSeed(t *testing.T) {
+	// BIP-39 test vector with TREZOR passphrase
+	seed, err := mnemonicSeed("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "TREZOR")
+	require.NoError(t, err)
+	require.Equal(t, "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		hex.EncodeToString(seed))
+}
@@ -0,0 +1,64 @@
+package currency
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	// _maxRestResponse limit size of chain rest api response body
+	_maxRestResponse = 1 << 20
+	// _defaultRestTimeout bound chain rest api call of client not given to constructor, stalled node not
+	// hang funding
+	_defaultRestTimeout = 30 * time.Second
+)
+
+// restClient return client of chain rest api calls, nil client replaced by client with _defaultRestTimeout
+func restClient(client *http.Client) *http.Client {
+	if client == nil {
+		return &http.Client{Timeout: _defaultRestTimeout}
+	}
+	return client
+}
+
+func getJSON(ctx context.Context, client *http.Client, url string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	return doJSON(client, req, out)
+}
+
+func postJSON(ctx context.Context, client *http.Client, url, contentType string, body io.Reader, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	return doJSON(client, req, out)
+}
+
+func doJSON(client *http.Client, req *http.Request, out any) error {
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, _maxRestResponse))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%s %s: status %d: %s", req.Method, req.URL.Path, resp.StatusCode, body)
+	}
+
+	return json.Unmarshal(body, out)
+}
@@ -0,0 +1,39 @@
+// Package simulated provide in-memory evm chain for test currency and funding logic without real rpc
+package simulated
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+
+	"github.com/Ja7ad/irys/currency"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const _defaultGasLimit = 30000000
+
+// Backend is go-ethereum simulated chain implement currency.EthBackend
+type Backend struct {
+	*backends.SimulatedBackend
+}
+
+var _ currency.EthBackend = (*Backend)(nil)
+
+// New create simulated chain and fund address of keys with balance
+func New(balance *big.Int, keys ...*ecdsa.PrivateKey) *Backend {
+	alloc := make(core.GenesisAlloc, len(keys))
+	for _, key := range keys {
+		alloc[crypto.PubkeyToAddress(key.PublicKey)] = core.GenesisAccount{Balance: balance}
+	}
+
+	return &Backend{
+		SimulatedBackend: backends.NewSimulatedBackend(alloc, _defaultGasLimit),
+	}
+}
+
+// ChainID return chain id of simulated chain (always 1337)
+func (b *Backend) ChainID(_ context.Context) (*big.Int, error) {
+	return b.Blockchain().Config().ChainID, nil
+}
@@ -0,0 +1,110 @@
+package irys
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/Ja7ad/irys/currency"
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+	"github.com/Ja7ad/irys/utils/amount"
+)
+
+// _nodeInfoTTL is time node info cached before fetched again, so changes of currency decimals,
+// funding limits and capabilities of node picked up by long-running clients
+const _nodeInfoTTL = 10 * time.Minute
+
+// ttlCache cache value for ttl
+type ttlCache[T any] struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	value   T
+	fetched time.Time
+}
+
+func newTTLCache[T any](ttl time.Duration) *ttlCache[T] {
+	return &ttlCache[T]{ttl: ttl}
+}
+
+func (c *ttlCache[T]) set(value T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.value = value
+	c.fetched = time.Now()
+}
+
+// get return cached value, false when value not set or expired
+func (c *ttlCache[T]) get() (T, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.value, !c.fetched.IsZero() && time.Since(c.fetched) < c.ttl
+}
+
+// currencyInfoOf return info of currency reported by node, decimals of currency used when node not report them
+func currencyInfoOf(info types.NodeInfo, cur currency.Currency) types.CurrencyInfo {
+	ci := info.Currencies[cur.GetName()]
+	if ci.Decimals == 0 {
+		ci.Decimals = amount.Decimals(cur)
+	}
+	return ci
+}
+
+// CurrencyInfo return decimals and funding limits of client currency reported by node info,
+// cached for 10 minutes.
+func (c *Client) CurrencyInfo(ctx context.Context) (types.CurrencyInfo, error) {
+	info, err := c.cachedNodeInfo(ctx)
+	if err != nil {
+		return types.CurrencyInfo{}, err
+	}
+	return currencyInfoOf(info, c.currency), nil
+}
+
+// cachedNodeInfo return node info cached for 10 minutes
+func (c *Client) cachedNodeInfo(ctx context.Context) (types.NodeInfo, error) {
+	if c.nodeInfo != nil {
+		if info, ok := c.nodeInfo.get(); ok {
+			return info, nil
+		}
+	}
+
+	info, err := c.getNodeInfo(ctx, c.network)
+	if err != nil {
+		return types.NodeInfo{}, err
+	}
+
+	if c.nodeInfo != nil {
+		c.nodeInfo.set(info)
+	}
+	return info, nil
+}
+
+// decimals return decimals of currency reported by node, decimals of currency when node info not available
+func (c *Client) decimals(ctx context.Context) int {
+	info, err := c.CurrencyInfo(ctx)
+	if err != nil {
+		return amount.Decimals(c.currency)
+	}
+	return info.Decimals
+}
+
+// validateFunding check amount is in funding limits of node. Limits enforced only when node report them
+// in currencies of node info, skipped when node info not available or limit missing because
+// node reject funding out of limits anyway.
+func (c *Client) validateFunding(ctx context.Context, value *big.Int) error {
+	info, err := c.CurrencyInfo(ctx)
+	if err != nil {
+		c.debugMsg("[TopUpBalance] currency info not available, skip funding limits: %v", err)
+		return nil
+	}
+
+	if info.MinFunding != nil && value.Cmp(info.MinFunding) < 0 {
+		return fmt.Errorf("%w: %s < %s", errors.ErrFundingBelowMinimum, value, info.MinFunding)
+	}
+	if info.MaxFunding != nil && value.Cmp(info.MaxFunding) > 0 {
+		return fmt.Errorf("%w: %s > %s", errors.ErrFundingAboveMaximum, value, info.MaxFunding)
+	}
+	return nil
+}
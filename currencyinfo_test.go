@@ -0,0 +1,95 @@
+package irys
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Ja7ad/irys/currency"
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurrencyInfo(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"version":"1","currencies":{"matic":{"decimals":6,"minFunding":1000,"maxFunding":1000000}}}`)
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	c := &Client{
+		client:   retryablehttp.NewClient(),
+		network:  Node(srv.URL),
+		currency: matic,
+		nodeInfo: newTTLCache[types.NodeInfo](_nodeInfoTTL),
+	}
+
+	for i := 0; i < 2; i++ {
+		info, err := c.CurrencyInfo(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 6, info.Decimals)
+		require.Equal(t, big.NewInt(1000), info.MinFunding)
+		require.Equal(t, big.NewInt(1000000), info.MaxFunding)
+	}
+	require.Equal(t, 1, requests)
+
+	// limits checked before funding transaction created
+	require.ErrorIs(t, c.TopUpBalance(context.Background(), big.NewInt(999)), errs.ErrFundingBelowMinimum)
+	require.ErrorIs(t, c.TopUpBalance(context.Background(), big.NewInt(1000001)), errs.ErrFundingAboveMaximum)
+	// 0.0001 in 6 decimals of node is 100
+	require.ErrorIs(t, c.TopUpStandard(context.Background(), "0.0001"), errs.ErrFundingBelowMinimum)
+
+	// decimals of currency used when node not report them
+	info := currencyInfoOf(types.NodeInfo{}, matic)
+	require.Equal(t, 18, info.Decimals)
+	require.Nil(t, info.MinFunding)
+}
+
+func TestWithCurrencyNodeInfo(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		fmt.Fprint(w, `{"version":"1","addresses":{"matic":"0x1"}}`)
+	}))
+	defer srv.Close()
+
+	newMatic := func() currency.Currency {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		cur, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+		require.NoError(t, err)
+		return cur
+	}
+
+	base, err := New(Node(srv.URL), newMatic(), false, WithContractAddress("0x1"), WithCustomRetryMax(0))
+	require.NoError(t, err)
+	defer base.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = base.WithCurrency(ctx, newMatic())
+	require.ErrorIs(t, err, context.Canceled)
+
+	// node info fetched once and reused by next derived clients
+	for i := 0; i < 3; i++ {
+		derived, err := base.WithCurrency(context.Background(), newMatic())
+		require.NoError(t, err)
+		require.Equal(t, "0x1", derived.(*Client).contract)
+	}
+	require.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
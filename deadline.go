@@ -0,0 +1,123 @@
+package irys
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+const (
+	_txStatusPath    = "%s/tx/%s/status"
+	_arweaveInfoPath = "%s/info"
+
+	// ArweaveBlockTime is average time of arweave block, used to estimate time to deadline height
+	ArweaveBlockTime = 2 * time.Minute
+)
+
+// IsFinalized report transaction bundle is seeded and finalized on arweave
+func (c *Client) IsFinalized(ctx context.Context, txId string) (bool, error) {
+	status, err := c.GetStatus(ctx, txId)
+	if err != nil {
+		return false, err
+	}
+	return status.Status == types.TxStatusFinalized, nil
+}
+
+// GetStatus return status of transaction on node (pending, confirmed or finalized)
+func (c *Client) GetStatus(ctx context.Context, txId string) (types.TxStatus, error) {
+	ctx, cancel := withTimeout(ctx, c.timeouts.Query)
+	defer cancel()
+
+	url := fmt.Sprintf(_txStatusPath, c.network, txId)
+	req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return types.TxStatus{}, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return types.TxStatus{}, err
+	}
+	defer resp.Body.Close()
+
+	select {
+	case <-ctx.Done():
+		return types.TxStatus{}, ctx.Err()
+	default:
+		if err := statusCheck(resp); err != nil {
+			return types.TxStatus{}, err
+		}
+		return decodeBody[types.TxStatus](c.codec, c.limitBody(EndpointInfo, resp.Body))
+	}
+}
+
+// TimeToDeadline estimate time until deadline height of transaction receipt by current arweave height,
+// negative when deadline passed. Deadline warning hook called when deadline is within WithDeadlineWarning
+// and bundle is not finalized yet.
+func (c *Client) TimeToDeadline(ctx context.Context, txId string) (time.Duration, error) {
+	receipt, err := c.GetReceipt(ctx, txId)
+	if err != nil {
+		return 0, err
+	}
+
+	if receipt.DeadlineHeight == 0 {
+		return 0, fmt.Errorf("%w: %s", errors.ErrDeadlineUnknown, txId)
+	}
+
+	height, err := c.arweaveHeight(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	remaining := time.Duration(int64(receipt.DeadlineHeight)-height) * ArweaveBlockTime
+
+	if c.deadlineWarning > 0 && c.hooks.OnDeadlineWarning != nil && remaining <= c.deadlineWarning {
+		finalized, err := c.IsFinalized(ctx, txId)
+		if err != nil {
+			return remaining, err
+		}
+		if !finalized {
+			c.hooks.OnDeadlineWarning(ctx, txId, remaining)
+		}
+	}
+
+	return remaining, nil
+}
+
+// arweaveHeight return current block height of arweave node (WithArweaveL1 url or gateway)
+func (c *Client) arweaveHeight(ctx context.Context) (int64, error) {
+	base := c.arweaveURL
+	if len(base) == 0 {
+		base = c.gateway
+	}
+
+	req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(_arweaveInfoPath, base), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	default:
+		if err := statusCheck(resp); err != nil {
+			return 0, err
+		}
+		info, err := decodeBody[types.ArweaveInfo](c.codec, c.limitBody(EndpointInfo, resp.Body))
+		if err != nil {
+			return 0, err
+		}
+		return info.Height, nil
+	}
+}
@@ -0,0 +1,60 @@
+package irys
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeToDeadline(t *testing.T) {
+	deadline := "1010"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/graphql":
+			_, _ = io.WriteString(w, `{"data":{"transactions":{"edges":[{"node":{"id":"tx","receipt":{"deadlineHeight":`+deadline+`}}}]}}}`)
+		case "/info":
+			_, _ = io.WriteString(w, `{"network":"arweave.N.1","height":1000}`)
+		case "/tx/tx/status":
+			_, _ = io.WriteString(w, `{"status":"CONFIRMED","height":990}`)
+		}
+	}))
+	defer srv.Close()
+
+	var warned time.Duration
+	c := &Client{
+		client:          retryablehttp.NewClient(),
+		network:         Node(srv.URL),
+		gateway:         srv.URL,
+		deadlineWarning: 30 * time.Minute,
+		hooks: Hooks{OnDeadlineWarning: func(ctx context.Context, txId string, remaining time.Duration) {
+			warned = remaining
+		}},
+	}
+
+	finalized, err := c.IsFinalized(context.Background(), "tx")
+	require.NoError(t, err)
+	require.False(t, finalized)
+
+	remaining, err := c.TimeToDeadline(context.Background(), "tx")
+	require.NoError(t, err)
+	require.Equal(t, 10*ArweaveBlockTime, remaining)
+	require.Equal(t, remaining, warned)
+
+	warned = 0
+	deadline = "1100"
+	remaining, err = c.TimeToDeadline(context.Background(), "tx")
+	require.NoError(t, err)
+	require.Equal(t, 100*ArweaveBlockTime, remaining)
+	require.Zero(t, warned)
+
+	deadline = "0"
+	_, err = c.TimeToDeadline(context.Background(), "tx")
+	require.ErrorIs(t, err, errs.ErrDeadlineUnknown)
+}
@@ -0,0 +1,82 @@
+package irys
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/Ja7ad/irys/signer"
+	"github.com/Ja7ad/irys/types"
+)
+
+// ContentHashTag is tag name of sha256 data hash, used by UploadIfAbsent for non deterministic signers
+const ContentHashTag = "Content-Sha256"
+
+func (c *Client) UploadIfAbsent(ctx context.Context, file []byte, tags ...types.Tag) (types.Transaction, error) {
+	url := fmt.Sprintf(_uploadPath, c.network, c.currency.GetName())
+
+	var (
+		id  string
+		err error
+	)
+
+	// resolve generated tags once, so computed id is same as uploaded item
+	tags = c.uploadTags(file, tags...)
+
+	if c.uploadSigner().GetType() == signer.Ethereum {
+		id, err = ComputeTxID(file, c.uploadSigner(), tags...)
+		if err != nil {
+			return types.Transaction{}, err
+		}
+
+		exists, err := c.Exists(ctx, id)
+		if err != nil {
+			return types.Transaction{}, err
+		}
+		if !exists {
+			id = ""
+		}
+	} else {
+		hash := sha256.Sum256(file)
+		contentHash := hex.EncodeToString(hash[:])
+		// copy so caller tags slice not modified by append
+		tags = append(append([]types.Tag(nil), tags...), types.Tag{Name: ContentHashTag, Value: contentHash})
+
+		// tag of other owners not trusted, anyone can tag other data with hash
+		owner, err := c.uploaderAddress()
+		if err != nil {
+			return types.Transaction{}, err
+		}
+
+		id, err = c.findByTag(ctx, ContentHashTag, contentHash, owner)
+		if err != nil {
+			return types.Transaction{}, err
+		}
+	}
+
+	if len(id) != 0 {
+		c.debugMsg("[UploadIfAbsent] transaction %s already exists, skip upload", id)
+		return c.GetMetaData(ctx, id)
+	}
+
+	return c.upload(ctx, url, file, tags...)
+}
+
+// findByTag return id of first transaction with tag owned by one of owners (any owner when not given),
+// empty if not found
+func (c *Client) findByTag(ctx context.Context, name, value string, owners ...string) (string, error) {
+	ctx, cancel := withTimeout(ctx, c.timeouts.Query)
+	defer cancel()
+
+	data, err := graphqlQuery[types.TransactionsData](ctx, c, tagQuery(name, value, 1, owners...))
+	if err != nil {
+		return "", err
+	}
+
+	if len(data.Transactions.Edges) == 0 {
+		return "", nil
+	}
+
+	return data.Transactions.Edges[0].Node.ID, nil
+}
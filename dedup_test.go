@@ -0,0 +1,84 @@
+package irys
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Ja7ad/irys/currency"
+	"github.com/Ja7ad/irys/types"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadIfAbsent(t *testing.T) {
+	data := []byte("hello irys")
+	hash := sha256.Sum256(data)
+
+	var (
+		uploads int
+		exists  bool
+		owners  []any
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/graphql":
+			var request types.GraphqlRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			owners, _ = request.Variables["owners"].([]any)
+			if !exists {
+				fmt.Fprint(w, `{"data":{"transactions":{"edges":[]}}}`)
+				return
+			}
+			fmt.Fprint(w, `{"data":{"transactions":{"edges":[{"node":{"id":"old"}}]}}}`)
+		case "/tx/aptos":
+			uploads++
+			b, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+
+			item := new(types.BundleItem)
+			require.NoError(t, item.Unmarshal(b))
+			value, ok := item.GetTag(ContentHashTag)
+			require.True(t, ok)
+			require.Equal(t, hex.EncodeToString(hash[:]), value)
+			_ = json.NewEncoder(w).Encode(types.Transaction{ID: item.Id.Base64()})
+		case "/tx/old":
+			fmt.Fprint(w, `{"id":"old"}`)
+		}
+	}))
+	defer srv.Close()
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	aptos, err := currency.NewAptos(hex.EncodeToString(key.Seed()), "")
+	require.NoError(t, err)
+
+	c := &Client{client: retryablehttp.NewClient(), network: Node(srv.URL), gateway: srv.URL, currency: aptos}
+	c.client.RetryMax = 0
+
+	userTags := make([]types.Tag, 1, 4)
+	userTags[0] = types.Tag{Name: "App-Name", Value: "app"}
+
+	_, err = c.UploadIfAbsent(context.Background(), data, userTags...)
+	require.NoError(t, err)
+	require.Equal(t, 1, uploads)
+	// only items of client owner trusted
+	require.Equal(t, []any{aptos.(currency.Addresser).GetAddress()}, owners)
+	// spare capacity of caller slice not written
+	require.Empty(t, userTags[:2][1])
+
+	exists = true
+	tx, err := c.UploadIfAbsent(context.Background(), data, userTags...)
+	require.NoError(t, err)
+	require.Equal(t, "old", tx.ID)
+	require.Equal(t, 1, uploads)
+}
@@ -0,0 +1,143 @@
+package irys
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/signer"
+	"github.com/Ja7ad/irys/types"
+)
+
+// Tags of delegation added to items signed by session key (WithDelegation)
+const (
+	DelegationOwnerTag         = "Delegation-Owner"
+	DelegationSignatureTypeTag = "Delegation-Signature-Type"
+	DelegationExpiryTag        = "Delegation-Expiry"
+	DelegationSignatureTag     = "Delegation-Signature"
+)
+
+// Delegate sign delegation of master to session key owner until expiry and return delegation tags,
+// signed once where master key is kept (offline) and passed to WithDelegation of upload service.
+func Delegate(master signer.Signer, sessionOwner []byte, expiry time.Time) ([]types.Tag, error) {
+	signature, err := master.Sign(delegationMessage(sessionOwner, expiry.Unix()))
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := master.GetOwner()
+	if err != nil {
+		return nil, err
+	}
+
+	return []types.Tag{
+		{Name: DelegationOwnerTag, Value: types.Base64String(owner).Base64()},
+		{Name: DelegationSignatureTypeTag, Value: strconv.Itoa(int(master.GetType()))},
+		{Name: DelegationExpiryTag, Value: strconv.FormatInt(expiry.Unix(), 10)},
+		{Name: DelegationSignatureTag, Value: types.Base64String(signature).Base64()},
+	}, nil
+}
+
+// VerifyDelegation verify delegation tags of item signed by session key owner at time and return master owner,
+// error ErrDelegationNotFound when item not delegated.
+func VerifyDelegation(sessionOwner []byte, tags []types.Tag, at time.Time) (types.Owner, error) {
+	var owner, sigType, expiry, signature string
+	for _, tag := range tags {
+		switch tag.Name {
+		case DelegationOwnerTag:
+			owner = tag.Value
+		case DelegationSignatureTypeTag:
+			sigType = tag.Value
+		case DelegationExpiryTag:
+			expiry = tag.Value
+		case DelegationSignatureTag:
+			signature = tag.Value
+		}
+	}
+
+	if len(owner) == 0 || len(signature) == 0 {
+		return types.Owner{}, errors.ErrDelegationNotFound
+	}
+
+	t, err := strconv.Atoi(sigType)
+	if err != nil {
+		return types.Owner{}, fmt.Errorf("%w: signature type %q", errors.ErrInvalidDelegation, sigType)
+	}
+
+	exp, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil {
+		return types.Owner{}, fmt.Errorf("%w: expiry %q", errors.ErrInvalidDelegation, expiry)
+	}
+	if at.Unix() > exp {
+		return types.Owner{}, fmt.Errorf("%w: expired at %s", errors.ErrDelegationExpired, time.Unix(exp, 0).UTC())
+	}
+
+	var ownerBytes, sigBytes types.Base64String
+	if err := ownerBytes.Decode(owner); err != nil {
+		return types.Owner{}, err
+	}
+	if err := sigBytes.Decode(signature); err != nil {
+		return types.Owner{}, err
+	}
+
+	s, err := signer.GetSigner(signer.SignatureType(t), ownerBytes)
+	if err != nil {
+		return types.Owner{}, err
+	}
+
+	if err := s.Verify(delegationMessage(sessionOwner, exp), sigBytes); err != nil {
+		return types.Owner{}, fmt.Errorf("%w: %v", errors.ErrInvalidDelegation, err)
+	}
+
+	address, err := signer.OwnerAddress(signer.SignatureType(t), ownerBytes)
+	if err != nil {
+		return types.Owner{}, err
+	}
+
+	return types.Owner{
+		SignatureType: signer.SignatureType(t),
+		PublicKey:     ownerBytes,
+		Address:       address,
+	}, nil
+}
+
+func delegationMessage(sessionOwner []byte, expiry int64) []byte {
+	return []byte(fmt.Sprintf("irys-delegation\n%s\n%d", types.Base64String(sessionOwner).Base64(), expiry))
+}
+
+// uploadSigner return signer of uploads, session key of delegation or currency signer
+func (c *Client) uploadSigner() signer.Signer {
+	if c.sessionSigner != nil {
+		return c.sessionSigner
+	}
+	return c.currency.GetSinger()
+}
+
+// addDelegationTags add delegation tags of session key (WithDelegation)
+func (c *Client) addDelegationTags(tags ...types.Tag) []types.Tag {
+	if c.sessionSigner == nil {
+		return tags
+	}
+	return append(tags, c.delegation...)
+}
+
+// validateDelegation check delegation of session key is valid now, auto fund rejected because it top up
+// balance of currency wallet while uploads are paid by session key or approved payer
+func (c *Client) validateDelegation() error {
+	if c.sessionSigner == nil {
+		return nil
+	}
+
+	if c.autoFundLimit != nil {
+		return errors.ErrAutoFundWithDelegation
+	}
+
+	owner, err := c.sessionSigner.GetOwner()
+	if err != nil {
+		return err
+	}
+
+	_, err = VerifyDelegation(owner, c.delegation, time.Now())
+	return err
+}
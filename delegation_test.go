@@ -0,0 +1,112 @@
+package irys
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/signer"
+	"github.com/Ja7ad/irys/types"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelegation(t *testing.T) {
+	master, err := signer.NewEthereumSigner("0xf4a2b939592564feb35ab10a8e04f6f2fe0943579fb3c9c33505298978b74893")
+	require.NoError(t, err)
+	session, err := signer.NewEthereumSigner("0x8f2a55949038a9610f50fb23b5883af3b4ecb3c3bb792cbcefbd1542c692be63")
+	require.NoError(t, err)
+
+	sessionOwner, err := session.GetOwner()
+	require.NoError(t, err)
+	masterOwner, err := master.GetOwner()
+	require.NoError(t, err)
+
+	expiry := time.Now().Add(time.Hour)
+	tags, err := Delegate(master, sessionOwner, expiry)
+	require.NoError(t, err)
+
+	owner, err := VerifyDelegation(sessionOwner, tags, time.Now())
+	require.NoError(t, err)
+	require.Equal(t, []byte(masterOwner), owner.PublicKey)
+
+	address, err := signer.OwnerAddress(signer.Ethereum, masterOwner)
+	require.NoError(t, err)
+	require.Equal(t, address, owner.Address)
+
+	_, err = VerifyDelegation(sessionOwner, tags, expiry.Add(time.Minute))
+	require.ErrorIs(t, err, errors.ErrDelegationExpired)
+
+	// delegation of session key not valid for other owner
+	_, err = VerifyDelegation(masterOwner, tags, time.Now())
+	require.ErrorIs(t, err, errors.ErrInvalidDelegation)
+
+	_, err = VerifyDelegation(sessionOwner, nil, time.Now())
+	require.ErrorIs(t, err, errors.ErrDelegationNotFound)
+
+	c := &Client{sessionSigner: session, delegation: tags}
+	require.NoError(t, c.validateDelegation())
+	require.Equal(t, session, c.uploadSigner())
+	require.Equal(t, tags, c.addDelegationTags())
+
+	c.autoFundLimit = big.NewInt(1000)
+	require.ErrorIs(t, c.validateDelegation(), errors.ErrAutoFundWithDelegation)
+	c.autoFundLimit = nil
+
+	c.delegation = nil
+	require.ErrorIs(t, c.validateDelegation(), errors.ErrDelegationNotFound)
+}
+
+func TestResolveAddressDelegation(t *testing.T) {
+	master, err := signer.NewEthereumSigner("0xf4a2b939592564feb35ab10a8e04f6f2fe0943579fb3c9c33505298978b74893")
+	require.NoError(t, err)
+	session, err := signer.NewEthereumSigner("0x8f2a55949038a9610f50fb23b5883af3b4ecb3c3bb792cbcefbd1542c692be63")
+	require.NoError(t, err)
+
+	sessionOwner, err := session.GetOwner()
+	require.NoError(t, err)
+
+	delegation, err := Delegate(master, sessionOwner, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+	tx := types.Transaction{
+		ID:        "tx",
+		Owner:     base64.RawURLEncoding.EncodeToString(sessionOwner),
+		Timestamp: time.Now().UnixMilli(),
+	}
+	for _, tag := range delegation {
+		tx.Tags = append(tx.Tags, types.Tag{Name: encode(tag.Name), Value: encode(tag.Value)})
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(tx)
+	}))
+	defer srv.Close()
+
+	c := &Client{client: retryablehttp.NewClient(), gateway: srv.URL}
+
+	masterOwner, err := master.GetOwner()
+	require.NoError(t, err)
+	masterAddress, err := signer.OwnerAddress(signer.Ethereum, masterOwner)
+	require.NoError(t, err)
+
+	address, err := c.ResolveAddress(context.Background(), "tx")
+	require.NoError(t, err)
+	require.Equal(t, masterAddress, address)
+
+	// item without delegation resolved to owner
+	tx.Tags = nil
+	sessionAddress, err := signer.OwnerAddress(signer.Ethereum, sessionOwner)
+	require.NoError(t, err)
+
+	address, err = c.ResolveAddress(context.Background(), "tx")
+	require.NoError(t, err)
+	require.Equal(t, sessionAddress, address)
+}
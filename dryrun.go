@@ -0,0 +1,42 @@
+package irys
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+)
+
+// dryRunUpload price and check balance for signed item without post it to node,
+// returned transaction has id and cost of upload. size is data size (item signed by SignReader has no Data).
+func (c *Client) dryRunUpload(ctx context.Context, item *types.BundleItem, size int) (types.Transaction, error) {
+	price, err := c.GetPrice(ctx, size)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+
+	balance, err := c.GetBalance(ctx)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+
+	tx := types.Transaction{
+		ID:        item.Id.Base64(),
+		Currency:  c.currency.GetName(),
+		Owner:     item.Owner.Base64(),
+		Signature: item.Signature.Base64(),
+		Tags:      item.Tags,
+		Anchor:    item.Anchor.Base64(),
+		DataSize:  strconv.Itoa(size),
+		RawSize:   strconv.Itoa(item.Size() - len(item.Data) + size),
+		Cost:      price,
+	}
+	c.debugMsg("[DryRun] transaction %s cost %s with balance %s", tx.ID, price.String(), balance.String())
+
+	if balance.Cmp(price) < 0 {
+		return tx, errors.ErrNotEnoughBalance
+	}
+
+	return tx, nil
+}
@@ -0,0 +1,107 @@
+package irys
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Ja7ad/irys/currency"
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadDryRun(t *testing.T) {
+	var posts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			posts++
+			return
+		}
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/price/"):
+			size, err := strconv.Atoi(r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+			require.NoError(t, err)
+			if size > 10 {
+				fmt.Fprint(w, 150)
+				return
+			}
+			fmt.Fprint(w, 50)
+		case r.URL.Path == "/account/balance/matic":
+			fmt.Fprint(w, `{"balance":"100"}`)
+		}
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	store, err := NewFileReceiptStore(t.TempDir())
+	require.NoError(t, err)
+
+	idx, err := NewFileUploadIndex(filepath.Join(t.TempDir(), "uploads.jsonl"))
+	require.NoError(t, err)
+	defer idx.Close()
+
+	journalDir := t.TempDir()
+	j, err := newJournal(journalDir)
+	require.NoError(t, err)
+
+	c := &Client{
+		client:       retryablehttp.NewClient(),
+		network:      Node(srv.URL),
+		currency:     matic,
+		receiptStore: store,
+		uploadIndex:  idx,
+		journal:      j,
+		dryRun:       true,
+	}
+
+	tags := []types.Tag{{Name: "App", Value: "demo"}}
+
+	// signed item priced without post
+	tx, err := c.Upload(context.Background(), []byte("hello"), tags...)
+	require.NoError(t, err)
+	require.NotEmpty(t, tx.ID)
+	require.Equal(t, big.NewInt(50), tx.Cost)
+
+	tx, err = c.UploadReader(context.Background(), bytes.NewReader([]byte("hello")), 5, tags...)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(50), tx.Cost)
+
+	// shortfall reported with cost of upload
+	tx, err = c.Upload(context.Background(), []byte("hello irys dry run"), tags...)
+	require.ErrorIs(t, err, errs.ErrNotEnoughBalance)
+	require.Equal(t, big.NewInt(150), tx.Cost)
+
+	_, err = c.UploadReader(context.Background(), bytes.NewReader([]byte("hello irys dry run")), 18, tags...)
+	require.ErrorIs(t, err, errs.ErrNotEnoughBalance)
+
+	require.Zero(t, posts)
+
+	receipts, err := store.List()
+	require.NoError(t, err)
+	require.Empty(t, receipts)
+
+	entries, err := c.FindLocal("App", "demo")
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	files, err := os.ReadDir(journalDir)
+	require.NoError(t, err)
+	require.Empty(t, files)
+}
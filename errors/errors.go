@@ -34,5 +34,72 @@ var (
 	ErrNestedBundleInvalidLength         = errors.New("nested bundle invalid length in one of the fields")
 	ErrBalanceIsLow                      = errors.New("balance is low")
 	ErrNotEnoughBalance                  = errors.New("not enough balance")
-	ErrNotAllowedChunkSize               = errors.New("chunk size file is greater 95 MB or lesser 500 KB")
+	ErrNotAllowedChunkSize               = errors.New("chunk size file is lesser 500 KB")
+	ErrChunkReassembly                   = errors.New("uploaded chunks size mismatch with signed data item")
+	ErrInvalidContractAddress            = errors.New("token contract address is invalid")
+	ErrAttestationNotFound               = errors.New("attestation tags not found")
+	ErrAttestationHashMismatch           = errors.New("attestation content hash mismatch with data")
+	ErrDelegationNotFound                = errors.New("delegation tags not found")
+	ErrInvalidDelegation                 = errors.New("delegation is invalid")
+	ErrDelegationExpired                 = errors.New("delegation is expired")
+	ErrTransportNotConfigurable          = errors.New("custom http client transport is not *http.Transport")
+	ErrCircuitOpen                       = errors.New("circuit breaker is open, node is unavailable")
+	ErrClientClosed                      = errors.New("irys client is closed")
+	ErrAutoFundLimitExceeded             = errors.New("balance shortfall exceeds auto fund limit")
+	ErrCIDNotFound                       = errors.New("transaction with ipfs cid not found")
+	ErrCIDMismatch                       = errors.New("downloaded data not match ipfs cid")
+	ErrResponseTooLarge                  = errors.New("response body is too large")
+	ErrInvalidReceiptField               = errors.New("receipt field is not supported")
+	ErrGraphql                           = errors.New("graphql query failed")
+	ErrDownloadVerification              = errors.New("downloaded data not match data item signature")
+	ErrInvalidAmount                     = errors.New("amount must be greater than zero")
+	ErrFundingBelowMinimum               = errors.New("funding amount is below node minimum")
+	ErrFundingAboveMaximum               = errors.New("funding amount is above node maximum")
+	ErrEmptyPlan                         = errors.New("upload plan has no items")
+	ErrReceiptNotFound                   = errors.New("receipt not found")
+	ErrInvalidReceipt                    = errors.New("receipt is invalid")
+	ErrPayloadTooLarge                   = errors.New("payload is too large for node")
+	ErrArweaveNotConfigured              = errors.New("arweave wallet is not configured for L1 upload")
+	ErrUploadIndexNotConfigured          = errors.New("upload index is not configured")
+	ErrEmptyPassphrase                   = errors.New("passphrase is empty")
+	ErrInvalidAlias                      = errors.New("key alias is invalid")
+	ErrKeyExists                         = errors.New("key with alias already exists")
+	ErrKeyNotFound                       = errors.New("key with alias not found")
+	ErrInvalidKeyFile                    = errors.New("key file is not valid keystore json")
+	ErrInvalidMnemonic                   = errors.New("mnemonic is invalid")
+	ErrInvalidDerivationPath             = errors.New("derivation path is invalid")
+	ErrLedgerInvalidReply                = errors.New("invalid reply from ledger device")
+	ErrLedgerRejected                    = errors.New("ledger device rejected request")
+	ErrInvalidEd25519Key                 = errors.New("ed25519 key is invalid")
+	ErrEd25519SignatureMismatch          = errors.New("ed25519 signature mismatch")
+	ErrInvalidAddress                    = errors.New("address is invalid")
+	ErrChainTxFailed                     = errors.New("chain rejected funding transaction")
+	ErrInvalidBundle                     = errors.New("bundle is invalid or truncated")
+	ErrBundleClosed                      = errors.New("bundle writer is closed")
+	ErrInvalidThreshold                  = errors.New("threshold must be between 1 and number of public keys")
+	ErrSignatureThresholdNotMet          = errors.New("signatures not reached threshold")
+	ErrStreamSizeMismatch                = errors.New("stream size mismatch")
+	ErrStreamNotSupported                = errors.New("stream blob is not supported by deep hash, use DeepHashStream")
+	ErrUnsupportedDeepHashType           = errors.New("unsupported deep hash type")
+	ErrInvalidSignedItem                 = errors.New("signed data item is invalid")
+	ErrRedirectNotAllowed                = errors.New("redirect is not allowed")
+	ErrInvalidGateway                    = errors.New("gateway must be absolute http or https url")
+	ErrInvalidSearchQuery                = errors.New("search query is invalid")
+	ErrEmptyManifest                     = errors.New("manifest has no files")
+	ErrManifestPathNotFound              = errors.New("path not found in manifest")
+	ErrManifestIndexNotFound             = errors.New("manifest index path not found in files")
+	ErrDeadlineUnknown                   = errors.New("receipt of transaction has no deadline height")
+	ErrTxNotFound                        = errors.New("transaction not found")
+	ErrPlanPartialFailure                = errors.New("some items of upload plan failed")
+	ErrAccountLogNotConfigured           = errors.New("account log is not configured")
+	ErrReceiptStoreNotConfigured         = errors.New("receipt store is not configured")
+	ErrReceiptVerifierNotConfigured      = errors.New("receipt verifier is not configured")
+	ErrNotModified                       = errors.New("content not modified")
+	ErrQueueClosed                       = errors.New("upload queue is closed")
+	ErrTicketNotFound                    = errors.New("upload queue ticket not found")
+	ErrChainIDMismatch                   = errors.New("rpc chain id not match currency chain")
+	ErrSpendLimitExceeded                = errors.New("spend limit exceeded")
+	ErrInvalidFileHash                   = errors.New("file hash must be hex sha256")
+	ErrSourceTooLarge                    = errors.New("source resource is too large")
+	ErrAutoFundWithDelegation            = errors.New("auto fund not supported with delegation")
 )
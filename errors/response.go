@@ -0,0 +1,59 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ResponseError is error status response of node or gateway, Body is raw response body (limited) for debugging
+// and Message is error message parsed from json body ({"error": ...} or {"message": ...}) or body text.
+// It unwraps to sentinel error of status when exists (e.g. ErrNotEnoughBalance for 402).
+type ResponseError struct {
+	StatusCode int
+	Message    string
+	Body       []byte
+	Err        error
+}
+
+// NewResponseError create response error of status and body, message parsed from body
+func NewResponseError(statusCode int, body []byte, sentinel error) *ResponseError {
+	return &ResponseError{
+		StatusCode: statusCode,
+		Message:    parseMessage(body),
+		Body:       body,
+		Err:        sentinel,
+	}
+}
+
+func (e *ResponseError) Error() string {
+	if e.Err != nil {
+		if len(e.Message) == 0 {
+			return e.Err.Error()
+		}
+		return fmt.Sprintf("%s: %s", e.Err, e.Message)
+	}
+	return fmt.Sprintf("%d: %s", e.StatusCode, e.Message)
+}
+
+func (e *ResponseError) Unwrap() error {
+	return e.Err
+}
+
+// parseMessage return error message of json body, or trimmed body when not json
+func parseMessage(body []byte) string {
+	var payload map[string]any
+	if err := json.Unmarshal(body, &payload); err == nil {
+		for _, key := range []string{"error", "message", "msg"} {
+			switch v := payload[key].(type) {
+			case string:
+				return v
+			case map[string]any:
+				if msg, ok := v["message"].(string); ok {
+					return msg
+				}
+			}
+		}
+	}
+	return strings.TrimSpace(string(body))
+}
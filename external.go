@@ -0,0 +1,30 @@
+package irys
+
+import (
+	"net/http"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// newExternalClient return client of third party servers (source of UploadFromURL, arweave node), it share network
+// transport (proxy, dialer), redirect policy and graceful close of node client, but not headers, middlewares,
+// circuit breaker and upload stats of node client.
+func (c *Client) newExternalClient(base http.RoundTripper) *retryablehttp.Client {
+	external := retryablehttp.NewClient()
+	external.HTTPClient = &http.Client{
+		Timeout:       c.client.HTTPClient.Timeout,
+		CheckRedirect: c.client.HTTPClient.CheckRedirect,
+		Transport: c.drain.wrap(&headerTransport{
+			next:      base,
+			userAgent: c.userAgent,
+			external:  true,
+		}),
+	}
+	external.RetryMax = c.client.RetryMax
+	external.RetryWaitMin = c.client.RetryWaitMin
+	external.RetryWaitMax = c.client.RetryWaitMax
+	external.ErrorHandler = retryablehttp.PassthroughErrorHandler
+	external.Logger = c.client.Logger
+	external.CheckRetry = stopOnClosed(retryablehttp.DefaultRetryPolicy)
+	return external
+}
@@ -0,0 +1,87 @@
+package irys
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+)
+
+// FileHashTag is tag name of hex sha256 of payload, added on upload with WithFileHash
+const FileHashTag = "File-Hash"
+
+// FileHash return hex sha256 of data as value of FileHashTag
+func FileHash(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func (c *Client) addFileHashTag(file []byte, tags ...types.Tag) []types.Tag {
+	if !c.fileHash || hasTag(tags, FileHashTag) {
+		return tags
+	}
+	return append(tags, types.Tag{Name: FileHashTag, Value: FileHash(file)})
+}
+
+// addFileHashTagReader is addFileHashTag of data read from r
+func (c *Client) addFileHashTagReader(r io.Reader, tags ...types.Tag) ([]types.Tag, error) {
+	if !c.fileHash || hasTag(tags, FileHashTag) {
+		return tags, nil
+	}
+
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return nil, err
+	}
+	return append(tags, types.Tag{Name: FileHashTag, Value: hex.EncodeToString(h.Sum(nil))}), nil
+}
+
+func hasTag(tags []types.Tag, name string) bool {
+	for _, tag := range tags {
+		if tag.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// FindByHash return all transactions uploaded with File-Hash tag of hash (WithFileHash) or Content-Sha256 tag
+// of same hash (UploadIfAbsent), each transaction once
+func (c *Client) FindByHash(ctx context.Context, hash string) ([]types.TransactionNode, error) {
+	hash = strings.ToLower(hash)
+	if b, err := hex.DecodeString(hash); err != nil || len(b) != sha256.Size {
+		return nil, fmt.Errorf("%w: %s", errors.ErrInvalidFileHash, hash)
+	}
+
+	var txs []types.TransactionNode
+	seen := make(map[string]struct{})
+	// tag filters of graphql match all names, so each tag of hash searched separately
+	for _, name := range []string{FileHashTag, ContentHashTag} {
+		it := c.Search(types.SearchQuery{
+			Tags: []types.TagFilter{{Name: name, Values: []string{hash}}},
+		})
+
+		for {
+			page, err := it.Next(ctx)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			for _, tx := range page.Transactions {
+				if _, ok := seen[tx.ID]; ok {
+					continue
+				}
+				seen[tx.ID] = struct{}{}
+				txs = append(txs, tx)
+			}
+		}
+	}
+	return txs, nil
+}
@@ -0,0 +1,69 @@
+package irys
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddFileHashTag(t *testing.T) {
+	hash := "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"
+	require.Equal(t, hash, FileHash([]byte("hello world")))
+
+	c := &Client{}
+	require.Empty(t, c.addFileHashTag([]byte("hello world")))
+
+	c.fileHash = true
+	require.Equal(t, []types.Tag{{Name: FileHashTag, Value: hash}}, c.addFileHashTag([]byte("hello world")))
+
+	tags, err := c.addFileHashTagReader(strings.NewReader("hello world"))
+	require.NoError(t, err)
+	require.Equal(t, []types.Tag{{Name: FileHashTag, Value: hash}}, tags)
+
+	tags = []types.Tag{{Name: FileHashTag, Value: "custom"}}
+	require.Equal(t, tags, c.addFileHashTag([]byte("hello world"), tags...))
+}
+
+func TestFindByHash(t *testing.T) {
+	hash := FileHash([]byte("hello world"))
+
+	var pages int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request types.GraphqlRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		tags := fmt.Sprint(request.Variables["tags"])
+		require.Contains(t, tags, hash)
+
+		if strings.Contains(tags, ContentHashTag) {
+			// item of UploadIfAbsent, tx2 tagged by both
+			_, _ = fmt.Fprint(w, `{"data":{"transactions":{"edges":[{"cursor":"c","node":{"id":"tx2"}},{"cursor":"c3","node":{"id":"tx3"}}],"pageInfo":{"hasNextPage":false}}}}`)
+			return
+		}
+
+		pages++
+		_, _ = fmt.Fprintf(w, `{"data":{"transactions":{"edges":[{"cursor":"c%d","node":{"id":"tx%d"}}],"pageInfo":{"hasNextPage":%t}}}}`,
+			pages, pages, pages < 2)
+	}))
+	defer srv.Close()
+
+	c := &Client{client: retryablehttp.NewClient(), network: Node(srv.URL)}
+
+	txs, err := c.FindByHash(context.Background(), strings.ToUpper(hash))
+	require.NoError(t, err)
+	require.Len(t, txs, 3)
+	require.Equal(t, "tx1", txs[0].ID)
+	require.Equal(t, "tx2", txs[1].ID)
+	require.Equal(t, "tx3", txs[2].ID)
+
+	_, err = c.FindByHash(context.Background(), "abc")
+	require.ErrorIs(t, err, errs.ErrInvalidFileHash)
+}
@@ -0,0 +1,136 @@
+// Package fixtures build realistic transactions, receipts and graphql responses signed by real keys, for test
+// suites of code built on irys client without hand-crafted json.
+package fixtures
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/Ja7ad/irys/signer"
+	"github.com/Ja7ad/irys/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ReceiptVersion is version of receipts built by Receipt
+const ReceiptVersion = "1.0.0"
+
+var _encode = base64.RawURLEncoding.EncodeToString
+
+// Signer return ethereum signer of new random key, usable as uploader or node signer
+func Signer() (*signer.EthereumSigner, error) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	return signer.NewEthereumSigner("0x" + hex.EncodeToString(crypto.FromECDSA(key)))
+}
+
+// Transaction sign data item of data with tags by s and return its transaction metadata as served by
+// gateway (base64url fields and tags), so it pass VerifyDownload with data. Signed item returned too.
+func Transaction(s signer.Signer, data []byte, tags ...types.Tag) (types.Transaction, *types.BundleItem, error) {
+	item := &types.BundleItem{
+		Data: types.Base64String(data),
+		Tags: tags,
+	}
+	if err := item.Sign(s); err != nil {
+		return types.Transaction{}, nil, err
+	}
+
+	address, err := signer.OwnerAddress(item.SignatureType, item.Owner)
+	if err != nil {
+		return types.Transaction{}, nil, err
+	}
+
+	tx := types.Transaction{
+		ID:        item.Id.Base64(),
+		Address:   address,
+		Owner:     item.Owner.Base64(),
+		Signature: item.Signature.Base64(),
+		Target:    item.Target.Base64(),
+		Anchor:    item.Anchor.Base64(),
+		DataSize:  strconv.Itoa(len(data)),
+		RawSize:   strconv.Itoa(len(data)),
+	}
+	for _, tag := range tags {
+		tx.Tags = append(tx.Tags, types.Tag{Name: _encode([]byte(tag.Name)), Value: _encode([]byte(tag.Value))})
+	}
+
+	return tx, item, nil
+}
+
+// Receipt return receipt of transaction id signed by node signer, signature is over deep hash of
+// "Bundlr", version, id, deadline height and timestamp in milliseconds, verified by VerifyReceipt.
+func Receipt(node signer.Signer, id string, deadlineHeight int, timestamp time.Time) (types.Receipt, error) {
+	receipt := types.Receipt{
+		ID:             id,
+		Timestamp:      timestamp.UnixMilli(),
+		Version:        ReceiptVersion,
+		DeadlineHeight: deadlineHeight,
+	}
+
+	hash := receiptHash(receipt)
+	signature, err := node.Sign(hash[:])
+	if err != nil {
+		return types.Receipt{}, err
+	}
+
+	receipt.Signature = _encode(signature)
+	return receipt, nil
+}
+
+// VerifyReceipt verify signature of receipt by node signer
+func VerifyReceipt(node signer.Signer, receipt types.Receipt) error {
+	signature, err := base64.RawURLEncoding.DecodeString(receipt.Signature)
+	if err != nil {
+		return err
+	}
+
+	hash := receiptHash(receipt)
+	return node.Verify(hash[:], signature)
+}
+
+func receiptHash(receipt types.Receipt) [48]byte {
+	return types.DeepHash([]any{
+		"Bundlr",
+		receipt.Version,
+		receipt.ID,
+		strconv.Itoa(receipt.DeadlineHeight),
+		strconv.FormatInt(receipt.Timestamp, 10),
+	})
+}
+
+// Node return graphql node of transaction with decoded tags and receipt
+func Node(tx types.Transaction, currency string, receipt types.Receipt) (types.TransactionNode, error) {
+	tags, err := tx.DecodedTags()
+	if err != nil {
+		return types.TransactionNode{}, err
+	}
+
+	return types.TransactionNode{
+		ID:        tx.ID,
+		Address:   tx.Address,
+		Currency:  currency,
+		Timestamp: receipt.Timestamp,
+		Tags:      tags,
+		Receipt:   receipt,
+	}, nil
+}
+
+// Transactions return graphql response of transactions query with nodes, cursor of edge is id of node
+func Transactions(hasNextPage bool, nodes ...types.TransactionNode) types.GraphqlResponse[types.TransactionsData] {
+	edges := make([]types.TransactionEdge, 0, len(nodes))
+	for _, node := range nodes {
+		edges = append(edges, types.TransactionEdge{Cursor: node.ID, Node: node})
+	}
+
+	return types.GraphqlResponse[types.TransactionsData]{
+		Data: types.TransactionsData{
+			Transactions: types.TransactionConnection{
+				Edges:    edges,
+				PageInfo: types.PageInfo{HasNextPage: hasNextPage},
+			},
+		},
+	}
+}
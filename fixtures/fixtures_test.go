@@ -0,0 +1,49 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Ja7ad/irys/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixtures(t *testing.T) {
+	uploader, err := Signer()
+	require.NoError(t, err)
+	node, err := Signer()
+	require.NoError(t, err)
+
+	tx, item, err := Transaction(uploader, []byte("hello irys"), types.Tag{Name: "App-Name", Value: "irys"})
+	require.NoError(t, err)
+	require.NoError(t, item.VerifySignature())
+	require.Equal(t, item.Id.Base64(), tx.ID)
+	require.Equal(t, "10", tx.DataSize)
+
+	tags, err := tx.DecodedTags()
+	require.NoError(t, err)
+	require.Equal(t, []types.Tag{{Name: "App-Name", Value: "irys"}}, tags)
+
+	receipt, err := Receipt(node, tx.ID, 1000, time.UnixMilli(1700000000000))
+	require.NoError(t, err)
+	require.NoError(t, VerifyReceipt(node, receipt))
+
+	tampered := receipt
+	tampered.DeadlineHeight++
+	require.Error(t, VerifyReceipt(node, tampered))
+
+	n, err := Node(tx, "matic", receipt)
+	require.NoError(t, err)
+	require.Equal(t, tx.Address, n.Address)
+	require.Equal(t, tags, n.Tags)
+
+	b, err := json.Marshal(Transactions(true, n))
+	require.NoError(t, err)
+
+	var resp types.GraphqlResponse[types.TransactionsData]
+	require.NoError(t, json.Unmarshal(b, &resp))
+	require.Len(t, resp.Data.Transactions.Edges, 1)
+	require.Equal(t, receipt, resp.Data.Transactions.Edges[0].Node.Receipt)
+	require.True(t, resp.Data.Transactions.PageInfo.HasNextPage)
+}
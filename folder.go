@@ -0,0 +1,208 @@
+package irys
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/tags"
+	"github.com/Ja7ad/irys/types"
+)
+
+const _folderProgressExt = ".folder"
+
+// folderProgress is uploaded files of UploadFolder by path relative to folder
+type folderProgress struct {
+	Files map[string]folderFile `json:"files"`
+}
+
+type folderFile struct {
+	ID      string    `json:"id"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+}
+
+// UploadFolder upload files of dir and path manifest of them, index is path served for manifest root
+// (empty for none). With WithJournal transaction of each uploaded file recorded in journal, so upload of
+// same folder after crash skip files uploaded and not modified since. Progress not read or written in dry run,
+// since ids of dry run are never posted.
+func (c *Client) UploadFolder(ctx context.Context, dir, index string) (types.Transaction, error) {
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+
+	files, err := folderFiles(root)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+	if len(files) == 0 {
+		return types.Transaction{}, errors.ErrEmptyManifest
+	}
+
+	manifest := types.Manifest{
+		Manifest: ManifestName,
+		Version:  ManifestVersion,
+		Paths:    make(map[string]types.ManifestPath, len(files)),
+	}
+
+	if len(index) != 0 {
+		if _, ok := files[index]; !ok {
+			return types.Transaction{}, fmt.Errorf("%w: %s", errors.ErrManifestIndexNotFound, index)
+		}
+		manifest.Index = &types.ManifestIndex{Path: index}
+	}
+
+	// nil journal keep no progress
+	j := c.journal
+	if c.dryRun {
+		j = nil
+	}
+
+	progress, err := j.folderProgress(root)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+
+	paths := make([]string, 0, len(files))
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	for _, p := range paths {
+		info := files[p]
+		if done, ok := progress.Files[p]; ok && done.Size == info.Size() && done.ModTime.Equal(info.ModTime()) {
+			c.debugMsg("[UploadFolder] skip path %s uploaded with transaction %s", p, done.ID)
+			manifest.Paths[p] = types.ManifestPath{ID: done.ID}
+			continue
+		}
+
+		var fileTags []types.Tag
+		if contentType := mime.TypeByExtension(path.Ext(p)); len(contentType) != 0 {
+			fileTags = append(fileTags, tags.WithContentType(contentType))
+		}
+
+		tx, err := c.UploadFile(ctx, filepath.Join(root, filepath.FromSlash(p)), fileTags...)
+		if err != nil {
+			return types.Transaction{}, fmt.Errorf("upload %s: %w", p, err)
+		}
+
+		c.debugMsg("[UploadFolder] uploaded path %s with transaction %s", p, tx.ID)
+		manifest.Paths[p] = types.ManifestPath{ID: tx.ID}
+
+		progress.Files[p] = folderFile{ID: tx.ID, Size: info.Size(), ModTime: info.ModTime()}
+		if err := j.saveFolderProgress(root, progress); err != nil {
+			return types.Transaction{}, err
+		}
+	}
+
+	tx, err := c.uploadManifest(ctx, manifest)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+
+	return tx, j.removeFolderProgress(root)
+}
+
+// folderFiles return regular files of root by slash separated path relative to root
+func folderFiles(root string) (map[string]fs.FileInfo, error) {
+	files := make(map[string]fs.FileInfo)
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = info
+		return nil
+	})
+	return files, err
+}
+
+// folderProgress read progress of folder upload, empty progress when journal disabled or not exists
+func (j *journal) folderProgress(root string) (folderProgress, error) {
+	progress := folderProgress{Files: make(map[string]folderFile)}
+	if j == nil {
+		return progress, nil
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	b, err := os.ReadFile(j.folderPath(root))
+	if os.IsNotExist(err) {
+		return progress, nil
+	}
+	if err != nil {
+		return progress, err
+	}
+
+	if err := json.Unmarshal(b, &progress); err != nil {
+		return progress, err
+	}
+	if progress.Files == nil {
+		progress.Files = make(map[string]folderFile)
+	}
+	return progress, nil
+}
+
+func (j *journal) saveFolderProgress(root string, progress folderProgress) error {
+	if j == nil {
+		return nil
+	}
+
+	b, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	// write to temp file and rename, so progress never left half written on crash
+	tmp := j.folderPath(root) + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, j.folderPath(root))
+}
+
+// removeFolderProgress remove progress of folder after manifest uploaded
+func (j *journal) removeFolderProgress(root string) error {
+	if j == nil {
+		return nil
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if err := os.Remove(j.folderPath(root)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// folderPath return path of progress file of folder, named by hash of folder absolute path
+func (j *journal) folderPath(root string) string {
+	hash := sha256.Sum256([]byte(root))
+	return j.path(hex.EncodeToString(hash[:16]), _folderProgressExt)
+}
@@ -0,0 +1,140 @@
+package irys
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Ja7ad/irys/currency"
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadFolderResume(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "images"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "index.html"), []byte("<html></html>"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "images", "1.png"), []byte("\x89PNG\r\n\x1a\n"), 0o600))
+
+	var uploads [][]byte
+	fail := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		// crash on upload of second file
+		if fail && len(uploads) == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		uploads = append(uploads, body)
+		_ = json.NewEncoder(w).Encode(types.Transaction{ID: fmt.Sprintf("tx%d", len(uploads))})
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	j, err := newJournal(t.TempDir())
+	require.NoError(t, err)
+
+	c := &Client{
+		client:   retryablehttp.NewClient(),
+		network:  Node(srv.URL),
+		currency: matic,
+		journal:  j,
+	}
+
+	_, err = c.UploadFolder(context.Background(), dir, "index.html")
+	require.Error(t, err)
+	require.Len(t, uploads, 1)
+
+	// rerun skip images/1.png uploaded before crash
+	fail = false
+	tx, err := c.UploadFolder(context.Background(), dir, "index.html")
+	require.NoError(t, err)
+	require.Equal(t, "tx3", tx.ID)
+	require.Len(t, uploads, 3)
+	require.Contains(t, string(uploads[1]), "text/html")
+	require.Contains(t, string(uploads[2]),
+		`{"manifest":"arweave/paths","version":"0.1.0","index":{"path":"index.html"},"paths":{"images/1.png":{"id":"tx1"},"index.html":{"id":"tx2"}}}`)
+
+	// progress removed after manifest uploaded
+	progress, err := j.folderProgress(dir)
+	require.NoError(t, err)
+	require.Empty(t, progress.Files)
+}
+
+func TestUploadFolderDryRun(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "images"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "index.html"), []byte("<html></html>"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "images", "1.png"), []byte("\x89PNG\r\n\x1a\n"), 0o600))
+
+	var posts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/price/"):
+			// balance cover images/1.png, not index.html
+			size, _ := strconv.Atoi(path.Base(r.URL.Path))
+			if size > 10 {
+				fmt.Fprint(w, 150)
+				return
+			}
+			fmt.Fprint(w, 50)
+		case r.URL.Path == "/account/balance/matic":
+			fmt.Fprint(w, `{"balance":"100"}`)
+		case r.Method == http.MethodPost:
+			posts++
+			_ = json.NewEncoder(w).Encode(types.Transaction{ID: fmt.Sprintf("tx%d", posts)})
+		}
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	j, err := newJournal(t.TempDir())
+	require.NoError(t, err)
+
+	c := &Client{
+		client:   retryablehttp.NewClient(),
+		network:  Node(srv.URL),
+		currency: matic,
+		journal:  j,
+		dryRun:   true,
+	}
+
+	// dry run fail on shortfall of index.html, id of images/1.png not kept as progress
+	_, err = c.UploadFolder(context.Background(), dir, "index.html")
+	require.ErrorIs(t, err, errs.ErrNotEnoughBalance)
+	require.Zero(t, posts)
+
+	progress, err := j.folderProgress(dir)
+	require.NoError(t, err)
+	require.Empty(t, progress.Files)
+
+	// real run upload all files and manifest
+	c.dryRun = false
+	tx, err := c.UploadFolder(context.Background(), dir, "index.html")
+	require.NoError(t, err)
+	require.Equal(t, "tx3", tx.ID)
+	require.Equal(t, 3, posts)
+}
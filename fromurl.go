@@ -0,0 +1,132 @@
+package irys
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// DefaultMaxSourceSize is default limit of resource size fetched by UploadFromURL
+const DefaultMaxSourceSize = 1 << 30
+
+// UploadFromURL fetch remote resource and upload it with its Content-Type, size of resource checked
+// by HEAD request for price before download. Resource spooled to temp file and uploaded by UploadReader,
+// resource bigger than WithMaxSourceSize (default DefaultMaxSourceSize) fail with ErrSourceTooLarge.
+// Remote source fetched by separate client, so it not get client headers and not affect node circuit breaker.
+func (c *Client) UploadFromURL(ctx context.Context, srcURL string, tags ...types.Tag) (types.Transaction, error) {
+	maxSize := c.maxSourceSize
+	if maxSize <= 0 {
+		maxSize = DefaultMaxSourceSize
+	}
+
+	size, err := c.sourceSize(ctx, srcURL)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+	if size > maxSize {
+		return types.Transaction{}, fmt.Errorf("%w: size %d, limit %d", errors.ErrSourceTooLarge, size, maxSize)
+	}
+
+	// with auto funding shortfall funded on upload
+	if size > 0 && !c.dryRun && c.autoFundLimit == nil {
+		if err := c.checkBalance(ctx, int(size)); err != nil {
+			return types.Transaction{}, err
+		}
+	}
+
+	req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodGet, srcURL, nil)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+
+	resp, err := c.external.Do(req)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+	defer resp.Body.Close()
+
+	if err := statusCheck(resp); err != nil {
+		return types.Transaction{}, err
+	}
+
+	body := io.LimitReader(resp.Body, maxSize+1)
+	if size > 0 {
+		// source may send more than announced size, which not priced
+		body = io.LimitReader(resp.Body, size)
+	}
+
+	f, err := os.CreateTemp("", "irys-source-*")
+	if err != nil {
+		return types.Transaction{}, err
+	}
+	defer func() {
+		f.Close()
+		os.Remove(f.Name())
+	}()
+
+	n, err := io.Copy(f, body)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+	if n > maxSize {
+		return types.Transaction{}, fmt.Errorf("%w: limit %d", errors.ErrSourceTooLarge, maxSize)
+	}
+
+	if contentType := resp.Header.Get("Content-Type"); len(contentType) != 0 {
+		tags = addContentType(contentType, tags...)
+	}
+
+	c.debugMsg("[UploadFromURL] fetched %d bytes from %s", n, srcURL)
+
+	return c.UploadReader(ctx, f, n, tags...)
+}
+
+// sourceSize return content length of remote resource by HEAD request, -1 if not known
+func (c *Client) sourceSize(ctx context.Context, srcURL string) (int64, error) {
+	req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodHead, srcURL, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := c.external.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// some servers not allow HEAD, size checked after download
+	if resp.StatusCode == http.StatusMethodNotAllowed {
+		return -1, nil
+	}
+
+	if err := statusCheck(resp); err != nil {
+		return 0, err
+	}
+
+	return resp.ContentLength, nil
+}
+
+// checkBalance return ErrNotEnoughBalance when balance is lower than price of size
+func (c *Client) checkBalance(ctx context.Context, size int) error {
+	price, err := c.GetPrice(ctx, size)
+	if err != nil {
+		return err
+	}
+
+	balance, err := c.GetBalance(ctx)
+	if err != nil {
+		return err
+	}
+
+	if balance.Cmp(price) < 0 {
+		return fmt.Errorf("%w: price %s, balance %s", errors.ErrNotEnoughBalance, price, balance)
+	}
+
+	return nil
+}
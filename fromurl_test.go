@@ -0,0 +1,98 @@
+package irys
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Ja7ad/irys/currency"
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadFromURL(t *testing.T) {
+	src := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Empty(t, r.Header.Get("X-Api-Key"))
+		require.Empty(t, r.Header.Get("X-Request-Key"))
+		w.Header().Set("Content-Type", "text/css")
+		_, _ = io.WriteString(w, "body { color: red; }")
+	}))
+	defer src.Close()
+
+	balance := 100
+	var uploaded []byte
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/price/"):
+			fmt.Fprint(w, 50)
+		case r.URL.Path == "/account/balance/matic":
+			fmt.Fprintf(w, `{"balance":"%d"}`, balance)
+		case r.URL.Path == "/tx/matic":
+			uploaded, _ = io.ReadAll(r.Body)
+			_ = json.NewEncoder(w).Encode(types.Transaction{ID: "tx"})
+		}
+	}))
+	defer node.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	c := &Client{
+		client:   retryablehttp.NewClient(),
+		network:  Node(node.URL),
+		currency: matic,
+	}
+	c.client.HTTPClient.Transport = &headerTransport{
+		next:    http.DefaultTransport,
+		headers: map[string]string{"X-Api-Key": "key"},
+	}
+	c.external = retryablehttp.NewClient()
+	c.external.HTTPClient.Transport = &headerTransport{next: http.DefaultTransport, external: true}
+
+	ctx := ContextWithHeaders(context.Background(), map[string]string{"X-Request-Key": "key"})
+	tx, err := c.UploadFromURL(ctx, src.URL+"/style.css")
+	require.NoError(t, err)
+	require.Equal(t, "tx", tx.ID)
+	require.Contains(t, string(uploaded), "body { color: red; }")
+	require.Contains(t, string(uploaded), "text/css")
+
+	balance = 10
+	_, err = c.UploadFromURL(context.Background(), src.URL+"/style.css")
+	require.ErrorIs(t, err, errs.ErrNotEnoughBalance)
+
+	balance = 100
+	c.maxSourceSize = 10
+	_, err = c.UploadFromURL(context.Background(), src.URL+"/style.css")
+	require.ErrorIs(t, err, errs.ErrSourceTooLarge)
+}
+
+func TestUploadFromURLUnknownSize(t *testing.T) {
+	src := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		// chunked response without content length
+		w.(http.Flusher).Flush()
+		_, _ = io.WriteString(w, strings.Repeat("a", 100))
+	}))
+	defer src.Close()
+
+	c := &Client{external: retryablehttp.NewClient(), maxSourceSize: 50}
+	c.external.RetryMax = 0
+
+	_, err := c.UploadFromURL(context.Background(), src.URL)
+	require.ErrorIs(t, err, errs.ErrSourceTooLarge)
+}
@@ -3,6 +3,7 @@ package irys
 import (
 	"context"
 	"math/big"
+	"sync"
 
 	"github.com/Ja7ad/irys/currency"
 	"github.com/Ja7ad/irys/errors"
@@ -15,7 +16,7 @@ import (
 
 func (c *Client) createTx(ctx context.Context, amount *big.Int) (string, error) {
 	switch c.currency.GetType() {
-	case currency.ETHEREUM, currency.MATIC, currency.AVALANCHE, currency.FANTOM, currency.BNB, currency.ARBITRUM:
+	case currency.ETHEREUM, currency.MATIC, currency.AVALANCHE, currency.FANTOM, currency.BNB, currency.ARBITRUM, currency.BASE:
 		c.debugMsg("[Transaction] create ethereum transaction")
 		hash, err := createEthTx(ctx, c, amount)
 		if err != nil {
@@ -23,6 +24,36 @@ func (c *Client) createTx(ctx context.Context, amount *big.Int) (string, error)
 		}
 		c.debugMsg("[Transaction] transaction with hash %s done", hash)
 		return hash, nil
+	case currency.ERC20:
+		token, ok := c.currency.(currency.Token)
+		if !ok {
+			return "", errors.ErrTokenNotSupported
+		}
+		c.debugMsg("[Transaction] create erc20 transfer transaction on contract %s", token.GetContractAddress())
+		hash, err := createErc20Tx(ctx, c, common.HexToAddress(token.GetContractAddress()), amount)
+		if err != nil {
+			return "", err
+		}
+		c.debugMsg("[Transaction] transaction with hash %s done", hash)
+		return hash, nil
+	case currency.APTOS, currency.COSMOS:
+		transferer, ok := c.currency.(currency.Transferer)
+		if !ok {
+			return "", errors.ErrTokenNotSupported
+		}
+		c.debugMsg("[Transaction] create %s transfer transaction to %s", c.currency.GetName(), c.contract)
+		// transfers of same account serialized so they not reuse sequence number
+		mu := txLock(c.currency.GetChain(), c.Address())
+		if err := lockContext(ctx, mu); err != nil {
+			return "", err
+		}
+		hash, err := transferer.Transfer(ctx, c.contract, amount)
+		mu.Unlock()
+		if err != nil {
+			return "", err
+		}
+		c.debugMsg("[Transaction] transaction with hash %s done", hash)
+		return hash, nil
 	// TODO: arweave not supported currently
 	case currency.ARWEAVE:
 
@@ -31,40 +62,52 @@ func (c *Client) createTx(ctx context.Context, amount *big.Int) (string, error)
 }
 
 func createEthTx(ctx context.Context, i *Client, amount *big.Int) (string, error) {
-	pubKey := i.currency.GetPublicKey()
-	client := i.currency.GetRPCClient()
-	fromAddress := crypto.PubkeyToAddress(*pubKey)
 	toAddress := common.HexToAddress(i.contract)
+	return sendTx(ctx, i, toAddress, amount, transferData(toAddress, amount))
+}
 
-	gasPrice, err := client.SuggestGasPrice(ctx)
-	if err != nil {
-		return "", err
-	}
-
-	chainID, err := client.ChainID(ctx)
-	if err != nil {
-		return "", err
-	}
+// createErc20Tx transfer amount of token to bundler address by call transfer on token contract
+func createErc20Tx(ctx context.Context, i *Client, tokenAddress common.Address, amount *big.Int) (string, error) {
+	return sendTx(ctx, i, tokenAddress, big.NewInt(0), transferData(common.HexToAddress(i.contract), amount))
+}
 
+// transferData encode erc-20 transfer(address,uint256) call
+func transferData(to common.Address, amount *big.Int) []byte {
 	var data []byte
 	transferFnSignature := []byte("transfer(address,uint256)")
 	hash := sha3.NewLegacyKeccak256()
 	hash.Write(transferFnSignature)
 	methodID := hash.Sum(nil)[:4]
 	data = append(data, methodID...)
-	paddedAddress := common.LeftPadBytes(toAddress.Bytes(), 32)
+	paddedAddress := common.LeftPadBytes(to.Bytes(), 32)
 	data = append(data, paddedAddress...)
 	paddedAmount := common.LeftPadBytes(amount.Bytes(), 32)
 	data = append(data, paddedAmount...)
+	return data
+}
 
-	gasLimit, err := client.EstimateGas(ctx, ethereum.CallMsg{
-		To:   &toAddress,
-		Data: data,
-	})
+func sendTx(ctx context.Context, i *Client, toAddress common.Address, value *big.Int, data []byte) (string, error) {
+	client := i.currency.GetRPCClient()
+	fromAddress := crypto.PubkeyToAddress(*i.currency.GetPublicKey())
+
+	gasPrice, gasLimit, err := estimateGas(ctx, i, toAddress, data)
 	if err != nil {
 		return "", err
 	}
 
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	// wait for pending top up of other caller only until ctx done, native and erc-20 transfers of wallet
+	// on same chain share nonce
+	mu := txLock("evm:"+chainID.String(), fromAddress.Hex())
+	if err := lockContext(ctx, mu); err != nil {
+		return "", err
+	}
+	defer mu.Unlock()
+
 	nonce, err := client.PendingNonceAt(ctx, fromAddress)
 	if err != nil {
 		return "", err
@@ -72,21 +115,89 @@ func createEthTx(ctx context.Context, i *Client, amount *big.Int) (string, error
 
 	tx := types.NewTransaction(
 		nonce,
-		common.HexToAddress(i.contract),
-		amount,
+		toAddress,
+		value,
 		gasLimit,
 		gasPrice,
 		data,
 	)
 
-	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(chainID), i.currency.GetPrivateKey())
+	signedTx, err := signTx(i.currency, tx, chainID)
 	if err != nil {
 		return "", err
 	}
 
+	// signing may wait for user (e.g. hardware wallet), transaction not sent when caller gave up meanwhile
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
 	if err = client.SendTransaction(ctx, signedTx); err != nil {
 		return "", err
 	}
 
 	return signedTx.Hash().Hex(), nil
 }
+
+// estimateGas return suggested gas price and estimated gas limit of transaction from currency address
+func estimateGas(ctx context.Context, i *Client, toAddress common.Address, data []byte) (*big.Int, uint64, error) {
+	client := i.currency.GetRPCClient()
+
+	gasPrice, err := client.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	gasLimit, err := client.EstimateGas(ctx, ethereum.CallMsg{
+		From: crypto.PubkeyToAddress(*i.currency.GetPublicKey()),
+		To:   &toAddress,
+		Data: data,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if config, ok := currency.ChainConfigOf(i.currency.GetType()); ok && config.GasLimitBuffer != 0 {
+		gasLimit += gasLimit * config.GasLimitBuffer / 100
+	}
+
+	return gasPrice, gasLimit, nil
+}
+
+// _txLocks hold funding lock per chain and wallet address, shared by all clients of same wallet (e.g. derived
+// by WithCurrency or created by New) so their funding transactions not reuse pending nonce
+var _txLocks sync.Map
+
+// txLock return funding lock of address on chain
+func txLock(chain, address string) *sync.Mutex {
+	mu, _ := _txLocks.LoadOrStore(chain+":"+address, new(sync.Mutex))
+	return mu.(*sync.Mutex)
+}
+
+// lockContext lock mu or return error of ctx when ctx done before, lock acquired after ctx done released
+func lockContext(ctx context.Context, mu *sync.Mutex) error {
+	locked := make(chan struct{})
+	go func() {
+		mu.Lock()
+		close(locked)
+	}()
+
+	select {
+	case <-locked:
+		return nil
+	case <-ctx.Done():
+		go func() {
+			<-locked
+			mu.Unlock()
+		}()
+		return ctx.Err()
+	}
+}
+
+// signTx sign funding transaction by currency signer (e.g. hardware wallet) or private key
+func signTx(cur currency.Currency, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	if s, ok := cur.(currency.TxSigner); ok {
+		return s.SignTx(tx, chainID)
+	}
+	return types.SignTx(tx, types.NewEIP155Signer(chainID), cur.GetPrivateKey())
+}
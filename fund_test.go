@@ -0,0 +1,454 @@
+package irys
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ja7ad/irys/currency"
+	"github.com/Ja7ad/irys/currency/simulated"
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateEthTx(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	backend := simulated.New(new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18)), key)
+	defer backend.Close()
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), backend)
+	require.NoError(t, err)
+
+	c := &Client{
+		mu:       new(sync.Mutex),
+		currency: matic,
+		contract: "0x853758425e953739F5438fd6fd0Efe04A477b039",
+	}
+
+	ctx := context.Background()
+
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		hash, err := c.createTx(ctx, big.NewInt(1000))
+		require.NoError(t, err)
+		backend.Commit()
+
+		tx, pending, err := backend.TransactionByHash(ctx, common.HexToHash(hash))
+		require.NoError(t, err)
+		require.False(t, pending)
+		require.Equal(t, nonce, tx.Nonce())
+
+		receipt, err := backend.TransactionReceipt(ctx, common.HexToHash(hash))
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), receipt.Status)
+	}
+}
+
+func TestEstimateTopUp(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	backend := simulated.New(new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18)), key)
+	defer backend.Close()
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), backend)
+	require.NoError(t, err)
+
+	c := &Client{
+		mu:       new(sync.Mutex),
+		currency: matic,
+		contract: "0x853758425e953739F5438fd6fd0Efe04A477b039",
+	}
+
+	ctx := context.Background()
+	amount := big.NewInt(1000)
+
+	estimate, err := c.EstimateTopUp(ctx, amount)
+	require.NoError(t, err)
+	require.Equal(t, amount, estimate.Amount)
+	require.NotZero(t, estimate.GasLimit)
+	require.Equal(t, new(big.Int).Mul(estimate.GasPrice, new(big.Int).SetUint64(estimate.GasLimit)), estimate.Fee)
+	require.Equal(t, new(big.Int).Add(amount, estimate.Fee), estimate.Total)
+
+	hash, err := c.createTx(ctx, amount)
+	require.NoError(t, err)
+	backend.Commit()
+
+	tx, _, err := backend.TransactionByHash(ctx, common.HexToHash(hash))
+	require.NoError(t, err)
+	require.Equal(t, estimate.GasLimit, tx.Gas())
+
+	_, err = c.EstimateTopUp(ctx, big.NewInt(0))
+	require.ErrorIs(t, err, errors.ErrInvalidAmount)
+}
+
+func TestBasicUploadFunding(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	backend := simulated.New(new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18)), key)
+	defer backend.Close()
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), backend)
+	require.NoError(t, err)
+
+	var (
+		confirmed   string
+		confirmFail bool
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/price/"):
+			fmt.Fprint(w, 150)
+		case r.URL.Path == "/account/balance/matic" && r.Method == http.MethodPost && confirmFail:
+			w.WriteHeader(http.StatusInternalServerError)
+		case r.URL.Path == "/account/balance/matic" && r.Method == http.MethodPost:
+			var req types.TxToBalanceRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			confirmed = req.TxId
+		case r.URL.Path == "/account/balance/matic":
+			fmt.Fprint(w, `{"balance":"100"}`)
+		case r.URL.Path == "/tx/matic":
+			_ = json.NewEncoder(w).Encode(types.Transaction{ID: "tx1"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := &Client{
+		client:   retryablehttp.NewClient(),
+		network:  Node(srv.URL),
+		mu:       new(sync.Mutex),
+		currency: matic,
+		contract: "0x853758425e953739F5438fd6fd0Efe04A477b039",
+	}
+	c.client.RetryMax = 0
+
+	result, err := c.BasicUpload(context.Background(), []byte("hello irys"))
+	require.NoError(t, err)
+	require.Equal(t, "tx1", result.ID)
+	require.True(t, result.Funded)
+	require.Equal(t, big.NewInt(150), result.FundedAmount)
+	require.NotEmpty(t, result.FundingTxHash)
+	require.Equal(t, confirmed, result.FundingTxHash)
+
+	// tx hash of sent funding returned when node not confirm it
+	confirmFail = true
+	result, err = c.BasicUpload(context.Background(), []byte("hello irys"))
+	require.Error(t, err)
+	require.Empty(t, result.ID)
+	require.True(t, result.Funded)
+	require.Equal(t, big.NewInt(150), result.FundedAmount)
+	require.NotEmpty(t, result.FundingTxHash)
+	require.NotEqual(t, confirmed, result.FundingTxHash)
+	require.Contains(t, err.Error(), result.FundingTxHash)
+}
+
+func TestCreateEthTxContext(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	backend := simulated.New(new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18)), key)
+	defer backend.Close()
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), backend)
+	require.NoError(t, err)
+
+	c := &Client{
+		mu:       new(sync.Mutex),
+		currency: matic,
+		contract: "0x853758425e953739F5438fd6fd0Efe04A477b039",
+	}
+
+	// pending top up of other caller hold lock until deadline of ctx
+	mu := txLock("evm:1337", crypto.PubkeyToAddress(key.PublicKey).Hex())
+	mu.Lock()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = c.createTx(ctx, big.NewInt(1000))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// lock released after other caller done
+	mu.Unlock()
+	hash, err := c.createTx(context.Background(), big.NewInt(1000))
+	require.NoError(t, err)
+	require.NotEmpty(t, hash)
+}
+
+func TestTxLock(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	backend := simulated.New(new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18)), key)
+	defer backend.Close()
+
+	hexKey := hex.EncodeToString(crypto.FromECDSA(key))
+	matic, err := currency.NewWithBackend(currency.MATIC, hexKey, backend)
+	require.NoError(t, err)
+	token, err := currency.NewERC20WithBackend("usdc-polygon", "0x3c499c542cEF5E3811e1192ce70d8cC03d5c3359", hexKey, backend)
+	require.NoError(t, err)
+
+	const funds = 4
+	clients := []*Client{
+		{mu: new(sync.Mutex), currency: matic, contract: "0x853758425e953739F5438fd6fd0Efe04A477b039"},
+		{mu: new(sync.Mutex), currency: token, contract: "0x853758425e953739F5438fd6fd0Efe04A477b039"},
+	}
+
+	// native and erc-20 transfers of wallet on same chain get own nonce
+	var wg sync.WaitGroup
+	errCh := make(chan error, funds*len(clients))
+	for _, c := range clients {
+		for i := 0; i < funds; i++ {
+			wg.Add(1)
+			go func(c *Client) {
+				defer wg.Done()
+				_, err := c.createTx(context.Background(), big.NewInt(1000))
+				errCh <- err
+			}(c)
+		}
+	}
+	wg.Wait()
+	close(errCh)
+	for err := range errCh {
+		require.NoError(t, err)
+	}
+
+	backend.Commit()
+	nonce, err := backend.PendingNonceAt(context.Background(), crypto.PubkeyToAddress(key.PublicKey))
+	require.NoError(t, err)
+	require.Equal(t, uint64(funds*len(clients)), nonce)
+
+	require.Same(t, txLock("evm:1", "0x1"), txLock("evm:1", "0x1"))
+	require.NotSame(t, txLock("evm:1", "0x1"), txLock("evm:137", "0x1"))
+}
+
+// concurrentTransfer is Transferer which record max concurrent transfers
+type concurrentTransfer struct {
+	currency.Currency
+	mu         sync.Mutex
+	active     int
+	concurrent int
+}
+
+func (s *concurrentTransfer) Transfer(context.Context, string, *big.Int) (string, error) {
+	s.mu.Lock()
+	s.active++
+	if s.active > s.concurrent {
+		s.concurrent = s.active
+	}
+	s.mu.Unlock()
+
+	time.Sleep(5 * time.Millisecond)
+
+	s.mu.Lock()
+	s.active--
+	s.mu.Unlock()
+	return "hash", nil
+}
+
+func TestTransferLock(t *testing.T) {
+	kyve, err := currency.NewKyve("f4a2b939592564feb35ab10a8e04f6f2fe0943579fb3c9c33505298978b74893", "")
+	require.NoError(t, err)
+
+	stub := &concurrentTransfer{Currency: kyve}
+
+	// transfers of clients of same account serialized
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c := &Client{mu: new(sync.Mutex), currency: stub, contract: "bundler"}
+			_, err := c.createTx(context.Background(), big.NewInt(1000))
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, 1, stub.concurrent)
+}
+
+// balanceNode serve balance and funding confirmation of currency name only, other paths fail
+func balanceNode(t *testing.T, name string, confirmed *string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/info":
+			// funding limits of node
+			fmt.Fprint(w, `{"version":"0.2.0","addresses":{}}`)
+		case r.URL.Path == "/account/balance/"+name && r.Method == http.MethodPost:
+			var req types.TxToBalanceRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			*confirmed = req.TxId
+		case r.URL.Path == "/account/balance/"+name && len(r.URL.Query().Get("address")) != 0:
+			fmt.Fprint(w, `{"balance":"100"}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestCurrencyBalancePath(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	backend := simulated.New(new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18)), key)
+	defer backend.Close()
+
+	eth, err := currency.NewWithBackend(currency.ETHEREUM, hex.EncodeToString(crypto.FromECDSA(key)), backend)
+	require.NoError(t, err)
+
+	var confirmed string
+	srv := balanceNode(t, "ethereum", &confirmed)
+	defer srv.Close()
+
+	c, err := New(Node(srv.URL), eth, false, WithContractAddress("0x853758425e953739F5438fd6fd0Efe04A477b039"),
+		WithCustomRetryMax(0))
+	require.NoError(t, err)
+	defer c.Close()
+
+	balance, err := c.GetBalance(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(100), balance)
+
+	// funding confirmed on balance of client currency
+	require.NoError(t, c.TopUpBalance(context.Background(), big.NewInt(1000)))
+	require.NotEmpty(t, confirmed)
+}
+
+// transferStub is currency funded by Transferer without chain, Transfer return hash
+type transferStub struct {
+	currency.Currency
+	hash string
+}
+
+func (s transferStub) Transfer(_ context.Context, _ string, _ *big.Int) (string, error) {
+	return s.hash, nil
+}
+
+func TestTransfererBalancePath(t *testing.T) {
+	aptos, err := currency.NewAptos("c5338cd251c22daa8c9c9cc94f498cc8a5c7e1d2e75287a5dda91096fe64efa5", "")
+	require.NoError(t, err)
+	kyve, err := currency.NewKyve("f4a2b939592564feb35ab10a8e04f6f2fe0943579fb3c9c33505298978b74893", "")
+	require.NoError(t, err)
+
+	for _, cur := range []currency.Currency{aptos, kyve} {
+		t.Run(cur.GetName(), func(t *testing.T) {
+			var confirmed string
+			srv := balanceNode(t, cur.GetName(), &confirmed)
+			defer srv.Close()
+
+			c, err := New(Node(srv.URL), cur, false, WithContractAddress("bundler"), WithCustomRetryMax(0))
+			require.NoError(t, err)
+			defer c.Close()
+
+			balance, err := c.GetBalance(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, big.NewInt(100), balance)
+
+			c.(*Client).currency = transferStub{Currency: cur, hash: "0xhash"}
+			require.NoError(t, c.TopUpBalance(context.Background(), big.NewInt(1000)))
+			require.Equal(t, "0xhash", confirmed)
+		})
+	}
+}
+
+// fakeChain is EthBackend of chain id which accept any transaction
+type fakeChain struct {
+	chainID *big.Int
+	mu      sync.Mutex
+	sent    []*ethtypes.Transaction
+}
+
+func (f *fakeChain) ChainID(context.Context) (*big.Int, error) {
+	return f.chainID, nil
+}
+
+func (f *fakeChain) SuggestGasPrice(context.Context) (*big.Int, error) {
+	return big.NewInt(1), nil
+}
+
+func (f *fakeChain) EstimateGas(context.Context, ethereum.CallMsg) (uint64, error) {
+	return 21000, nil
+}
+
+func (f *fakeChain) PendingNonceAt(context.Context, common.Address) (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return uint64(len(f.sent)), nil
+}
+
+func (f *fakeChain) SendTransaction(_ context.Context, tx *ethtypes.Transaction) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, tx)
+	return nil
+}
+
+func TestBaseBalancePath(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	// base mainnet
+	base, err := currency.NewWithBackend(currency.BASE, hex.EncodeToString(crypto.FromECDSA(key)), &fakeChain{chainID: big.NewInt(8453)})
+	require.NoError(t, err)
+	require.Equal(t, "base-eth", base.GetName())
+
+	var confirmed string
+	srv := balanceNode(t, "base-eth", &confirmed)
+	defer srv.Close()
+
+	c, err := New(Node(srv.URL), base, false, WithContractAddress("0x853758425e953739F5438fd6fd0Efe04A477b039"),
+		WithCustomRetryMax(0))
+	require.NoError(t, err)
+	defer c.Close()
+
+	balance, err := c.GetBalance(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(100), balance)
+
+	require.NoError(t, c.TopUpBalance(context.Background(), big.NewInt(1000)))
+	require.NotEmpty(t, confirmed)
+}
+
+func TestERC20BalancePath(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	token, err := currency.NewERC20WithBackend("usdc-eth", "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48",
+		hex.EncodeToString(crypto.FromECDSA(key)), &fakeChain{chainID: big.NewInt(1)})
+	require.NoError(t, err)
+
+	var confirmed string
+	srv := balanceNode(t, "usdc-eth", &confirmed)
+	defer srv.Close()
+
+	c, err := New(Node(srv.URL), token, false, WithContractAddress("0x853758425e953739F5438fd6fd0Efe04A477b039"),
+		WithCustomRetryMax(0))
+	require.NoError(t, err)
+	defer c.Close()
+
+	balance, err := c.GetBalance(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(100), balance)
+
+	// token transfer confirmed on balance of token
+	require.NoError(t, c.TopUpBalance(context.Background(), big.NewInt(1000)))
+	require.NotEmpty(t, confirmed)
+}
@@ -0,0 +1,266 @@
+package irys
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// Gateway download data from gateway without upload client (no currency or node needed),
+// safe for concurrent use.
+type Gateway struct {
+	url          string
+	client       *retryablehttp.Client
+	headers      map[string]string
+	authHeader   string
+	authValue    func() (string, error)
+	cacheControl string
+	cache        downloadCache
+}
+
+// GatewayOption customize Gateway created by NewGateway
+type GatewayOption func(g *Gateway)
+
+// WithGatewayHTTPClient set http client of gateway requests
+func WithGatewayHTTPClient(client *http.Client) GatewayOption {
+	return func(g *Gateway) {
+		g.client.HTTPClient = client
+	}
+}
+
+// WithGatewayHeaders add headers to every gateway request
+func WithGatewayHeaders(headers map[string]string) GatewayOption {
+	return func(g *Gateway) {
+		for k, v := range headers {
+			g.headers[k] = v
+		}
+	}
+}
+
+// WithGatewayAuth set header with value generated per request (e.g. bearer token of private gateway)
+func WithGatewayAuth(name string, value func() (string, error)) GatewayOption {
+	return func(g *Gateway) {
+		g.authHeader = name
+		g.authValue = value
+	}
+}
+
+// WithGatewayCacheControl set Cache-Control header of requests (e.g. no-cache to force revalidation by gateway)
+func WithGatewayCacheControl(value string) GatewayOption {
+	return func(g *Gateway) {
+		g.cacheControl = value
+	}
+}
+
+// WithGatewayCache cache downloads in memory up to maxBytes, responses are cached by Cache-Control
+// (no-store skipped, max-age and no-cache revalidated with ETag)
+func WithGatewayCache(maxBytes int64) GatewayOption {
+	return func(g *Gateway) {
+		g.cache = newMemoryCache(maxBytes)
+	}
+}
+
+// NewGateway create gateway client of url (e.g. https://gateway.irys.xyz)
+func NewGateway(url string, opts ...GatewayOption) *Gateway {
+	g := &Gateway{
+		url:     strings.TrimRight(url, _gatewayURLSeparator),
+		client:  retryablehttp.NewClient(),
+		headers: make(map[string]string),
+	}
+	g.client.Logger = nil
+	g.client.ErrorHandler = retryablehttp.PassthroughErrorHandler
+
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	return g
+}
+
+// URL return gateway url of transaction
+func (g *Gateway) URL(txId string) string {
+	return fmt.Sprintf(_downloadPath, g.url, txId)
+}
+
+// Download get data of transaction, served from cache when fresh
+func (g *Gateway) Download(ctx context.Context, txId string) (*types.File, error) {
+	req, err := g.request(ctx, http.MethodGet, txId)
+	if err != nil {
+		return nil, err
+	}
+
+	var cached *cacheEntry
+	if g.cache != nil && g.cacheControl != "no-store" {
+		if entry, ok := g.cache.get(txId); ok {
+			if entry.fresh(time.Now()) && !strings.Contains(g.cacheControl, "no-cache") {
+				return entry.file(), nil
+			}
+			if len(entry.ETag) != 0 {
+				cached = entry
+				req.Header.Set("If-None-Match", entry.ETag)
+			}
+		}
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		resp.Body.Close()
+		return nil, ctx.Err()
+	default:
+		if resp.StatusCode == http.StatusNotModified && cached != nil {
+			resp.Body.Close()
+			revalidated := *cached
+			revalidated.Stored = time.Now()
+			g.cache.put(txId, &revalidated)
+			return revalidated.file(), nil
+		}
+
+		if err := statusCheck(resp); err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+
+		return g.store(txId, resp)
+	}
+}
+
+// Exists report transaction data is available on gateway
+func (g *Gateway) Exists(ctx context.Context, txId string) (bool, error) {
+	_, err := g.Head(ctx, txId)
+	if stderrors.Is(err, errors.ErrTxNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Head return headers of transaction data without download body
+func (g *Gateway) Head(ctx context.Context, txId string) (*types.File, error) {
+	req, err := g.request(ctx, http.MethodHead, txId)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("%w: %s", errors.ErrTxNotFound, txId)
+		}
+
+		if err := statusCheck(resp); err != nil {
+			return nil, err
+		}
+
+		return &types.File{
+			Data:          http.NoBody,
+			Header:        resp.Header,
+			ContentLength: resp.ContentLength,
+			ContentType:   resp.Header.Get("Content-Type"),
+		}, nil
+	}
+}
+
+func (g *Gateway) request(ctx context.Context, method, txId string) (*retryablehttp.Request, error) {
+	req, err := retryablehttp.NewRequestWithContext(ctx, method, g.URL(txId), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range g.headers {
+		req.Header.Set(k, v)
+	}
+
+	if len(g.cacheControl) != 0 {
+		req.Header.Set("Cache-Control", g.cacheControl)
+	}
+
+	if g.authValue != nil {
+		value, err := g.authValue()
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set(g.authHeader, value)
+	}
+
+	return req, nil
+}
+
+// store cache response body when cacheable and fit in cache
+func (g *Gateway) store(txId string, resp *http.Response) (*types.File, error) {
+	file := &types.File{
+		Data:          resp.Body,
+		Header:        resp.Header,
+		ContentLength: resp.ContentLength,
+		ContentType:   resp.Header.Get("Content-Type"),
+	}
+
+	if g.cache == nil || g.cacheControl == "no-store" || hasDirective(resp.Header, "no-store") ||
+		resp.ContentLength < 0 || resp.ContentLength > g.cache.maxBytes() {
+		return file, nil
+	}
+
+	b := make([]byte, resp.ContentLength)
+	_, err := io.ReadFull(resp.Body, b)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &cacheEntry{ETag: resp.Header.Get("ETag"), Header: resp.Header, Stored: time.Now(), Data: b}
+	g.cache.put(txId, entry)
+
+	return entry.file(), nil
+}
+
+// fresh report entry can be served without revalidation by its Cache-Control, irys data is immutable
+// so entries without directives are fresh
+func (e *cacheEntry) fresh(now time.Time) bool {
+	if hasDirective(e.Header, "no-cache") {
+		return false
+	}
+	if hasDirective(e.Header, "immutable") {
+		return true
+	}
+	if maxAge, ok := directiveValue(e.Header, "max-age"); ok {
+		seconds, err := strconv.Atoi(maxAge)
+		return err == nil && now.Sub(e.Stored) < time.Duration(seconds)*time.Second
+	}
+	return true
+}
+
+func hasDirective(header http.Header, name string) bool {
+	_, ok := directiveValue(header, name)
+	return ok
+}
+
+// directiveValue return value of Cache-Control directive of header
+func directiveValue(header http.Header, name string) (string, bool) {
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		if strings.EqualFold(key, name) {
+			return strings.Trim(value, `"`), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,76 @@
+package irys
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGatewayDownload(t *testing.T) {
+	requests := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		requests[r.URL.Path]++
+
+		switch r.URL.Path {
+		case "/fresh":
+			w.Header().Set("Cache-Control", "public, max-age=3600")
+		case "/revalidate":
+			w.Header().Set("Cache-Control", "no-cache")
+			w.Header().Set("ETag", `"v1"`)
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		case "/private":
+			w.Header().Set("Cache-Control", "no-store")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = io.WriteString(w, "data of "+r.URL.Path)
+	}))
+	defer srv.Close()
+
+	g := NewGateway(srv.URL+"/",
+		WithGatewayAuth("Authorization", func() (string, error) { return "Bearer token", nil }),
+		WithGatewayCache(1<<20),
+	)
+	ctx := context.Background()
+
+	for _, path := range []string{"fresh", "revalidate", "private"} {
+		for i := 0; i < 2; i++ {
+			file, err := g.Download(ctx, path)
+			require.NoError(t, err)
+			b, err := io.ReadAll(file.Data)
+			require.NoError(t, err)
+			require.Equal(t, "data of /"+path, string(b))
+			require.Equal(t, "text/plain", file.ContentType)
+		}
+	}
+
+	require.Equal(t, 1, requests["/fresh"])
+	require.Equal(t, 2, requests["/revalidate"])
+	require.Equal(t, 2, requests["/private"])
+
+	head, err := g.Head(ctx, "fresh")
+	require.NoError(t, err)
+	require.Equal(t, "text/plain", head.ContentType)
+
+	ok, err := g.Exists(ctx, "fresh")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = g.Exists(ctx, "missing")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, err = g.Head(ctx, "missing")
+	require.ErrorIs(t, err, errs.ErrTxNotFound)
+}
@@ -0,0 +1,320 @@
+package irys
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// reusable query fragments, appended to queries which use them
+const (
+	_receiptFragment = "fragment ReceiptFields on Receipt { signature timestamp version deadlineHeight }"
+
+	_receiptQuery = "query ($ids: [String!], $limit: Int) { transactions(ids: $ids, limit: $limit) { edges { node { %s " +
+		"receipt { ...ReceiptFields } } } } } " +
+		_receiptFragment
+	_tagQuery = "query ($name: String!, $values: [String!]!, $limit: Int) { transactions(tags: [{name: $name, values: $values}], " +
+		"limit: $limit) { edges { node { id } } } }"
+	_ownerTagQuery = "query ($name: String!, $values: [String!]!, $owners: [String!], $limit: Int) { transactions(" +
+		"tags: [{name: $name, values: $values}], owners: $owners, limit: $limit) { edges { node { id } } } }"
+)
+
+const (
+	// _defaultSearchPageSize is page size of search when not set
+	_defaultSearchPageSize = 100
+	// _receiptBatchSize is max ids of one receipt query, node limit page of transactions to 100
+	_receiptBatchSize = 100
+)
+
+// _searchArgs is graphql variable type of search arguments, in order of query
+var _searchArgs = []struct {
+	name     string
+	typeName string
+}{
+	{name: "tags", typeName: "[TagFilter!]"},
+	{name: "owners", typeName: "[String!]"},
+	{name: "currency", typeName: "String"},
+	{name: "timestamp", typeName: "TimestampFilter"},
+	{name: "order", typeName: "SortOrder"},
+	{name: "limit", typeName: "Int"},
+	{name: "after", typeName: "String"},
+}
+
+// _blockSearchArgs is graphql variable type of search arguments of arweave schema, in order of query
+var _blockSearchArgs = []struct {
+	name     string
+	typeName string
+}{
+	{name: "tags", typeName: "[TagFilter!]"},
+	{name: "owners", typeName: "[String!]"},
+	{name: "block", typeName: "BlockFilter"},
+	{name: "sort", typeName: "SortOrder"},
+	{name: "first", typeName: "Int"},
+	{name: "after", typeName: "String"},
+}
+
+// _receiptFields is allowed transaction fields of receipt query, others rejected to keep query schema safe
+var _receiptFields = map[types.ReceiptField]struct{}{
+	types.ReceiptFieldID:        {},
+	types.ReceiptFieldAddress:   {},
+	types.ReceiptFieldCurrency:  {},
+	types.ReceiptFieldTimestamp: {},
+}
+
+func receiptQuery(txIds []string, fields []types.ReceiptField) (types.GraphqlRequest, error) {
+	selection := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if _, ok := _receiptFields[f]; !ok {
+			return types.GraphqlRequest{}, fmt.Errorf("%w: %s", errors.ErrInvalidReceiptField, f)
+		}
+		selection = append(selection, string(f))
+	}
+
+	return types.GraphqlRequest{
+		Query:     fmt.Sprintf(_receiptQuery, strings.Join(selection, " ")),
+		Variables: map[string]any{"ids": txIds, "limit": len(txIds)},
+	}, nil
+}
+
+// tagQuery build query of transactions with tag, owned by one of owners when given
+func tagQuery(name, value string, limit int, owners ...string) types.GraphqlRequest {
+	if len(owners) != 0 {
+		return types.GraphqlRequest{
+			Query:     _ownerTagQuery,
+			Variables: map[string]any{"name": name, "values": []string{value}, "owners": owners, "limit": limit},
+		}
+	}
+
+	return types.GraphqlRequest{
+		Query:     _tagQuery,
+		Variables: map[string]any{"name": name, "values": []string{value}, "limit": limit},
+	}
+}
+
+// searchQuery build transactions query of set filters, values passed only as variables
+func searchQuery(q types.SearchQuery, after string) (types.GraphqlRequest, error) {
+	switch q.Order {
+	case "", types.SortAsc, types.SortDesc:
+	default:
+		return types.GraphqlRequest{}, fmt.Errorf("%w: order %s", errors.ErrInvalidSearchQuery, q.Order)
+	}
+
+	if !q.From.IsZero() && !q.To.IsZero() && q.To.Before(q.From) {
+		return types.GraphqlRequest{}, fmt.Errorf("%w: timestamp range", errors.ErrInvalidSearchQuery)
+	}
+
+	limit := q.PageSize
+	if limit <= 0 {
+		limit = _defaultSearchPageSize
+	}
+
+	vars := map[string]any{"limit": limit}
+	if len(q.Tags) != 0 {
+		vars["tags"] = q.Tags
+	}
+	if len(q.Addresses) != 0 {
+		vars["owners"] = q.Addresses
+	}
+	if len(q.Currency) != 0 {
+		vars["currency"] = q.Currency
+	}
+	if !q.From.IsZero() || !q.To.IsZero() {
+		timestamp := map[string]int64{}
+		if !q.From.IsZero() {
+			timestamp["from"] = q.From.UnixMilli()
+		}
+		if !q.To.IsZero() {
+			timestamp["to"] = q.To.UnixMilli()
+		}
+		vars["timestamp"] = timestamp
+	}
+	if len(q.Order) != 0 {
+		vars["order"] = q.Order
+	}
+	if len(after) != 0 {
+		vars["after"] = after
+	}
+
+	params := make([]string, 0, len(vars))
+	args := make([]string, 0, len(vars))
+	for _, arg := range _searchArgs {
+		if _, ok := vars[arg.name]; !ok {
+			continue
+		}
+		params = append(params, fmt.Sprintf("$%s: %s", arg.name, arg.typeName))
+		args = append(args, fmt.Sprintf("%s: $%s", arg.name, arg.name))
+	}
+
+	return types.GraphqlRequest{
+		Query: fmt.Sprintf("query (%s) { transactions(%s) { edges { cursor node { id address currency timestamp "+
+			"tags { name value } } } pageInfo { hasNextPage } } }", strings.Join(params, ", "), strings.Join(args, ", ")),
+		Variables: vars,
+	}, nil
+}
+
+// blockSearch report query filter block height, which is searched on gateway with arweave graphql schema
+func blockSearch(q types.SearchQuery) bool {
+	return q.MinBlockHeight != 0 || q.MaxBlockHeight != 0
+}
+
+// blockSearchQuery build transactions query of arweave graphql schema (gateway), it filter tags, owners and
+// block height range, currency and timestamp range not supported by schema
+func blockSearchQuery(q types.SearchQuery, after string) (types.GraphqlRequest, error) {
+	if q.MaxBlockHeight != 0 && q.MaxBlockHeight < q.MinBlockHeight {
+		return types.GraphqlRequest{}, fmt.Errorf("%w: block height range", errors.ErrInvalidSearchQuery)
+	}
+	if len(q.Currency) != 0 || !q.From.IsZero() || !q.To.IsZero() {
+		return types.GraphqlRequest{}, fmt.Errorf("%w: block height not combined with currency or timestamp",
+			errors.ErrInvalidSearchQuery)
+	}
+
+	sort := "HEIGHT_DESC"
+	switch q.Order {
+	case "", types.SortDesc:
+	case types.SortAsc:
+		sort = "HEIGHT_ASC"
+	default:
+		return types.GraphqlRequest{}, fmt.Errorf("%w: order %s", errors.ErrInvalidSearchQuery, q.Order)
+	}
+
+	first := q.PageSize
+	if first <= 0 {
+		first = _defaultSearchPageSize
+	}
+
+	block := map[string]int64{}
+	if q.MinBlockHeight != 0 {
+		block["min"] = q.MinBlockHeight
+	}
+	if q.MaxBlockHeight != 0 {
+		block["max"] = q.MaxBlockHeight
+	}
+
+	vars := map[string]any{"block": block, "sort": sort, "first": first}
+	if len(q.Tags) != 0 {
+		vars["tags"] = q.Tags
+	}
+	if len(q.Addresses) != 0 {
+		vars["owners"] = q.Addresses
+	}
+	if len(after) != 0 {
+		vars["after"] = after
+	}
+
+	params := make([]string, 0, len(vars))
+	args := make([]string, 0, len(vars))
+	for _, arg := range _blockSearchArgs {
+		if _, ok := vars[arg.name]; !ok {
+			continue
+		}
+		params = append(params, fmt.Sprintf("$%s: %s", arg.name, arg.typeName))
+		args = append(args, fmt.Sprintf("%s: $%s", arg.name, arg.name))
+	}
+
+	return types.GraphqlRequest{
+		Query: fmt.Sprintf("query (%s) { transactions(%s) { edges { cursor node { id owner { address } "+
+			"tags { name value } block { height timestamp } } } pageInfo { hasNextPage } } }",
+			strings.Join(params, ", "), strings.Join(args, ", ")),
+		Variables: vars,
+	}, nil
+}
+
+// arweaveTransactionsData is data of transactions query of arweave graphql schema
+type arweaveTransactionsData struct {
+	Transactions struct {
+		Edges []struct {
+			Cursor string `json:"cursor"`
+			Node   struct {
+				ID    string `json:"id"`
+				Owner struct {
+					Address string `json:"address"`
+				} `json:"owner"`
+				Tags  []types.Tag `json:"tags"`
+				Block *struct {
+					Height    int64 `json:"height"`
+					Timestamp int64 `json:"timestamp"`
+				} `json:"block"`
+			} `json:"node"`
+		} `json:"edges"`
+		PageInfo types.PageInfo `json:"pageInfo"`
+	} `json:"transactions"`
+}
+
+// transactions convert arweave transactions to transactions of node schema, timestamp of block converted
+// to milliseconds
+func (d arweaveTransactionsData) transactions() types.TransactionsData {
+	var data types.TransactionsData
+	data.Transactions.PageInfo = d.Transactions.PageInfo
+	for _, edge := range d.Transactions.Edges {
+		node := types.TransactionNode{
+			ID:      edge.Node.ID,
+			Address: edge.Node.Owner.Address,
+			Tags:    edge.Node.Tags,
+		}
+		if edge.Node.Block != nil {
+			node.BlockHeight = edge.Node.Block.Height
+			node.Timestamp = edge.Node.Block.Timestamp * 1000
+		}
+		data.Transactions.Edges = append(data.Transactions.Edges, types.TransactionEdge{Cursor: edge.Cursor, Node: node})
+	}
+	return data
+}
+
+// graphqlQuery post query to node graphql endpoint and decode data, errors of response returned as ErrGraphql
+func graphqlQuery[T any](ctx context.Context, c *Client, query types.GraphqlRequest) (T, error) {
+	return graphqlQueryAt[T](ctx, c, fmt.Sprintf(_graphql, c.network), query)
+}
+
+// graphqlQueryAt post query to graphql endpoint of url (node or gateway) and decode data
+func graphqlQueryAt[T any](ctx context.Context, c *Client, url string, query types.GraphqlRequest) (T, error) {
+	var data T
+
+	b, err := c.marshal(&query)
+	if err != nil {
+		return data, err
+	}
+
+	// graphql query is read only
+	req, err := retryablehttp.NewRequestWithContext(withIdempotencyKey(ctx, url), http.MethodPost, url, bytes.NewBuffer(b))
+	if err != nil {
+		return data, err
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return data, err
+	}
+	defer resp.Body.Close()
+
+	select {
+	case <-ctx.Done():
+		return data, ctx.Err()
+	default:
+		if err := statusCheck(resp); err != nil {
+			return data, err
+		}
+
+		response, err := decodeBody[types.GraphqlResponse[T]](c.codec, c.limitBody(EndpointGraphql, resp.Body))
+		if err != nil {
+			return data, err
+		}
+
+		if len(response.Errors) != 0 {
+			messages := make([]string, 0, len(response.Errors))
+			for _, e := range response.Errors {
+				messages = append(messages, e.Message)
+			}
+			return data, fmt.Errorf("%w: %s", errors.ErrGraphql, strings.Join(messages, "; "))
+		}
+
+		return response.Data, nil
+	}
+}
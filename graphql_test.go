@@ -0,0 +1,119 @@
+package irys
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReceiptQuery(t *testing.T) {
+	query, err := receiptQuery([]string{`tx"]) { injected }`}, []types.ReceiptField{types.ReceiptFieldID})
+	require.NoError(t, err)
+	require.NotContains(t, query.Query, "injected")
+	require.Equal(t, []string{`tx"]) { injected }`}, query.Variables["ids"])
+
+	_, err = receiptQuery([]string{"tx"}, []types.ReceiptField{"id } injected {"})
+	require.ErrorIs(t, err, errs.ErrInvalidReceiptField)
+}
+
+func TestGraphqlQuery(t *testing.T) {
+	var request types.GraphqlRequest
+	fail := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(b, &request)
+		if fail {
+			_, _ = io.WriteString(w, `{"errors":[{"message":"bad query"}]}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"data":{"transactions":{"edges":[{"node":{"id":"tx","receipt":{"signature":"sig"}}}]}}}`)
+	}))
+	defer srv.Close()
+
+	c := &Client{client: retryablehttp.NewClient(), network: Node(srv.URL)}
+
+	receipt, err := c.GetReceipt(context.Background(), "tx")
+	require.NoError(t, err)
+	require.Equal(t, "tx", receipt.ID)
+	require.Equal(t, "sig", receipt.Signature)
+	require.Equal(t, []any{"tx"}, request.Variables["ids"])
+
+	fail = true
+	_, err = c.findByTag(context.Background(), "name", "value")
+	require.ErrorIs(t, err, errs.ErrGraphql)
+}
+
+func TestGetReceiptFields(t *testing.T) {
+	var request types.GraphqlRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(b, &request))
+		_, _ = io.WriteString(w, `{"data":{"transactions":{"edges":[{"node":{"id":"tx","address":"0xabc",`+
+			`"currency":"matic","timestamp":1700000000000,"receipt":{"signature":"sig","timestamp":1700000000001}}}]}}}`)
+	}))
+	defer srv.Close()
+
+	c := &Client{client: retryablehttp.NewClient(), network: Node(srv.URL)}
+	WithReceiptFields(types.ReceiptFieldAddress, types.ReceiptFieldCurrency, types.ReceiptFieldTimestamp)(c)
+
+	receipt, err := c.GetReceipt(context.Background(), "tx")
+	require.NoError(t, err)
+	require.Contains(t, request.Query, "node { address currency timestamp receipt {")
+	require.Equal(t, types.Receipt{
+		Signature:            "sig",
+		Timestamp:            1700000000001,
+		ID:                   "tx",
+		Address:              "0xabc",
+		Currency:             "matic",
+		TransactionTimestamp: 1700000000000,
+	}, receipt)
+}
+
+func TestGetReceipts(t *testing.T) {
+	var batches [][]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request types.GraphqlRequest
+		b, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(b, &request))
+		require.Contains(t, request.Query, " id ")
+
+		ids := request.Variables["ids"].([]any)
+		batches = append(batches, ids)
+
+		var data types.TransactionsData
+		for _, id := range ids {
+			if id == "missing" {
+				continue
+			}
+			node := types.TransactionNode{ID: id.(string)}
+			node.Receipt.Signature = "sig-" + node.ID
+			data.Transactions.Edges = append(data.Transactions.Edges, types.TransactionEdge{Node: node})
+		}
+		_ = json.NewEncoder(w).Encode(types.GraphqlResponse[types.TransactionsData]{Data: data})
+	}))
+	defer srv.Close()
+
+	c := &Client{client: retryablehttp.NewClient(), network: Node(srv.URL)}
+
+	ids := []string{"missing"}
+	for i := 0; i < 150; i++ {
+		ids = append(ids, fmt.Sprintf("tx%d", i))
+	}
+
+	receipts, err := c.GetReceipts(context.Background(), ids)
+	require.NoError(t, err)
+	require.Len(t, batches, 2)
+	require.Len(t, batches[0], _receiptBatchSize)
+	require.Len(t, receipts, 150)
+	require.Equal(t, "sig-tx42", receipts["tx42"].Signature)
+	require.Equal(t, "tx42", receipts["tx42"].ID)
+}
@@ -0,0 +1,80 @@
+package irys
+
+import (
+	"context"
+	"net/http"
+	"runtime/debug"
+	"sync"
+)
+
+const (
+	_modulePath       = "github.com/Ja7ad/irys"
+	_defaultUserAgent = "irys-go"
+)
+
+var (
+	_userAgent     string
+	_userAgentOnce sync.Once
+)
+
+// defaultUserAgent return irys-go/<version> with sdk version of build info, only irys-go when not known
+func defaultUserAgent() string {
+	_userAgentOnce.Do(func() {
+		_userAgent = _defaultUserAgent
+
+		info, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+
+		for _, dep := range info.Deps {
+			if dep.Path == _modulePath && len(dep.Version) != 0 {
+				_userAgent += "/" + dep.Version
+				return
+			}
+		}
+	})
+	return _userAgent
+}
+
+type headersCtxKey struct{}
+
+// ContextWithHeaders attach headers to requests made with ctx, they override headers of WithHeaders
+func ContextWithHeaders(ctx context.Context, headers map[string]string) context.Context {
+	return context.WithValue(ctx, headersCtxKey{}, headers)
+}
+
+// headerTransport add custom headers and user agent to every request, requests to third party servers
+// (external) get only user agent
+type headerTransport struct {
+	next      http.RoundTripper
+	headers   map[string]string
+	userAgent string
+	external  bool
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	if !t.external {
+		for k, v := range t.headers {
+			req.Header.Set(k, v)
+		}
+		if headers, ok := req.Context().Value(headersCtxKey{}).(map[string]string); ok {
+			for k, v := range headers {
+				req.Header.Set(k, v)
+			}
+		}
+	}
+	req.Header.Set("User-Agent", t.userAgent)
+	return t.next.RoundTrip(req)
+}
+
+// CloseIdleConnections pass to wrapped transport
+func (t *headerTransport) CloseIdleConnections() {
+	type closeIdler interface {
+		CloseIdleConnections()
+	}
+	if tr, ok := t.next.(closeIdler); ok {
+		tr.CloseIdleConnections()
+	}
+}
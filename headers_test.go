@@ -0,0 +1,32 @@
+package irys
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeaderTransport(t *testing.T) {
+	var got http.Header
+	tr := &headerTransport{
+		next: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			got = req.Header
+			return &http.Response{StatusCode: http.StatusOK}, nil
+		}),
+		headers:   map[string]string{"X-Api-Key": "key", "X-Trace-Id": "client"},
+		userAgent: "my-app",
+	}
+
+	ctx := ContextWithHeaders(context.Background(), map[string]string{"X-Trace-Id": "request"})
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://localhost", nil)
+	require.NoError(t, err)
+
+	_, err = tr.RoundTrip(req)
+	require.NoError(t, err)
+	require.Equal(t, "key", got.Get("X-Api-Key"))
+	require.Equal(t, "request", got.Get("X-Trace-Id"))
+	require.Equal(t, "my-app", got.Get("User-Agent"))
+	require.Empty(t, req.Header.Get("X-Api-Key"))
+}
@@ -1,55 +1,80 @@
 package irys
 
 import (
+	"bytes"
 	"crypto/rand"
 	"encoding/json"
-	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/Ja7ad/irys/errors"
 	"github.com/Ja7ad/irys/signer"
+	"github.com/Ja7ad/irys/tags"
 	"github.com/Ja7ad/irys/types"
 )
 
-func decodeBody[T any](body io.Reader) (T, error) {
-	var resp T
-	d := json.NewDecoder(body)
-	return resp, d.Decode(&resp)
-}
-
-func addContentType(contentType string, tags ...types.Tag) types.Tags {
+func addContentType(contentType string, list ...types.Tag) types.Tags {
 	found := false
-	for _, tag := range tags {
-		if tag.Name == "Content-Type" {
+	for _, tag := range list {
+		if tag.Name == tags.ContentType {
 			found = true
 		}
 	}
 
 	if !found {
-		tags = append(tags, types.Tag{Name: "Content-Type", Value: contentType})
+		list = append(list, tags.WithContentType(contentType))
 	}
 
-	return tags
+	return list
 }
 
-func statusCheck(resp *http.Response) error {
+// sniffContentType detect mime type of payload, extend http.DetectContentType for json and svg
+// and add utf-8 charset for text types.
+func sniffContentType(file []byte) string {
+	contentType := http.DetectContentType(file)
+	mediaType, _, _ := strings.Cut(contentType, ";")
+
 	switch {
-	case resp.StatusCode == http.StatusPaymentRequired:
-		return errors.ErrNotEnoughBalance
-	case resp.StatusCode >= http.StatusBadRequest:
-		b, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return err
-		}
-		return fmt.Errorf("%d: %s", resp.StatusCode, string(b))
-	case resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusAccepted:
+	case mediaType == "text/plain" && json.Valid(file):
+		contentType = "application/json"
+	case (mediaType == "text/plain" || mediaType == "text/xml") && bytes.Contains(file, []byte("<svg")):
+		contentType = "image/svg+xml"
+	}
+
+	if !strings.Contains(contentType, "charset") && utf8.Valid(file) &&
+		(strings.HasPrefix(contentType, "text/") || contentType == "application/json" || contentType == "image/svg+xml") {
+		contentType += "; charset=utf-8"
+	}
+
+	return contentType
+}
+
+// statusCheck return *errors.ResponseError with body of error status, 402 and 413 unwrap to
+// ErrNotEnoughBalance and ErrPayloadTooLarge
+func statusCheck(resp *http.Response) error {
+	if resp.StatusCode < http.StatusBadRequest {
 		return nil
 	}
-	return nil
+
+	b, err := io.ReadAll(io.LimitReader(resp.Body, _maxErrorBodySize))
+	if err != nil {
+		return err
+	}
+
+	var sentinel error
+	switch resp.StatusCode {
+	case http.StatusPaymentRequired:
+		sentinel = errors.ErrNotEnoughBalance
+	case http.StatusRequestEntityTooLarge:
+		sentinel = errors.ErrPayloadTooLarge
+	}
+
+	return errors.NewResponseError(resp.StatusCode, b, sentinel)
 }
 
-func signFile(file []byte, signer signer.Signer, withAnchor bool, tags ...types.Tag) ([]byte, error) {
+func signItem(file []byte, signer signer.Signer, withAnchor bool, tags ...types.Tag) (*types.BundleItem, error) {
 	tags = addContentType(http.DetectContentType(file), tags...)
 
 	dataItem := types.BundleItem{
@@ -72,15 +97,5 @@ func signFile(file []byte, signer signer.Signer, withAnchor bool, tags ...types.
 		return nil, err
 	}
 
-	reader, err := dataItem.Reader()
-	if err != nil {
-		return nil, err
-	}
-
-	signedByte, err := io.ReadAll(reader)
-	if err != nil {
-		return nil, err
-	}
-
-	return signedByte, nil
+	return &dataItem, nil
 }
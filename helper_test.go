@@ -0,0 +1,48 @@
+package irys
+
+import (
+	stderrors "errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSniffContentType(t *testing.T) {
+	tests := map[string]string{
+		`{"name":"irys"}`: "application/json; charset=utf-8",
+		`<svg xmlns="http://www.w3.org/2000/svg"></svg>`: "image/svg+xml; charset=utf-8",
+		"hello irys":              "text/plain; charset=utf-8",
+		"\x89PNG\x0D\x0A\x1A\x0A": "image/png",
+	}
+
+	for payload, want := range tests {
+		require.Equal(t, want, sniffContentType([]byte(payload)), payload)
+	}
+}
+
+func TestStatusCheck(t *testing.T) {
+	response := func(status int, body string) *http.Response {
+		return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(body))}
+	}
+
+	require.NoError(t, statusCheck(response(http.StatusOK, "")))
+
+	err := statusCheck(response(http.StatusBadRequest, `{"error":"invalid signature"}`))
+	var respErr *errs.ResponseError
+	require.True(t, stderrors.As(err, &respErr))
+	require.Equal(t, http.StatusBadRequest, respErr.StatusCode)
+	require.Equal(t, "invalid signature", respErr.Message)
+	require.Equal(t, `{"error":"invalid signature"}`, string(respErr.Body))
+	require.EqualError(t, err, "400: invalid signature")
+
+	err = statusCheck(response(http.StatusPaymentRequired, "Not enough balance for transaction"))
+	require.ErrorIs(t, err, errs.ErrNotEnoughBalance)
+	require.EqualError(t, err, "not enough balance: Not enough balance for transaction")
+
+	err = statusCheck(response(http.StatusInternalServerError, "boom\n"))
+	require.EqualError(t, err, "500: boom")
+}
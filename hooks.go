@@ -0,0 +1,61 @@
+package irys
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/Ja7ad/irys/types"
+)
+
+// Hooks is callbacks for upload lifecycle, nil callbacks are skipped.
+// Callbacks run synchronously in caller goroutine, so keep them fast (e.g. push to queue).
+type Hooks struct {
+	// OnUploadStart called before signing and sending file with size in byte
+	OnUploadStart func(ctx context.Context, size int, tags []types.Tag)
+	// OnUploadComplete called after node accepted upload
+	OnUploadComplete func(ctx context.Context, tx types.Transaction)
+	// OnFundingComplete called after top up transaction confirmed by node
+	OnFundingComplete func(ctx context.Context, amount *big.Int, txHash string)
+	// OnError called when upload or funding failed, op is name of method
+	OnError func(ctx context.Context, op string, err error)
+	// OnDeadlineWarning called by TimeToDeadline when bundle of transaction is not finalized and deadline
+	// is within WithDeadlineWarning duration, remaining is negative when deadline passed
+	OnDeadlineWarning func(ctx context.Context, txId string, remaining time.Duration)
+}
+
+func (c *Client) uploadStarted(ctx context.Context, size int, tags []types.Tag) {
+	if c.hooks.OnUploadStart != nil {
+		c.hooks.OnUploadStart(ctx, size, tags)
+	}
+}
+
+func (c *Client) uploadCompleted(ctx context.Context, op string, tx types.Transaction, err error) {
+	if err != nil {
+		c.failed(ctx, op, err)
+		return
+	}
+	if c.hooks.OnUploadComplete != nil {
+		c.hooks.OnUploadComplete(ctx, tx)
+	}
+}
+
+func (c *Client) fundingCompleted(ctx context.Context, amount *big.Int, hash string, err error) {
+	// tx sent on chain is recorded also when node not confirmed funding
+	if len(hash) != 0 {
+		c.recordFunding(ctx, amount, hash)
+	}
+	if err != nil {
+		c.failed(ctx, "TopUpBalance", err)
+		return
+	}
+	if c.hooks.OnFundingComplete != nil {
+		c.hooks.OnFundingComplete(ctx, amount, hash)
+	}
+}
+
+func (c *Client) failed(ctx context.Context, op string, err error) {
+	if c.hooks.OnError != nil {
+		c.hooks.OnError(ctx, op, err)
+	}
+}
@@ -0,0 +1,82 @@
+package irys
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Ja7ad/irys/currency"
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadHooks(t *testing.T) {
+	reject := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if reject {
+			w.WriteHeader(http.StatusPaymentRequired)
+			return
+		}
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		item := new(types.BundleItem)
+		require.NoError(t, item.Unmarshal(b))
+		_ = json.NewEncoder(w).Encode(types.Transaction{ID: item.Id.Base64()})
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	var (
+		sizes     []int
+		started   [][]types.Tag
+		completed []types.Transaction
+		failedOps []string
+		failures  []error
+	)
+	c := &Client{client: retryablehttp.NewClient(), network: Node(srv.URL), currency: matic}
+	WithHooks(Hooks{
+		OnUploadStart: func(_ context.Context, size int, tags []types.Tag) {
+			sizes = append(sizes, size)
+			started = append(started, tags)
+		},
+		OnUploadComplete: func(_ context.Context, tx types.Transaction) {
+			completed = append(completed, tx)
+		},
+		OnError: func(_ context.Context, op string, err error) {
+			failedOps = append(failedOps, op)
+			failures = append(failures, err)
+		},
+	})(c)
+
+	tags := []types.Tag{{Name: "App-Name", Value: "irys"}}
+	tx, err := c.Upload(context.Background(), []byte("hello irys"), tags...)
+	require.NoError(t, err)
+
+	require.Equal(t, []int{10}, sizes)
+	require.Equal(t, [][]types.Tag{tags}, started)
+	require.Equal(t, []types.Transaction{tx}, completed)
+	require.Empty(t, failures)
+
+	reject = true
+	_, err = c.Upload(context.Background(), []byte("hello"), tags...)
+	require.ErrorIs(t, err, errs.ErrNotEnoughBalance)
+
+	require.Equal(t, []int{10, 5}, sizes)
+	require.Len(t, completed, 1)
+	require.Equal(t, []string{"Upload"}, failedOps)
+	require.Len(t, failures, 1)
+	require.ErrorIs(t, failures[0], errs.ErrNotEnoughBalance)
+}
@@ -0,0 +1,198 @@
+package irys
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+)
+
+// IPFSCIDTag is tag name of payload ipfs cid, added on upload with WithIPFSCID
+const IPFSCIDTag = "IPFS-CID"
+
+const (
+	_cidVersion1 = 0x01
+	_codecRaw    = 0x55
+	_codecDagPB  = 0x70
+	_sha2_256    = 0x12
+
+	// _cidChunkSize and _cidLinksPerNode are default chunker and balanced layout of `ipfs add`
+	_cidChunkSize    = 256 * 1024
+	_cidLinksPerNode = 174
+
+	_unixfsFile = 2
+)
+
+var _cidBase32 = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// ComputeCID return ipfs CIDv1 (base32) of data, same as `ipfs add --cid-version 1` (raw leaves, 256 KiB
+// chunks and balanced unixfs dag), data not bigger than one chunk is single raw block.
+func ComputeCID(data []byte) string {
+	cid, _ := computeCIDReader(bytes.NewReader(data))
+	return cid
+}
+
+// computeCIDReader return ComputeCID of data read from r
+func computeCIDReader(r io.Reader) (string, error) {
+	var (
+		leaves []dagLink
+		chunk  = make([]byte, _cidChunkSize)
+	)
+
+	for {
+		n, err := io.ReadFull(r, chunk)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return "", err
+		}
+		if n > 0 || len(leaves) == 0 {
+			leaves = append(leaves, dagLink{
+				cid:      cidBytes(_codecRaw, sha256.Sum256(chunk[:n])),
+				tsize:    uint64(n),
+				fileSize: uint64(n),
+			})
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	level := leaves
+	for len(level) > 1 {
+		var parents []dagLink
+		for i := 0; i < len(level); i += _cidLinksPerNode {
+			end := i + _cidLinksPerNode
+			if end > len(level) {
+				end = len(level)
+			}
+			parents = append(parents, dagNode(level[i:end]))
+		}
+		level = parents
+	}
+
+	return "b" + strings.ToLower(_cidBase32.EncodeToString(level[0].cid)), nil
+}
+
+// dagLink is link to block of dag, tsize is size of linked block with its descendants and fileSize is
+// size of file data under it
+type dagLink struct {
+	cid      []byte
+	tsize    uint64
+	fileSize uint64
+}
+
+// dagNode encode dag-pb node of unixfs file with links and return link to it
+func dagNode(links []dagLink) dagLink {
+	var (
+		data     []byte
+		fileSize uint64
+		tsize    uint64
+	)
+
+	for _, l := range links {
+		fileSize += l.fileSize
+	}
+	data = appendPBVarint(data, 1, _unixfsFile)
+	data = appendPBVarint(data, 3, fileSize)
+	for _, l := range links {
+		data = appendPBVarint(data, 4, l.fileSize)
+	}
+
+	// dag-pb encode links before data
+	var node []byte
+	for _, l := range links {
+		var link []byte
+		link = appendPBBytes(link, 1, l.cid)
+		link = appendPBBytes(link, 2, nil)
+		link = appendPBVarint(link, 3, l.tsize)
+
+		node = appendPBBytes(node, 2, link)
+		tsize += l.tsize
+	}
+	node = appendPBBytes(node, 1, data)
+
+	return dagLink{
+		cid:      cidBytes(_codecDagPB, sha256.Sum256(node)),
+		tsize:    uint64(len(node)) + tsize,
+		fileSize: fileSize,
+	}
+}
+
+func appendPBVarint(b []byte, field int, v uint64) []byte {
+	b = appendUvarint(b, uint64(field<<3))
+	return appendUvarint(b, v)
+}
+
+func appendPBBytes(b []byte, field int, v []byte) []byte {
+	b = appendUvarint(b, uint64(field<<3|2))
+	b = appendUvarint(b, uint64(len(v)))
+	return append(b, v...)
+}
+
+func appendUvarint(b []byte, v uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	return append(b, buf[:binary.PutUvarint(buf[:], v)]...)
+}
+
+func cidBytes(codec byte, hash [sha256.Size]byte) []byte {
+	cid := make([]byte, 0, 4+len(hash))
+	cid = append(cid, _cidVersion1, codec, _sha2_256, byte(len(hash)))
+	return append(cid, hash[:]...)
+}
+
+func (c *Client) addIPFSCIDTag(file []byte, tags ...types.Tag) []types.Tag {
+	if !c.ipfsCID || hasTag(tags, IPFSCIDTag) {
+		return tags
+	}
+	return append(tags, types.Tag{Name: IPFSCIDTag, Value: ComputeCID(file)})
+}
+
+// addIPFSCIDTagReader is addIPFSCIDTag of data read from r
+func (c *Client) addIPFSCIDTagReader(r io.Reader, tags ...types.Tag) ([]types.Tag, error) {
+	if !c.ipfsCID || hasTag(tags, IPFSCIDTag) {
+		return tags, nil
+	}
+
+	cid, err := computeCIDReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return append(tags, types.Tag{Name: IPFSCIDTag, Value: cid}), nil
+}
+
+// DownloadByCID download data of transaction tagged with ipfs cid, tag may be added by any owner so cid
+// recomputed over data and ErrCIDMismatch returned when not match, data of returned file is in memory.
+func (c *Client) DownloadByCID(ctx context.Context, cid string) (*types.File, error) {
+	id, err := c.findByTag(ctx, IPFSCIDTag, cid)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(id) == 0 {
+		return nil, errors.ErrCIDNotFound
+	}
+
+	file, err := c.Download(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Data.Close()
+
+	data, err := io.ReadAll(file.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	if computed := ComputeCID(data); computed != cid {
+		return nil, fmt.Errorf("%w: transaction %s has cid %s", errors.ErrCIDMismatch, id, computed)
+	}
+
+	file.Data = io.NopCloser(bytes.NewReader(data))
+	return file, nil
+}
@@ -0,0 +1,97 @@
+package irys
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeCID(t *testing.T) {
+	require.Equal(t, "bafkreifzjut3te2nhyekklss27nh3k72ysco7y32koao5eei66wof36n5e", ComputeCID([]byte("hello world")))
+
+	// one chunk is raw block, bigger data is dag-pb root of chunks
+	chunk := bytes.Repeat([]byte{1}, _cidChunkSize)
+	require.True(t, strings.HasPrefix(ComputeCID(chunk), "bafkrei"))
+
+	big := append(chunk, 2)
+	cid := ComputeCID(big)
+	require.True(t, strings.HasPrefix(cid, "bafybei"))
+	require.NotEqual(t, cid, ComputeCID(append(chunk, 3)))
+
+	root := dagNode([]dagLink{
+		{cid: cidBytes(_codecRaw, sha256.Sum256(chunk)), tsize: _cidChunkSize, fileSize: _cidChunkSize},
+		{cid: cidBytes(_codecRaw, sha256.Sum256([]byte{2})), tsize: 1, fileSize: 1},
+	})
+	require.Equal(t, uint64(_cidChunkSize+1), root.fileSize)
+	require.Equal(t, "b"+strings.ToLower(_cidBase32.EncodeToString(root.cid)), cid)
+
+	// streamed data has same cid
+	streamed, err := computeCIDReader(iotest.HalfReader(bytes.NewReader(big)))
+	require.NoError(t, err)
+	require.Equal(t, cid, streamed)
+
+	// three chunks with partial last chunk, same as `ipfs add --cid-version 1` of file
+	// python3 -c 'import sys; sys.stdout.buffer.write(bytes(i % 251 for i in range(700000)))'
+	require.Equal(t, "bafybeiat65mgaomregcezwr6uzau6iumvujm3xlrtud36wlbpivuexuu24", ComputeCID(cidTestData(700000)))
+}
+
+func cidTestData(size int) []byte {
+	data := make([]byte, size)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+	return data
+}
+
+func TestDownloadByCID(t *testing.T) {
+	data := cidTestData(700000)
+	cid := ComputeCID(data)
+
+	served := data
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/graphql" {
+			fmt.Fprint(w, `{"data":{"transactions":{"edges":[{"node":{"id":"item"}}]}}}`)
+			return
+		}
+		_, _ = w.Write(served)
+	}))
+	defer srv.Close()
+
+	c := &Client{client: retryablehttp.NewClient(), network: Node(srv.URL), gateway: srv.URL}
+	c.client.RetryMax = 0
+
+	file, err := c.DownloadByCID(context.Background(), cid)
+	require.NoError(t, err)
+	b, err := io.ReadAll(file.Data)
+	require.NoError(t, err)
+	require.Equal(t, data, b)
+
+	// item of other owner tagged with cid of other data
+	served = []byte("tampered")
+	_, err = c.DownloadByCID(context.Background(), cid)
+	require.ErrorIs(t, err, errs.ErrCIDMismatch)
+}
+
+func TestAddIPFSCIDTag(t *testing.T) {
+	c := &Client{}
+	require.Empty(t, c.addIPFSCIDTag([]byte("hello world")))
+
+	c.ipfsCID = true
+	require.Equal(t, []types.Tag{{Name: IPFSCIDTag, Value: ComputeCID([]byte("hello world"))}},
+		c.addIPFSCIDTag([]byte("hello world")))
+
+	tags := []types.Tag{{Name: IPFSCIDTag, Value: "custom"}}
+	require.Equal(t, tags, c.addIPFSCIDTag([]byte("hello world"), tags...))
+}
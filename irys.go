@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"log/slog"
 	"math/big"
 	"net/http"
 	"sync"
@@ -11,59 +12,317 @@ import (
 
 	"github.com/Ja7ad/irys/currency"
 	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/signer"
 	"github.com/Ja7ad/irys/types"
 	"github.com/Ja7ad/irys/utils/logger"
 	"github.com/hashicorp/go-retryablehttp"
 )
 
+const _defaultSkipCaller = 4
+
+// _defaultHTTPTimeout timeout of default http client when no operation timeouts set
+var _defaultHTTPTimeout = 300 * time.Second
+
+// Client is safe for concurrent use by multiple goroutines, options applied only in New
+// and client state changed after construction (unspent credit, funding nonce) protected by locks.
 type Client struct {
-	mu       *sync.Mutex
-	client   *retryablehttp.Client
-	network  Node
-	currency currency.Currency
-	contract string
-	logging  logger.Logger
-	debug    bool
+	mu            *sync.Mutex
+	client        *retryablehttp.Client
+	network       Node
+	currency      currency.Currency
+	contract      string
+	logging       logger.Logger
+	logLevel      slog.Level
+	logFormat     logger.HandleType
+	debug         bool
+	unspentCredit *big.Int
+
+	correlationTag string
+	correlationID  func() string
+
+	defaultTags  []types.Tag
+	tagInjectors []TagInjector
+
+	breakerThreshold int
+	breakerCooldown  time.Duration
+
+	chunkSize        int
+	chunkConcurrency int
+	chunkMaxRetries  int
+
+	receiptFields   []types.ReceiptField
+	receiptVerifier signer.Signer
+
+	hooks Hooks
+
+	paidBy []string
+
+	authHeader string
+	authValue  func() (string, error)
+
+	dryRun          bool
+	uploadStats     bool
+	autoContentType bool
+
+	proxy  string
+	dialer Dialer
+
+	maxIdleConnsPerHost   int
+	maxConnsPerHost       int
+	idleConnTimeout       time.Duration
+	expectContinueTimeout time.Duration
+	forceHTTP2            bool
+
+	journalDir string
+	journal    *journal
+
+	receiptStore ReceiptStore
+	accountLog   AccountLog
+	uploadIndex  UploadIndex
+
+	timeouts OperationTimeouts
+
+	nodeInfo     *ttlCache[types.NodeInfo]
+	capabilities *ttlCache[types.NodeCapabilities]
+	chunkLimits  *ttlCache[types.ChunkResponse]
+
+	redirectPolicy RedirectPolicy
+	redirectHosts  []string
+
+	sessionSigner signer.Signer
+	delegation    []types.Tag
+
+	gateway string
+
+	drain        *drainTransport
+	drainTimeout time.Duration
+
+	maxSourceSize int64
+
+	// external is client of third party servers, not sharing headers, breaker and stats of node client
+	external *retryablehttp.Client
+
+	autoFundLimit *big.Int
+
+	spend *spendLedger
+
+	pricing *localPricing
+
+	uploadStrategy UploadStrategy
+	arweaveSigner  *signer.ArweaveSigner
+	arweaveURL     string
+
+	ipfsCID  bool
+	fileHash bool
+
+	bodyLimits map[Endpoint]int64
+
+	headers   map[string]string
+	userAgent string
+
+	downloadCache downloadCache
+	cacheDir      string
+	cacheMaxBytes int64
+
+	metaCache         *memoryCache
+	metaCacheMaxBytes int64
+
+	retryHook RetryHook
+
+	middlewares []Middleware
+
+	downloadHandlers []downloadHandler
+
+	deadlineWarning time.Duration
+
+	freeUploadLimit int
+
+	codec Codec
+
+	mmap bool
 }
 
+// Irys is composite of all client features, depend on Uploader, Downloader, Funder or Querier
+// when you need only part of client (e.g. for mocks).
 type Irys interface {
-	// GetPrice return fee base on fileSize in byte for selected currency
-	GetPrice(ctx context.Context, fileSize int) (*big.Int, error)
+	Uploader
+	Downloader
+	Funder
+	Querier
+
+	// Address return wallet address of client currency
+	Address() string
+	// Currency return name of client currency
+	Currency() string
+	// Node return url of node client bound to
+	Node() string
+	// Capabilities return features of node (currencies, max upload size, chunked upload), so callers branch
+	// on features instead of hard-coding node behavior. Node stays a string type (url) for compatibility,
+	// capabilities are learned by client instead of carried by Node.
+	Capabilities(ctx context.Context) (types.NodeCapabilities, error)
 
+	// WithCurrency return client with other currency which share transport with this client
+	WithCurrency(ctx context.Context, currency currency.Currency) (Irys, error)
+
+	// Close stop client and cancel in-flight requests (after drain timeout when set), next calls return ErrClientClosed
+	Close()
+	// Shutdown stop upload queues and wait in-flight requests until ctx done, next calls return ErrClientClosed
+	Shutdown(ctx context.Context) error
+}
+
+type Uploader interface {
 	// BasicUpload file with calculate price and topUp balance base on price (this is slower for upload)
-	BasicUpload(ctx context.Context, file []byte, tags ...types.Tag) (types.Transaction, error)
+	// result report whether balance topped up, funded amount and chain tx hash of funding
+	BasicUpload(ctx context.Context, file []byte, tags ...types.Tag) (types.UploadResult, error)
 	// Upload file with check balance
 	Upload(ctx context.Context, file []byte, tags ...types.Tag) (types.Transaction, error)
-	// ChunkUpload upload file chunk concurrent for big files (min size: 500 KB, max size: 95 MB)
+	// UploadWithSigner upload file signed by other signer than currency (e.g. threshold multi aptos signer)
+	UploadWithSigner(ctx context.Context, s signer.Signer, file []byte, tags ...types.Tag) (types.Transaction, error)
+	// UploadWithAttestation upload file with secondary signature of attestor over content hash and claims in tags
+	UploadWithAttestation(ctx context.Context, file []byte, attestor signer.Signer, claims map[string]string, tags ...types.Tag) (types.Transaction, error)
+	// SignDataItem sign file and return serialized data item for post by UploadSignedItem (e.g. on untrusted frontend)
+	SignDataItem(file []byte, tags ...types.Tag) ([]byte, error)
+	// UploadSignedItem verify and post serialized data item signed by SignDataItem
+	UploadSignedItem(ctx context.Context, raw []byte) (types.Transaction, error)
+	// UploadIfAbsent skip upload and return exists transaction if same data already uploaded
+	// (by deterministic id for ethereum signers or content hash tag for others)
+	UploadIfAbsent(ctx context.Context, file []byte, tags ...types.Tag) (types.Transaction, error)
+	// UploadReader upload data of size read from r in chunks, memory of signing and upload not depend on size
+	UploadReader(ctx context.Context, r io.ReaderAt, size int64, tags ...types.Tag) (types.Transaction, error)
+	// UploadFile upload file of path streamed by UploadReader, file mapped to memory with WithMmap
+	UploadFile(ctx context.Context, path string, tags ...types.Tag) (types.Transaction, error)
+	// ChunkUpload upload file chunk concurrent for big files (min size: 500 KB, no max size), file read in
+	// parts so memory not depend on size, readers without io.ReaderAt and io.Seeker copied to temp file.
 	//
 	// chunkId used for resume upload, chunkId expired after 30 min.
 	//
-	// Note: this feature is experimental, maybe not work.
+	// Chunks uploaded concurrently in any order, before finish chunks received by node validated
+	// and missing chunks re-uploaded, finished upload must have id of signed item.
 	ChunkUpload(ctx context.Context, file io.Reader, chunkId string, tags ...types.Tag) (types.Transaction, error)
+	// UploadFromURL fetch remote resource (up to WithMaxSourceSize) and upload it with its Content-Type
+	UploadFromURL(ctx context.Context, srcURL string, tags ...types.Tag) (types.Transaction, error)
+	// UploadFolder upload files of dir and path manifest of them, with WithJournal upload of folder
+	// resumed after crash without upload of files already uploaded
+	UploadFolder(ctx context.Context, dir, index string) (types.Transaction, error)
+	// UploadManifest upload in memory files (path to content) and return path manifest transaction of them
+	UploadManifest(ctx context.Context, files map[string]io.Reader, index string) (types.Transaction, error)
+	// UploadNFT upload media and ERC-721/1155 metadata json which reference media gateway url
+	UploadNFT(ctx context.Context, media []byte, metadata types.NFTMetadata, tags ...types.Tag) (types.NFTUpload, error)
+	// UpdateManifest upload new version of path manifest with changed paths (empty txId remove path), linked by Root-TX tag
+	UpdateManifest(ctx context.Context, manifestTx string, changes map[string]string) (types.Transaction, error)
+	// NewPlan create batch upload plan which fund once for aggregate cost of items then upload them
+	NewPlan() *Plan
+	// NewUploadQueue create background upload queue persisted in dir, Enqueue return ticket and workers upload
+	// with retries, queued tickets resumed after restart
+	NewUploadQueue(dir string, workers, maxAttempts int) (*UploadQueue, error)
+	// Recover resume or verify pending uploads recorded in journal (WithJournal) after process crash
+	Recover(ctx context.Context) ([]types.JournalEntry, error)
+}
 
-	// Download get file with header details
-	Download(ctx context.Context, txId string) (*types.File, error)
+type Downloader interface {
+	// Download get file with header details, data decoded by handlers of WithDownloadHandler.
+	// With conditional options (WithIfNoneMatch, WithIfModifiedSince) return ErrNotModified when content not changed
+	Download(ctx context.Context, txId string, opts ...DownloadOption) (*types.File, error)
+	// VerifyDownload download data and verify it against data item id and owner signature
+	VerifyDownload(ctx context.Context, txId string) ([]byte, error)
+	// DownloadByCID get file uploaded with ipfs cid tag (WithIPFSCID), data verified against cid
+	DownloadByCID(ctx context.Context, cid string) (*types.File, error)
+	// Gateway return base url of gateway used for download and transaction metadata
+	Gateway() string
+	// GatewayURL return shareable gateway url of transaction
+	GatewayURL(txId string, opts ...GatewayURLOption) string
+	// GatewayQRCode return png qr code of gateway url for share uploaded content
+	GatewayQRCode(txId string, opts ...GatewayURLOption) ([]byte, error)
+	// DownloadPath get file of path (e.g. images/1.png) resolved through path manifest, empty path is index
+	DownloadPath(ctx context.Context, manifestTx, path string) (*types.File, error)
 	// GetMetaData get transaction details
 	GetMetaData(ctx context.Context, txId string) (types.Transaction, error)
+	// GetTags get transaction tags with decoded name and value
+	GetTags(ctx context.Context, txId string) ([]types.Tag, error)
+	// GetOwner get transaction owner public key with signature type and normalized address
+	GetOwner(ctx context.Context, txId string) (types.Owner, error)
+	// ResolveAddress get normalized owner address of transaction (ethereum hex or arweave base64url),
+	// master address for item signed by delegated session key
+	ResolveAddress(ctx context.Context, txId string) (string, error)
+	// Exists check transaction is available on gateway without download body
+	Exists(ctx context.Context, txId string) (bool, error)
+}
+
+type Funder interface {
+	// IsFreeUpload return true when signed item of size uploaded free by node (free tier), BasicUpload skip funding for it
+	IsFreeUpload(size int) bool
+	// GetPrice return fee base on fileSize in byte for selected currency
+	GetPrice(ctx context.Context, fileSize int) (*big.Int, error)
+	// GetPriceBreakdown return itemized price of size (base fee, per chunk and per byte rate, currency decimals)
+	GetPriceBreakdown(ctx context.Context, size int) (types.PriceBreakdown, error)
+	// GetBulkPrice return fee of each size and total, computed locally from node rate without request per item
+	GetBulkPrice(ctx context.Context, sizes []int) (types.BulkPrice, error)
 
 	// GetBalance return current balance in irys node
 	GetBalance(ctx context.Context) (*big.Int, error)
+	// GetBalanceOf return current balance of address in irys node
+	GetBalanceOf(ctx context.Context, address string) (*big.Int, error)
 	// TopUpBalance top up your balance base on your amount in selected node
 	TopUpBalance(ctx context.Context, amount *big.Int) error
+	// CurrencyInfo return decimals and min/max funding amount of currency reported by node,
+	// limits are nil (not enforced by TopUpBalance) when node not report them
+	CurrencyInfo(ctx context.Context) (types.CurrencyInfo, error)
+	// EstimateTopUp return expected cost of TopUpBalance of amount, amount plus estimated chain fee
+	EstimateTopUp(ctx context.Context, amount *big.Int) (types.TopUpEstimate, error)
+	// TopUpStandard top up balance by decimal amount in standard unit of currency (e.g. "0.5")
+	TopUpStandard(ctx context.Context, value string) error
+	// FundToTarget top up only delta needed to reach target balance, return funded amount
+	FundToTarget(ctx context.Context, target *big.Int) (*big.Int, error)
+	// PreflightUpload return price, balance and shortfall of upload size before spend, with typed error
+	// (ErrNotEnoughBalance, ErrAutoFundLimitExceeded) when upload would fail for balance
+	PreflightUpload(ctx context.Context, size int) (types.Quote, error)
+	// AccountSummary return current balance with credit funded for canceled uploads
+	AccountSummary(ctx context.Context) (types.AccountSummary, error)
+	// Snapshot record current balance in account log (WithAccountLog) as opening balance of Reconcile
+	Snapshot(ctx context.Context) (types.AccountRecord, error)
+	// Reconcile report funded, spent, expected and actual balance since time from account log, uploads counted
+	// at price recorded at upload time
+	Reconcile(ctx context.Context, since time.Time) (types.ReconcileReport, error)
+
+	// CreateApproval approve address to pay its uploads from your balance up to amount, zero expiry means no expiry
+	CreateApproval(ctx context.Context, approvedAddress string, amount *big.Int, expiry time.Duration) (types.Transaction, error)
+	// RevokeApproval revoke approval of address created by CreateApproval
+	RevokeApproval(ctx context.Context, approvedAddress string) (types.Transaction, error)
+	// GetApprovals return approvals paid by your address, optional filter by approved addresses
+	GetApprovals(ctx context.Context, approvedAddresses ...string) ([]types.Approval, error)
+}
 
+type Querier interface {
 	// GetReceipt get receipt information from node
 	GetReceipt(ctx context.Context, txId string) (types.Receipt, error)
-
-	// Close stop irys client request
-	Close()
+	// GetReceipts get receipts of many transactions in batched queries, keyed by transaction id
+	GetReceipts(ctx context.Context, txIds []string) (map[string]types.Receipt, error)
+	// VerifyReceipts check receipt existence and node signature (WithReceiptVerifier) for stream of txIds with
+	// bounded concurrency, onResult called for each item and summary returned when txIds closed.
+	VerifyReceipts(ctx context.Context, txIds <-chan string, concurrency int, onResult func(types.VerifyResult)) (types.VerifySummary, error)
+	// GetStatus return status of transaction on node (pending, confirmed or finalized)
+	GetStatus(ctx context.Context, txId string) (types.TxStatus, error)
+	// IsFinalized report transaction bundle is seeded and finalized on arweave
+	IsFinalized(ctx context.Context, txId string) (bool, error)
+	// TimeToDeadline estimate time until receipt deadline height, negative when passed
+	TimeToDeadline(ctx context.Context, txId string) (time.Duration, error)
+	// Search iterate pages of transactions filtered by tags, addresses, timestamp or block height range (on gateway)
+	Search(query types.SearchQuery) *SearchIterator
+	// FindByHash return all transactions uploaded with File-Hash (WithFileHash) or Content-Sha256 (UploadIfAbsent)
+	// tag of sha256 hex hash, for find duplicates or audit integrity of dataset
+	FindByHash(ctx context.Context, hash string) ([]types.TransactionNode, error)
+	// FindLocal find uploaded items by tag in local upload index (WithUploadIndex) without graphql query
+	FindLocal(name, value string) ([]types.IndexEntry, error)
 }
 
+var _ Irys = (*Client)(nil)
+
 // New create IrysClient object
 func New(node Node, currency currency.Currency, debug bool, options ...Option) (Irys, error) {
 	irys := new(Client)
 
 	httpClient := &http.Client{
-		Timeout: 300 * time.Second,
+		Timeout: _defaultHTTPTimeout,
 	}
 
 	irys.client = retryablehttp.NewClient()
@@ -72,6 +331,10 @@ func New(node Node, currency currency.Currency, debug bool, options ...Option) (
 	irys.network = node
 	irys.currency = currency
 	irys.mu = new(sync.Mutex)
+	irys.unspentCredit = new(big.Int)
+	irys.nodeInfo = newTTLCache[types.NodeInfo](_nodeInfoTTL)
+	irys.capabilities = newTTLCache[types.NodeCapabilities](_nodeInfoTTL)
+	irys.chunkLimits = newTTLCache[types.ChunkResponse](_nodeInfoTTL)
 
 	irys.debug = debug
 
@@ -80,16 +343,39 @@ func New(node Node, currency currency.Currency, debug bool, options ...Option) (
 	irys.client.RetryWaitMax = 30 * time.Second
 	irys.client.ErrorHandler = retryablehttp.PassthroughErrorHandler
 
+	irys.freeUploadLimit = DefaultFreeUploadLimit
+
 	for _, opt := range options {
 		opt(irys)
 	}
 
+	// operation timeouts bound calls by context, default client timeout must not cut longer operations
+	if irys.timeouts != (OperationTimeouts{}) && irys.client.HTTPClient == httpClient {
+		httpClient.Timeout = 0
+	}
+
+	// client of WithCustomClient may be shared, transport wrapped and configured below only on copy of it
+	if irys.client.HTTPClient != httpClient {
+		irys.client.HTTPClient = copyHTTPClient(irys.client.HTTPClient)
+	}
+
+	if len(irys.gateway) != 0 {
+		if err := validateGateway(irys.gateway); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := irys.validateDelegation(); err != nil {
+		return nil, err
+	}
+
 	if irys.logging == nil {
-		logging, err := logger.New(logger.CONSOLE_HANDLER, logger.Options{
+		logging, err := logger.New(irys.logFormat, logger.Options{
 			Development:  false,
-			Debug:        false,
+			Debug:        debug,
+			Level:        irys.logLevel,
 			EnableCaller: true,
-			SkipCaller:   4,
+			SkipCaller:   _defaultSkipCaller,
 		})
 		if err != nil {
 			return nil, err
@@ -100,6 +386,10 @@ func New(node Node, currency currency.Currency, debug bool, options ...Option) (
 
 	irys.client.Logger = irys.logging
 
+	if irys.uploadStats {
+		irys.client.RequestLogHook = countRetries
+	}
+
 	if !debug {
 		irys.client.Logger = nil
 	}
@@ -108,48 +398,201 @@ func New(node Node, currency currency.Currency, debug bool, options ...Option) (
 		irys.client.HTTPClient.Transport = http.DefaultTransport.(*http.Transport).Clone()
 	}
 
+	irys.configureRedirects()
+
+	if err := irys.configureTransport(); err != nil {
+		return nil, err
+	}
+
+	if irys.metaCacheMaxBytes > 0 {
+		irys.metaCache = newMemoryCache(irys.metaCacheMaxBytes)
+	}
+
+	if irys.cacheMaxBytes > 0 {
+		irys.downloadCache = newMemoryCache(irys.cacheMaxBytes)
+		if len(irys.cacheDir) != 0 {
+			cache, err := newDiskCache(irys.cacheDir, irys.cacheMaxBytes)
+			if err != nil {
+				return nil, err
+			}
+			irys.downloadCache = cache
+		}
+	}
+
+	if len(irys.journalDir) != 0 {
+		j, err := newJournal(irys.journalDir)
+		if err != nil {
+			return nil, err
+		}
+		irys.journal = j
+	}
+
+	if len(irys.userAgent) == 0 {
+		irys.userAgent = defaultUserAgent()
+	}
+	base := irys.client.HTTPClient.Transport
+	irys.client.HTTPClient.Transport = &headerTransport{
+		next:      irys.client.HTTPClient.Transport,
+		headers:   irys.headers,
+		userAgent: irys.userAgent,
+	}
+
+	if len(irys.middlewares) != 0 {
+		irys.client.HTTPClient.Transport = newMiddlewareTransport(irys.client.HTTPClient.Transport, irys.middlewares)
+	}
+
+	if irys.breakerThreshold > 0 {
+		irys.client.HTTPClient.Transport = newCircuitBreaker(irys.client.HTTPClient.Transport, irys.breakerThreshold, irys.breakerCooldown)
+		irys.client.CheckRetry = breakerRetryPolicy
+	}
+
+	irys.drain = newDrainTransport(irys.client.HTTPClient.Transport)
+	irys.client.HTTPClient.Transport = irys.drain
+	irys.client.CheckRetry = stopOnClosed(irys.client.CheckRetry)
+	irys.external = irys.newExternalClient(base)
+	irys.client.CheckRetry = redirectRetryPolicy(irys.client.CheckRetry)
+
+	irys.client.RequestLogHook = trackRetry(irys.client.RequestLogHook)
+	irys.client.CheckRetry = idempotentRetryPolicy(irys.client.CheckRetry)
+
+	if irys.retryHook != nil {
+		irys.client.CheckRetry = retryHookPolicy(irys.client.CheckRetry, irys.client.RetryMax, irys.retryHook)
+	}
+
+	if len(irys.contract) != 0 {
+		// node info lookup skipped with WithContractAddress
+		if len(irys.gateway) == 0 {
+			irys.gateway = gatewayURL("")
+		}
+		return irys, nil
+	}
+
 	irys.mu.Lock()
-	contract, err := irys.getTokenContractAddress(node, currency)
+	info, err := irys.getNodeInfo(context.Background(), node)
+	irys.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	irys.nodeInfo.set(info)
+
+	contract, err := irys.currencyAddress(info, currency)
 	if err != nil {
 		return nil, err
 	}
-	irys.mu.Unlock()
 
 	irys.contract = contract
 
+	if len(irys.gateway) == 0 {
+		irys.gateway = gatewayURL(info.Gateway)
+	}
+
 	return irys, nil
 }
 
-func (c *Client) Close() {
-	type closeIdler interface {
-		CloseIdleConnections()
+// WithCurrency return client funding and signing with other currency, derived client share
+// transport and options of client, so many currencies used against same node without new connections.
+// Close of any derived client close shared transport. Node info cached by client reused, so deriving
+// many clients not fetch /info for each of them.
+func (c *Client) WithCurrency(ctx context.Context, currency currency.Currency) (Irys, error) {
+	info, err := c.cachedNodeInfo(ctx)
+	if err != nil {
+		return nil, err
 	}
-	if tr, ok := c.client.HTTPClient.Transport.(closeIdler); ok {
-		tr.CloseIdleConnections()
+
+	contract, err := c.currencyAddress(info, currency)
+	if err != nil {
+		return nil, err
 	}
+
+	derived := *c
+	derived.currency = currency
+	derived.contract = contract
+	derived.mu = new(sync.Mutex)
+	derived.unspentCredit = new(big.Int)
+	// chunked upload probed per currency
+	derived.capabilities = newTTLCache[types.NodeCapabilities](_nodeInfoTTL)
+	derived.chunkLimits = newTTLCache[types.ChunkResponse](_nodeInfoTTL)
+	if c.pricing != nil {
+		// price rate is per currency
+		derived.pricing = &localPricing{ttl: c.pricing.ttl}
+	}
+	if c.spend != nil {
+		// spend is tracked per currency
+		derived.spend = newSpendLedger(c.spend.perUpload, c.spend.perDay)
+	}
+
+	return &derived, nil
+}
+
+func (c *Client) currencyAddress(info types.NodeInfo, currency currency.Currency) (string, error) {
+	contract, ok := info.Addresses[currency.GetName()]
+	if !ok {
+		return "", errors.ErrCurrencyIsInvalid
+	}
+	c.debugMsg("set currency address %s base on currency %s", contract, currency.GetName())
+	return contract, nil
 }
 
-func (c *Client) getTokenContractAddress(node Node, currency currency.Currency) (string, error) {
-	r, err := c.client.Get(string(node))
+// getNodeInfo get node info from /info, legacy bundler nodes serve it also on root path
+func (c *Client) getNodeInfo(ctx context.Context, node Node) (types.NodeInfo, error) {
+	get := func(url string) (*http.Response, error) {
+		req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		return c.client.Do(req)
+	}
+
+	r, err := get(fmt.Sprintf(_infoPath, node))
 	if err != nil {
-		return "", err
+		return types.NodeInfo{}, err
 	}
 
+	if r.StatusCode == http.StatusNotFound {
+		r.Body.Close()
+		c.debugMsg("node %s has not info path, fallback to root", node)
+		if r, err = get(string(node)); err != nil {
+			return types.NodeInfo{}, err
+		}
+	}
+
+	defer r.Body.Close()
+
 	if err := statusCheck(r); err != nil {
-		return "", err
+		return types.NodeInfo{}, err
 	}
 
-	resp, err := decodeBody[types.NodeInfo](r.Body)
-	if err != nil {
-		return "", err
+	return decodeBody[types.NodeInfo](c.codec, c.limitBody(EndpointInfo, r.Body))
+}
+
+// uploadTags add tags generated by client options to user tags
+func (c *Client) uploadTags(file []byte, tags ...types.Tag) []types.Tag {
+	tags = c.injectTags(tags...)
+	tags = c.addCorrelationTag(tags...)
+	tags = c.addDelegationTags(tags...)
+	tags = c.addIPFSCIDTag(file, tags...)
+	tags = c.addFileHashTag(file, tags...)
+	if c.autoContentType {
+		tags = addContentType(sniffContentType(file), tags...)
+	}
+	return tags
+}
+
+func (c *Client) addCorrelationTag(tags ...types.Tag) []types.Tag {
+	if c.correlationID == nil || len(c.correlationTag) == 0 {
+		return tags
 	}
 
-	if v, ok := resp.Addresses[currency.GetName()]; ok {
-		c.debugMsg("set currency address %s base on currency %s", v, currency.GetName())
-		return v, nil
+	for _, tag := range tags {
+		if tag.Name == c.correlationTag {
+			return tags
+		}
 	}
 
-	return "", errors.ErrCurrencyIsInvalid
+	id := c.correlationID()
+	c.debugMsg("set correlation tag %s with value %s", c.correlationTag, id)
+	// copy so caller tags slice not modified by append
+	return append(append([]types.Tag(nil), tags...), types.Tag{Name: c.correlationTag, Value: id})
 }
 
 func (c *Client) debugMsg(msg string, args ...any) {
@@ -157,3 +600,10 @@ func (c *Client) debugMsg(msg string, args ...any) {
 		c.logging.Debug(fmt.Sprintf(msg, args...))
 	}
 }
+
+// warnMsg log warning also without debug, skipped when client has no logger
+func (c *Client) warnMsg(msg string, args ...any) {
+	if c.logging != nil {
+		c.logging.Warn(fmt.Sprintf(msg, args...))
+	}
+}
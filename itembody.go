@@ -0,0 +1,104 @@
+package irys
+
+import (
+	"bytes"
+	"io"
+	"sync"
+
+	"github.com/Ja7ad/irys/types"
+)
+
+// itemBody is request body of signed data item, serialized header followed by data of item without
+// copy of data, so large (or mapped) data not buffered twice.
+type itemBody struct {
+	mu     sync.Mutex
+	header []byte
+	data   io.ReaderAt
+	size   int64
+	closed bool
+}
+
+func newItemBody(item *types.BundleItem) (*itemBody, error) {
+	return newStreamItemBody(item, bytes.NewReader(item.Data), int64(len(item.Data)))
+}
+
+// newStreamItemBody is body of item signed by SignReader with data of size read from data
+func newStreamItemBody(item *types.BundleItem, data io.ReaderAt, size int64) (*itemBody, error) {
+	var header bytes.Buffer
+	if err := item.EncodeHeader(&header); err != nil {
+		return nil, err
+	}
+
+	return &itemBody{
+		header: header.Bytes(),
+		data:   data,
+		size:   int64(header.Len()) + size,
+	}, nil
+}
+
+// reader return new reader of body for each attempt of request
+func (b *itemBody) reader() (io.Reader, error) {
+	return &itemBodyReader{body: b}, nil
+}
+
+// close stop reads of data, transport may read body after response returned
+func (b *itemBody) close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+}
+
+type itemBodyReader struct {
+	body   *itemBody
+	offset int64
+}
+
+func (r *itemBodyReader) Read(p []byte) (int, error) {
+	n, err := r.body.readAt(p, r.offset)
+	r.offset += int64(n)
+	return n, err
+}
+
+// readAt read body at offset, used for parts of chunked upload
+func (b *itemBody) readAt(p []byte, offset int64) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return 0, io.ErrClosedPipe
+	}
+
+	if offset >= b.size {
+		return 0, io.EOF
+	}
+
+	header := int64(len(b.header))
+	if offset < header {
+		n := copy(p, b.header[offset:])
+		return n, nil
+	}
+
+	if rest := b.size - offset; int64(len(p)) > rest {
+		p = p[:rest]
+	}
+	n, err := b.data.ReadAt(p, offset-header)
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+	if err == io.EOF && offset+int64(n) < b.size {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+// readPart fill p with body at offset
+func (b *itemBody) readPart(p []byte, offset int64) error {
+	for n := 0; n < len(p); {
+		m, err := b.readAt(p[n:], offset+int64(n))
+		n += m
+		if err != nil && n < len(p) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,276 @@
+package irys
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+)
+
+const (
+	_journalEntryExt = ".json"
+	_journalItemExt  = ".item"
+)
+
+// journal record pending uploads on disk before post, for recover them after crash
+type journal struct {
+	mu  sync.Mutex
+	dir string
+}
+
+func newJournal(dir string) (*journal, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &journal{dir: dir}, nil
+}
+
+// begin write pending entry and signed data item, nil journal is disabled
+func (j *journal) begin(item *types.BundleItem, payload []byte) error {
+	if j == nil {
+		return nil
+	}
+
+	hash := sha256.Sum256(payload)
+	entry := types.JournalEntry{
+		ID:          item.Id.Base64(),
+		PayloadHash: hex.EncodeToString(hash[:]),
+		Tags:        item.Tags,
+		State:       types.JournalPending,
+		CreatedAt:   time.Now(),
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if err := writeItem(j.path(entry.ID, _journalItemExt), item); err != nil {
+		return err
+	}
+	return j.write(entry)
+}
+
+// writeItem write serialized data item to file of path
+func writeItem(path string, item *types.BundleItem) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+	if err := item.Encode(w); err != nil {
+		f.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// complete prune entry of item accepted by node with its stored data item
+func (j *journal) complete(id string) error {
+	if j == nil {
+		return nil
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.remove(id)
+}
+
+// fail mark entry rejected by node and remove stored data item, failed entry reported once by Recover
+func (j *journal) fail(id string, cause error) error {
+	if j == nil {
+		return nil
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entry, err := j.read(id)
+	if err != nil {
+		return err
+	}
+
+	entry.State = types.JournalFailed
+	entry.Err = cause.Error()
+	if err := j.write(entry); err != nil {
+		return err
+	}
+
+	if err := os.Remove(j.path(id, _journalItemExt)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// prune remove entry reported by Recover
+func (j *journal) prune(id string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.remove(id)
+}
+
+func (j *journal) remove(id string) error {
+	for _, ext := range []string{_journalItemExt, _journalEntryExt} {
+		if err := os.Remove(j.path(id, ext)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// unfinished return pending and failed entries
+func (j *journal) unfinished() ([]types.JournalEntry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	files, err := os.ReadDir(j.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []types.JournalEntry
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), _journalEntryExt) {
+			continue
+		}
+
+		entry, err := j.read(strings.TrimSuffix(f.Name(), _journalEntryExt))
+		if err != nil {
+			return nil, err
+		}
+
+		if entry.State == types.JournalPending || entry.State == types.JournalFailed {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+func (j *journal) item(id string) ([]byte, error) {
+	return os.ReadFile(j.path(id, _journalItemExt))
+}
+
+func (j *journal) read(id string) (types.JournalEntry, error) {
+	b, err := os.ReadFile(j.path(id, _journalEntryExt))
+	if err != nil {
+		return types.JournalEntry{}, err
+	}
+
+	var entry types.JournalEntry
+	return entry, json.Unmarshal(b, &entry)
+}
+
+func (j *journal) write(entry types.JournalEntry) error {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	// write to temp file and rename, so entry never left half written on crash
+	tmp := j.path(entry.ID, _journalEntryExt+".tmp")
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, j.path(entry.ID, _journalEntryExt))
+}
+
+func (j *journal) path(id, ext string) string {
+	return filepath.Join(j.dir, id+ext)
+}
+
+// Recover resume pending uploads of journal after crash. Status of each item checked on node, since
+// gateway may not serve item accepted by node yet, and only items unknown to node are posted again. Entries
+// of accepted items are pruned, entries of items rejected by node reported as failed once and pruned.
+func (c *Client) Recover(ctx context.Context) ([]types.JournalEntry, error) {
+	if c.journal == nil {
+		return nil, nil
+	}
+
+	entries, err := c.journal.unfinished()
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf(_uploadPath, c.network, c.currency.GetName())
+
+	for i, entry := range entries {
+		if entry.State == types.JournalFailed {
+			if err := c.journal.prune(entry.ID); err != nil {
+				return entries, err
+			}
+			continue
+		}
+
+		accepted, err := c.acceptedByNode(ctx, entry.ID)
+		if err != nil {
+			return entries, err
+		}
+
+		if !accepted {
+			raw, err := c.journal.item(entry.ID)
+			if err != nil {
+				return entries, err
+			}
+
+			c.debugMsg("[Recover] resume upload of %s", entry.ID)
+			if _, err := c.postItem(ctx, url, entry.ID, raw); err != nil {
+				if !rejectedByNode(err) {
+					return entries, err
+				}
+
+				c.debugMsg("[Recover] upload of %s rejected by node: %v", entry.ID, err)
+				if err := c.journal.prune(entry.ID); err != nil {
+					return entries, err
+				}
+				entries[i].State = types.JournalFailed
+				entries[i].Err = err.Error()
+				continue
+			}
+		}
+
+		if err := c.journal.complete(entry.ID); err != nil {
+			return entries, err
+		}
+		entries[i].State = types.JournalCompleted
+		entries[i].CompletedAt = time.Now()
+	}
+
+	return entries, nil
+}
+
+// acceptedByNode report node know item of id by its status, item unknown to node when status not found
+func (c *Client) acceptedByNode(ctx context.Context, id string) (bool, error) {
+	_, err := c.GetStatus(ctx, id)
+	var respErr *errors.ResponseError
+	if stderrors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// rejectedByNode report error is final rejection of item by node (e.g. invalid item, not enough balance),
+// not transient failure which item may be accepted on retry
+func rejectedByNode(err error) bool {
+	var respErr *errors.ResponseError
+	if !stderrors.As(err, &respErr) {
+		return false
+	}
+	return respErr.StatusCode >= http.StatusBadRequest && respErr.StatusCode < http.StatusInternalServerError &&
+		respErr.StatusCode != http.StatusRequestTimeout && respErr.StatusCode != http.StatusTooManyRequests
+}
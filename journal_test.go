@@ -0,0 +1,114 @@
+package irys
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Ja7ad/irys/currency"
+	"github.com/Ja7ad/irys/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournalRecover(t *testing.T) {
+	var (
+		posted   []string
+		accepted = map[string]bool{}
+		reject   bool
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/tx/matic":
+			item := new(types.BundleItem)
+			require.NoError(t, item.UnmarshalFromReader(r.Body))
+			if reject {
+				w.WriteHeader(http.StatusPaymentRequired)
+				return
+			}
+			posted = append(posted, item.Id.Base64())
+			accepted[item.Id.Base64()] = true
+			_ = json.NewEncoder(w).Encode(types.Transaction{ID: item.Id.Base64()})
+		case strings.HasSuffix(r.URL.Path, "/status"):
+			id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/tx/"), "/status")
+			if !accepted[id] {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			fmt.Fprint(w, `{"status":"PENDING"}`)
+		default:
+			// gateway not serve items yet
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	j, err := newJournal(dir)
+	require.NoError(t, err)
+
+	c := &Client{
+		client:   retryablehttp.NewClient(),
+		network:  Node(srv.URL),
+		gateway:  srv.URL,
+		currency: matic,
+		journal:  j,
+	}
+	c.client.RetryMax = 0
+
+	// entry of accepted upload pruned
+	_, err = c.Upload(context.Background(), []byte("uploaded"))
+	require.NoError(t, err)
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, files)
+
+	// upload rejected by node recorded as failed, not posted again
+	reject = true
+	_, err = c.Upload(context.Background(), []byte("rejected"))
+	require.Error(t, err)
+	reject = false
+
+	// crash after post accepted by node and before post
+	crash := func(data string) string {
+		item, err := signItem([]byte(data), matic.GetSinger(), false)
+		require.NoError(t, err)
+		require.NoError(t, j.begin(item, []byte(data)))
+		return item.Id.Base64()
+	}
+	sent, lost := crash("sent"), crash("lost")
+	accepted[sent] = true
+	posted = nil
+
+	entries, err := c.Recover(context.Background())
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+
+	states := make(map[types.JournalState]int)
+	for _, entry := range entries {
+		states[entry.State]++
+	}
+	require.Equal(t, map[types.JournalState]int{types.JournalCompleted: 2, types.JournalFailed: 1}, states)
+	require.Equal(t, []string{lost}, posted)
+
+	files, err = os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, files)
+
+	entries, err = c.Recover(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
@@ -0,0 +1,263 @@
+// Package keystore keep funding wallets of irys clients in directory by alias, private keys
+// stored as encrypted geth keystore json (<alias>.json) or raw hex (<alias>.key), so services
+// construct currencies from store without handle raw private keys in code.
+package keystore
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Ja7ad/irys/currency"
+	"github.com/Ja7ad/irys/errors"
+	gethkeystore "github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	_encryptedExt = ".json"
+	_rawExt       = ".key"
+)
+
+// Store is directory of wallets by alias, safe for concurrent use
+type Store struct {
+	mu      sync.RWMutex
+	dir     string
+	scryptN int
+	scryptP int
+}
+
+// New open store in dir, dir created if not exists
+func New(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &Store{
+		dir:     dir,
+		scryptN: gethkeystore.StandardScryptN,
+		scryptP: gethkeystore.StandardScryptP,
+	}, nil
+}
+
+// Import encrypt hex private key with passphrase and store it as geth keystore json by alias
+func (s *Store) Import(alias, privateKey, passphrase string) error {
+	if len(passphrase) == 0 {
+		return errors.ErrEmptyPassphrase
+	}
+
+	prKey, err := crypto.HexToECDSA(strings.TrimPrefix(privateKey, "0x"))
+	if err != nil {
+		return err
+	}
+
+	key := &gethkeystore.Key{
+		Address:    crypto.PubkeyToAddress(prKey.PublicKey),
+		PrivateKey: prKey,
+	}
+	// random uuid v4 of key file
+	if _, err := rand.Read(key.Id[:]); err != nil {
+		return err
+	}
+	key.Id[6] = key.Id[6]&0x0f | 0x40
+	key.Id[8] = key.Id[8]&0x3f | 0x80
+
+	b, err := gethkeystore.EncryptKey(key, passphrase, s.scryptN, s.scryptP)
+	if err != nil {
+		return err
+	}
+
+	return s.write(alias, _encryptedExt, b)
+}
+
+// ImportKeyFile store geth keystore json (e.g. exported by geth or metamask) by alias
+func (s *Store) ImportKeyFile(alias string, keyJSON []byte) error {
+	if _, err := keyAddress(keyJSON); err != nil {
+		return err
+	}
+	return s.write(alias, _encryptedExt, keyJSON)
+}
+
+// ImportRaw store hex private key unencrypted by alias, use only when directory is protected
+func (s *Store) ImportRaw(alias, privateKey string) error {
+	privateKey = strings.TrimPrefix(strings.TrimSpace(privateKey), "0x")
+	if _, err := crypto.HexToECDSA(privateKey); err != nil {
+		return err
+	}
+	return s.write(alias, _rawExt, []byte(privateKey))
+}
+
+// Aliases return sorted aliases of stored wallets
+func (s *Store) Aliases() ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	files, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases := make([]string, 0, len(files))
+	for _, f := range files {
+		ext := filepath.Ext(f.Name())
+		if f.IsDir() || (ext != _encryptedExt && ext != _rawExt) {
+			continue
+		}
+		aliases = append(aliases, strings.TrimSuffix(f.Name(), ext))
+	}
+	sort.Strings(aliases)
+
+	return aliases, nil
+}
+
+// Address return wallet address of alias, address of encrypted key read without passphrase
+func (s *Store) Address(alias string) (string, error) {
+	b, encrypted, err := s.read(alias)
+	if err != nil {
+		return "", err
+	}
+
+	if encrypted {
+		addr, err := keyAddress(b)
+		if err != nil {
+			return "", err
+		}
+		return addr.Hex(), nil
+	}
+
+	prKey, err := crypto.HexToECDSA(string(b))
+	if err != nil {
+		return "", err
+	}
+	return crypto.PubkeyToAddress(prKey.PublicKey).Hex(), nil
+}
+
+// PrivateKey return hex private key of alias, passphrase ignored for raw keys
+func (s *Store) PrivateKey(alias, passphrase string) (string, error) {
+	b, encrypted, err := s.read(alias)
+	if err != nil {
+		return "", err
+	}
+
+	if !encrypted {
+		return string(b), nil
+	}
+
+	key, err := gethkeystore.DecryptKey(b, passphrase)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(crypto.FromECDSA(key.PrivateKey)), nil
+}
+
+// Currency create evm currency of type with wallet of alias and backend, nil backend only sign
+func (s *Store) Currency(alias, passphrase string, currencyType currency.CurrencyType, backend currency.EthBackend) (currency.Currency, error) {
+	key, err := s.PrivateKey(alias, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return currency.NewWithBackend(currencyType, key, backend)
+}
+
+// ERC20 create erc-20 token currency with wallet of alias and backend
+func (s *Store) ERC20(alias, passphrase, tokenName, contractAddr string, backend currency.EthBackend) (currency.Currency, error) {
+	key, err := s.PrivateKey(alias, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return currency.NewERC20WithBackend(tokenName, contractAddr, key, backend)
+}
+
+// Delete remove wallet of alias
+func (s *Store) Delete(alias string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path, _, err := s.find(alias)
+	if err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
+
+func (s *Store) write(alias, ext string, b []byte) error {
+	if !validAlias(alias) {
+		return fmt.Errorf("%w: %q", errors.ErrInvalidAlias, alias)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, _, err := s.find(alias); err == nil {
+		return fmt.Errorf("%w: %s", errors.ErrKeyExists, alias)
+	}
+
+	// write to temp file and rename, so key file never left half written
+	path := filepath.Join(s.dir, alias+ext)
+	if err := os.WriteFile(path+".tmp", b, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+func (s *Store) read(alias string) ([]byte, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	path, encrypted, err := s.find(alias)
+	if err != nil {
+		return nil, false, err
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false, err
+	}
+	return []byte(strings.TrimSpace(string(b))), encrypted, nil
+}
+
+// find return key file path of alias and whether it is encrypted
+func (s *Store) find(alias string) (string, bool, error) {
+	if !validAlias(alias) {
+		return "", false, fmt.Errorf("%w: %q", errors.ErrInvalidAlias, alias)
+	}
+
+	for _, ext := range []string{_encryptedExt, _rawExt} {
+		path := filepath.Join(s.dir, alias+ext)
+		if _, err := os.Stat(path); err == nil {
+			return path, ext == _encryptedExt, nil
+		}
+	}
+	return "", false, fmt.Errorf("%w: %s", errors.ErrKeyNotFound, alias)
+}
+
+func keyAddress(keyJSON []byte) (common.Address, error) {
+	var v struct {
+		Address string `json:"address"`
+	}
+	if err := json.Unmarshal(keyJSON, &v); err != nil {
+		return common.Address{}, err
+	}
+	if !common.IsHexAddress(v.Address) {
+		return common.Address{}, errors.ErrInvalidKeyFile
+	}
+	return common.HexToAddress(v.Address), nil
+}
+
+func validAlias(alias string) bool {
+	if len(alias) == 0 {
+		return false
+	}
+	for _, r := range alias {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,50 @@
+package keystore
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/Ja7ad/irys/currency"
+	errs "github.com/Ja7ad/irys/errors"
+	gethkeystore "github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	s, err := New(t.TempDir())
+	require.NoError(t, err)
+	s.scryptN, s.scryptP = gethkeystore.LightScryptN, gethkeystore.LightScryptP
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	hexKey := hex.EncodeToString(crypto.FromECDSA(key))
+	address := crypto.PubkeyToAddress(key.PublicKey).Hex()
+
+	require.ErrorIs(t, s.Import("funder", hexKey, ""), errs.ErrEmptyPassphrase)
+	require.NoError(t, s.Import("funder", "0x"+hexKey, "secret"))
+	require.ErrorIs(t, s.Import("funder", hexKey, "secret"), errs.ErrKeyExists)
+	require.ErrorIs(t, s.ImportRaw("../escape", hexKey), errs.ErrInvalidAlias)
+	require.NoError(t, s.ImportRaw("hot", hexKey))
+
+	aliases, err := s.Aliases()
+	require.NoError(t, err)
+	require.Equal(t, []string{"funder", "hot"}, aliases)
+
+	addr, err := s.Address("funder")
+	require.NoError(t, err)
+	require.Equal(t, address, addr)
+
+	_, err = s.PrivateKey("funder", "wrong")
+	require.ErrorIs(t, err, gethkeystore.ErrDecrypt)
+
+	for _, alias := range aliases {
+		c, err := s.Currency(alias, "secret", currency.MATIC, nil)
+		require.NoError(t, err)
+		require.Equal(t, address, crypto.PubkeyToAddress(*c.GetPublicKey()).Hex())
+	}
+
+	require.NoError(t, s.Delete("hot"))
+	_, err = s.Address("hot")
+	require.ErrorIs(t, err, errs.ErrKeyNotFound)
+}
@@ -0,0 +1,277 @@
+package irys
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+
+	"github.com/Ja7ad/irys/arweave"
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/signer"
+	"github.com/Ja7ad/irys/types"
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+const (
+	_defaultArweaveURL = "https://arweave.net"
+
+	_arweaveTxPath     = "%s/tx"
+	_arweaveChunkPath  = "%s/chunk"
+	_arweaveAnchorPath = "%s/tx_anchor"
+	_arweavePricePath  = "%s/price/%d"
+
+	// _arweaveMaxResponse limit anchor and price responses of arweave node, node body limits not apply to it
+	_arweaveMaxResponse = 64 * 1024
+)
+
+// UploadStrategy select where Upload post data
+type UploadStrategy int
+
+const (
+	// StrategyBundler upload data items to irys node (default)
+	StrategyBundler UploadStrategy = iota
+	// StrategyArweave post data directly to arweave as L1 transaction
+	StrategyArweave
+	// StrategyBundlerWithFallback upload to irys node and post to arweave as L1 transaction
+	// when node is down or reject payload as too large. Item never sent to node, since it may be
+	// accepted and charged even when response of node is lost.
+	StrategyBundlerWithFallback
+)
+
+// nodeError is transport error of node request, used to detect unavailable node. sent report request
+// fully written to node by some attempt, so node may have accepted item.
+type nodeError struct {
+	err  error
+	sent bool
+}
+
+func (e *nodeError) Error() string { return e.err.Error() }
+func (e *nodeError) Unwrap() error { return e.err }
+
+// fallbackToL1 report upload error is node unavailable before item sent or payload too large for bundler,
+// timeout or reset after item sent not fall back because node may have charged for item
+func (c *Client) fallbackToL1(ctx context.Context, err error) bool {
+	if c.uploadStrategy != StrategyBundlerWithFallback || ctx.Err() != nil {
+		return false
+	}
+
+	if stderrors.Is(err, errors.ErrPayloadTooLarge) || stderrors.Is(err, errors.ErrCircuitOpen) {
+		return true
+	}
+
+	var ne *nodeError
+	return stderrors.As(err, &ne) && !ne.sent && connectError(ne.err)
+}
+
+// connectError report err is failure to connect to node (dns, dial or connection refused)
+func connectError(err error) bool {
+	var dnsErr *net.DNSError
+	if stderrors.As(err, &dnsErr) {
+		return true
+	}
+
+	var opErr *net.OpError
+	if stderrors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+
+	return stderrors.Is(err, syscall.ECONNREFUSED)
+}
+
+// l1Upload post file to arweave as L1 transaction within spend limit (WithSpendLimit), in dry run
+// transaction signed and priced without post
+func (c *Client) l1Upload(ctx context.Context, file []byte, tags ...types.Tag) (types.Transaction, error) {
+	if c.dryRun {
+		return c.arweaveDryRun(ctx, file, tags...)
+	}
+
+	release, err := c.reserveL1Spend(ctx, len(file))
+	if err != nil {
+		return types.Transaction{}, err
+	}
+
+	tx, err := c.arweaveUpload(ctx, file, tags...)
+	if err != nil {
+		release()
+	}
+	return tx, err
+}
+
+// arweaveUpload post file to arweave as L1 transaction signed by arweave wallet (WithArweaveL1),
+// data of single chunk posted in transaction body and bigger data uploaded by chunks.
+func (c *Client) arweaveUpload(ctx context.Context, file []byte, tags ...types.Tag) (types.Transaction, error) {
+	tx, tags, _, err := c.signArweaveTx(ctx, file, tags...)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+
+	body := tx
+	if tx.Chunks() > 1 {
+		body = tx.WithoutData()
+	}
+
+	if err := c.arweavePost(ctx, fmt.Sprintf(_arweaveTxPath, c.arweaveURL), body); err != nil {
+		return types.Transaction{}, err
+	}
+
+	if tx.Chunks() > 1 {
+		for i := 0; i < tx.Chunks(); i++ {
+			if err := c.arweavePost(ctx, fmt.Sprintf(_arweaveChunkPath, c.arweaveURL), tx.Chunk(i, file)); err != nil {
+				return types.Transaction{}, fmt.Errorf("upload chunk %d of %d: %w", i+1, tx.Chunks(), err)
+			}
+		}
+	}
+
+	c.debugMsg("[ArweaveUpload] posted transaction %s with %d chunks", base64.RawURLEncoding.EncodeToString(tx.ID), tx.Chunks())
+	return arweaveTransaction(tx, tags)
+}
+
+// arweaveDryRun sign L1 transaction of file without post it, returned transaction has id and reward
+// in winston as cost
+func (c *Client) arweaveDryRun(ctx context.Context, file []byte, tags ...types.Tag) (types.Transaction, error) {
+	tx, tags, reward, err := c.signArweaveTx(ctx, file, tags...)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+
+	result, err := arweaveTransaction(tx, tags)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+	result.Cost = reward
+
+	c.debugMsg("[DryRun] arweave transaction %s reward %s winston", result.ID, reward.String())
+	return result, nil
+}
+
+// signArweaveTx create L1 transaction of file with anchor and reward of arweave node, signed by arweave
+// wallet, tags returned with tags added on upload
+func (c *Client) signArweaveTx(ctx context.Context, file []byte, tags ...types.Tag) (*arweave.Transaction, []types.Tag, *big.Int, error) {
+	if c.arweaveSigner == nil {
+		return nil, nil, nil, errors.ErrArweaveNotConfigured
+	}
+
+	tags = addContentType(http.DetectContentType(file), c.uploadTags(file, tags...)...)
+
+	anchor, err := c.arweaveAnchor(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	reward, err := c.arweavePrice(ctx, len(file))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	c.debugMsg("[ArweaveUpload] reward %s winston", reward.String())
+
+	tx := arweave.NewTransaction(file, reward.String(), anchor, tags...)
+	if err := tx.Sign(c.arweaveSigner); err != nil {
+		return nil, nil, nil, err
+	}
+	return tx, tags, reward, nil
+}
+
+// arweaveTransaction return transaction of signed L1 transaction
+func arweaveTransaction(tx *arweave.Transaction, tags []types.Tag) (types.Transaction, error) {
+	address, err := signer.OwnerAddress(signer.Arweave, tx.Owner)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+
+	return types.Transaction{
+		ID:        base64.RawURLEncoding.EncodeToString(tx.ID),
+		Currency:  "arweave",
+		Address:   address,
+		Owner:     base64.RawURLEncoding.EncodeToString(tx.Owner),
+		Signature: base64.RawURLEncoding.EncodeToString(tx.Signature),
+		Tags:      tags,
+		Anchor:    base64.RawURLEncoding.EncodeToString(tx.LastTx),
+		DataSize:  tx.DataSize,
+	}, nil
+}
+
+func (c *Client) arweaveAnchor(ctx context.Context) ([]byte, error) {
+	req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(_arweaveAnchorPath, c.arweaveURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.external.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+		if err := statusCheck(resp); err != nil {
+			return nil, err
+		}
+
+		b, err := io.ReadAll(io.LimitReader(resp.Body, _arweaveMaxResponse))
+		if err != nil {
+			return nil, err
+		}
+		return base64.RawURLEncoding.DecodeString(strings.TrimSpace(string(b)))
+	}
+}
+
+func (c *Client) arweavePrice(ctx context.Context, size int) (*big.Int, error) {
+	req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(_arweavePricePath, c.arweaveURL, size), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.external.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+		if err := statusCheck(resp); err != nil {
+			return nil, err
+		}
+		return decodeBody[*big.Int](c.codec, io.LimitReader(resp.Body, _arweaveMaxResponse))
+	}
+}
+
+func (c *Client) arweavePost(ctx context.Context, url string, v any) error {
+	b, err := c.marshal(v)
+	if err != nil {
+		return err
+	}
+
+	// signed transaction and its chunks accepted once by arweave node, so repost is idempotent
+	req, err := retryablehttp.NewRequestWithContext(withIdempotencyKey(ctx, url), http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.external.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		return statusCheck(resp)
+	}
+}
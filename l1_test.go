@@ -0,0 +1,184 @@
+package irys
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Ja7ad/irys/arweave"
+	"github.com/Ja7ad/irys/currency"
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/signer"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadFallbackToL1(t *testing.T) {
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+	}))
+	defer node.Close()
+
+	var posted arweave.Transaction
+	ar := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// node headers not sent to arweave
+		require.Empty(t, r.Header.Get("X-Api-Key"))
+		switch r.URL.Path {
+		case "/tx_anchor":
+			fmt.Fprint(w, "YW5jaG9y")
+		case "/price/5":
+			fmt.Fprint(w, "500")
+		case "/tx":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&posted))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ar.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 4096)
+	require.NoError(t, err)
+
+	c := &Client{
+		client:   retryablehttp.NewClient(),
+		external: retryablehttp.NewClient(),
+		network:  Node(node.URL),
+		currency: matic,
+	}
+	c.client.HTTPClient.Transport = &headerTransport{
+		next:    http.DefaultTransport,
+		headers: map[string]string{"X-Api-Key": "key"},
+	}
+
+	_, err = c.Upload(context.Background(), []byte("hello"))
+	require.ErrorIs(t, err, errs.ErrPayloadTooLarge)
+
+	WithUploadStrategy(StrategyArweave)(c)
+	_, err = c.Upload(context.Background(), []byte("hello"))
+	require.ErrorIs(t, err, errs.ErrArweaveNotConfigured)
+
+	WithUploadStrategy(StrategyBundlerWithFallback)(c)
+	WithArweaveL1(&signer.ArweaveSigner{PrivateKey: rsaKey, Owner: rsaKey.N.Bytes()}, ar.URL+"/")(c)
+
+	tx, err := c.Upload(context.Background(), []byte("hello"))
+	require.NoError(t, err)
+	require.Equal(t, "arweave", tx.Currency)
+	require.Equal(t, "YW5jaG9y", tx.Anchor)
+
+	require.Equal(t, tx.ID, posted.ID.Base64())
+	require.Equal(t, "hello", string(posted.Data))
+	require.Equal(t, "500", posted.Reward)
+	require.NoError(t, (&signer.ArweaveSigner{Owner: posted.Owner}).Verify(posted.SignatureData(), posted.Signature))
+}
+
+func TestUploadFallbackAfterSent(t *testing.T) {
+	// node read item and drop connection before response
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		conn, _, err := w.(http.Hijacker).Hijack()
+		require.NoError(t, err)
+		conn.Close()
+	}))
+	defer node.Close()
+
+	var posts int
+	ar := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tx_anchor":
+			fmt.Fprint(w, "YW5jaG9y")
+		case "/price/5":
+			fmt.Fprint(w, "500")
+		case "/tx":
+			posts++
+		}
+	}))
+	defer ar.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	c := &Client{
+		client:   retryablehttp.NewClient(),
+		external: retryablehttp.NewClient(),
+		network:  Node(node.URL),
+		currency: matic,
+	}
+	c.client.RetryMax = 0
+	WithUploadStrategy(StrategyBundlerWithFallback)(c)
+	WithArweaveL1(&signer.ArweaveSigner{PrivateKey: rsaKey, Owner: rsaKey.N.Bytes()}, ar.URL)(c)
+
+	// node may have accepted item, so not posted to arweave
+	_, err = c.Upload(context.Background(), []byte("hello"))
+	require.Error(t, err)
+	require.Zero(t, posts)
+
+	// node down before item sent
+	node.Close()
+	tx, err := c.Upload(context.Background(), []byte("hello"))
+	require.NoError(t, err)
+	require.Equal(t, "arweave", tx.Currency)
+	require.Equal(t, 1, posts)
+}
+
+func TestUploadL1DryRun(t *testing.T) {
+	var posts int
+	ar := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tx_anchor":
+			fmt.Fprint(w, "YW5jaG9y")
+		case "/price/5":
+			fmt.Fprint(w, "500")
+		default:
+			posts++
+		}
+	}))
+	defer ar.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	c := &Client{
+		client:   retryablehttp.NewClient(),
+		external: retryablehttp.NewClient(),
+		network:  Node("http://127.0.0.1:1"),
+		currency: matic,
+		dryRun:   true,
+	}
+	WithUploadStrategy(StrategyArweave)(c)
+	WithArweaveL1(&signer.ArweaveSigner{PrivateKey: rsaKey, Owner: rsaKey.N.Bytes()}, ar.URL)(c)
+
+	// transaction signed and priced, not posted
+	tx, err := c.Upload(context.Background(), []byte("hello"))
+	require.NoError(t, err)
+	require.NotEmpty(t, tx.ID)
+	require.Equal(t, "arweave", tx.Currency)
+	require.Equal(t, big.NewInt(500), tx.Cost)
+	require.Zero(t, posts)
+}
@@ -0,0 +1,195 @@
+package irys
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/tags"
+	"github.com/Ja7ad/irys/types"
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+const (
+	ManifestContentType = "application/x.arweave-manifest+json"
+	ManifestName        = "arweave/paths"
+	ManifestVersion     = "0.1.0"
+	RootTXTag           = tags.RootTX // RootTXTag link new version of mutable reference to its root transaction
+
+	_rawPath = "%s/raw/%s"
+)
+
+// UploadManifest upload files generated in memory (path to content) and path manifest of them, index is
+// path served for manifest root (empty for none). Content-Type of files is detected by path extension or content.
+func (c *Client) UploadManifest(ctx context.Context, files map[string]io.Reader, index string) (types.Transaction, error) {
+	if len(files) == 0 {
+		return types.Transaction{}, errors.ErrEmptyManifest
+	}
+
+	manifest := types.Manifest{
+		Manifest: ManifestName,
+		Version:  ManifestVersion,
+		Paths:    make(map[string]types.ManifestPath, len(files)),
+	}
+
+	if len(index) != 0 {
+		if _, ok := files[index]; !ok {
+			return types.Transaction{}, fmt.Errorf("%w: %s", errors.ErrManifestIndexNotFound, index)
+		}
+		manifest.Index = &types.ManifestIndex{Path: index}
+	}
+
+	paths := make([]string, 0, len(files))
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	for _, p := range paths {
+		file, err := io.ReadAll(files[p])
+		if err != nil {
+			return types.Transaction{}, fmt.Errorf("read %s: %w", p, err)
+		}
+
+		contentType := mime.TypeByExtension(path.Ext(p))
+		if len(contentType) == 0 {
+			contentType = sniffContentType(file)
+		}
+
+		tx, err := c.Upload(ctx, file, tags.WithContentType(contentType))
+		if err != nil {
+			return types.Transaction{}, fmt.Errorf("upload %s: %w", p, err)
+		}
+
+		c.debugMsg("[UploadManifest] uploaded path %s with transaction %s", p, tx.ID)
+		manifest.Paths[p] = types.ManifestPath{ID: tx.ID}
+	}
+
+	return c.uploadManifest(ctx, manifest)
+}
+
+// uploadManifest upload path manifest json with manifest tags and extra tags
+func (c *Client) uploadManifest(ctx context.Context, manifest types.Manifest, extra ...types.Tag) (types.Transaction, error) {
+	b, err := json.Marshal(manifest)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+
+	return c.Upload(ctx, b, append([]types.Tag{
+		tags.WithContentType(ManifestContentType),
+		tags.New(tags.Type, "manifest"),
+	}, extra...)...)
+}
+
+// UpdateManifest fetch path manifest, apply changes (path to txId, empty txId remove path), upload
+// new manifest and link it to root of manifest with Root-TX tag, so data not uploaded again.
+func (c *Client) UpdateManifest(ctx context.Context, manifestTx string, changes map[string]string) (types.Transaction, error) {
+	manifest, err := c.getManifest(ctx, manifestTx)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+
+	if manifest.Paths == nil {
+		manifest.Paths = make(map[string]types.ManifestPath)
+	}
+
+	for path, txId := range changes {
+		if len(txId) == 0 {
+			delete(manifest.Paths, path)
+			continue
+		}
+		manifest.Paths[path] = types.ManifestPath{ID: txId}
+	}
+
+	if manifest.Index != nil {
+		if _, ok := manifest.Paths[manifest.Index.Path]; !ok {
+			manifest.Index = nil
+		}
+	}
+
+	root, err := c.manifestRoot(ctx, manifestTx)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+
+	c.debugMsg("[UpdateManifest] upload new version of manifest %s with root %s", manifestTx, root)
+
+	return c.uploadManifest(ctx, manifest, tags.WithRootTX(root))
+}
+
+// DownloadPath resolve path (e.g. images/1.png) through path manifest and download file of it,
+// empty path resolved to index of manifest.
+func (c *Client) DownloadPath(ctx context.Context, manifestTx, filePath string) (*types.File, error) {
+	manifest, err := c.getManifest(ctx, manifestTx)
+	if err != nil {
+		return nil, err
+	}
+
+	filePath = strings.Trim(filePath, "/")
+	if len(filePath) == 0 {
+		if manifest.Index == nil {
+			return nil, errors.ErrManifestIndexNotFound
+		}
+		filePath = manifest.Index.Path
+	}
+
+	entry, ok := manifest.Paths[filePath]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errors.ErrManifestPathNotFound, filePath)
+	}
+
+	c.debugMsg("[DownloadPath] resolve %s/%s to %s", manifestTx, filePath, entry.ID)
+
+	return c.Download(ctx, entry.ID)
+}
+
+// getManifest download raw path manifest and decode it, gateway resolve manifest on its path and serve
+// index instead of manifest
+func (c *Client) getManifest(ctx context.Context, manifestTx string) (types.Manifest, error) {
+	ctx, cancel := withTimeout(ctx, c.timeouts.Query)
+	defer cancel()
+
+	url := fmt.Sprintf(_rawPath, c.gateway, manifestTx)
+
+	req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return types.Manifest{}, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return types.Manifest{}, err
+	}
+	defer resp.Body.Close()
+
+	select {
+	case <-ctx.Done():
+		return types.Manifest{}, ctx.Err()
+	default:
+		if err := statusCheck(resp); err != nil {
+			return types.Manifest{}, err
+		}
+		return decodeBody[types.Manifest](c.codec, c.limitBody(EndpointManifest, resp.Body))
+	}
+}
+
+// manifestRoot return root transaction of manifest, manifest itself is root if has not Root-TX tag
+func (c *Client) manifestRoot(ctx context.Context, manifestTx string) (string, error) {
+	list, err := c.GetTags(ctx, manifestTx)
+	if err != nil {
+		return "", err
+	}
+
+	if root, ok := tags.Get(list, tags.RootTX); ok {
+		return root, nil
+	}
+
+	return manifestTx, nil
+}
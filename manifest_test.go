@@ -0,0 +1,142 @@
+package irys
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Ja7ad/irys/currency"
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateManifest(t *testing.T) {
+	var uploaded []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/manifest":
+			// gateway serve index of manifest on its path
+			_, _ = io.WriteString(w, "<html></html>")
+		case "/raw/manifest":
+			_, _ = io.WriteString(w, `{"manifest":"arweave/paths","version":"0.1.0","index":{"path":"index.html"},`+
+				`"paths":{"index.html":{"id":"a"},"old.txt":{"id":"b"}}}`)
+		case "/tx/manifest":
+			// Root-TX: root
+			_ = json.NewEncoder(w).Encode(types.Transaction{Tags: []types.Tag{{Name: "Um9vdC1UWA", Value: "cm9vdA"}}})
+		case "/tx/matic":
+			uploaded, _ = io.ReadAll(r.Body)
+			_ = json.NewEncoder(w).Encode(types.Transaction{ID: "new"})
+		}
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	c := &Client{
+		client:   retryablehttp.NewClient(),
+		network:  Node(srv.URL),
+		gateway:  srv.URL,
+		currency: matic,
+	}
+
+	tx, err := c.UpdateManifest(context.Background(), "manifest", map[string]string{"new.txt": "c", "old.txt": ""})
+	require.NoError(t, err)
+	require.Equal(t, "new", tx.ID)
+
+	require.Contains(t, string(uploaded),
+		`{"manifest":"arweave/paths","version":"0.1.0","index":{"path":"index.html"},"paths":{"index.html":{"id":"a"},"new.txt":{"id":"c"}}}`)
+	require.Contains(t, string(uploaded), RootTXTag)
+	require.Contains(t, string(uploaded), "root")
+}
+
+func TestUploadManifest(t *testing.T) {
+	var uploads [][]byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		uploads = append(uploads, body)
+		_ = json.NewEncoder(w).Encode(types.Transaction{ID: fmt.Sprintf("tx%d", len(uploads))})
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	c := &Client{
+		client:   retryablehttp.NewClient(),
+		network:  Node(srv.URL),
+		currency: matic,
+	}
+
+	tx, err := c.UploadManifest(context.Background(), map[string]io.Reader{
+		"metadata/1.json": strings.NewReader(`{"name":"nft #1","image":"images/1.png"}`),
+		"images/1.png":    bytes.NewReader([]byte("\x89PNG\r\n\x1a\n")),
+	}, "metadata/1.json")
+	require.NoError(t, err)
+	require.Equal(t, "tx3", tx.ID)
+	require.Len(t, uploads, 3)
+
+	// files uploaded in sorted path order before manifest
+	require.Contains(t, string(uploads[0]), "image/png")
+	require.Contains(t, string(uploads[1]), "application/json")
+	require.Contains(t, string(uploads[2]),
+		`{"manifest":"arweave/paths","version":"0.1.0","index":{"path":"metadata/1.json"},"paths":{"images/1.png":{"id":"tx1"},"metadata/1.json":{"id":"tx2"}}}`)
+	require.Contains(t, string(uploads[2]), ManifestContentType)
+
+	_, err = c.UploadManifest(context.Background(), map[string]io.Reader{"a.txt": strings.NewReader("a")}, "index.html")
+	require.ErrorIs(t, err, errs.ErrManifestIndexNotFound)
+
+	_, err = c.UploadManifest(context.Background(), nil, "")
+	require.ErrorIs(t, err, errs.ErrEmptyManifest)
+}
+
+func TestDownloadPath(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/manifest":
+			// gateway serve index of manifest on its path
+			_, _ = io.WriteString(w, "<html></html>")
+		case "/raw/manifest":
+			_, _ = io.WriteString(w, `{"manifest":"arweave/paths","version":"0.1.0","index":{"path":"index.html"},`+
+				`"paths":{"index.html":{"id":"a"},"images/1.png":{"id":"b"}}}`)
+		case "/a":
+			_, _ = io.WriteString(w, "<html></html>")
+		case "/b":
+			_, _ = io.WriteString(w, "png")
+		}
+	}))
+	defer srv.Close()
+
+	c := &Client{client: retryablehttp.NewClient(), gateway: srv.URL}
+
+	file, err := c.DownloadPath(context.Background(), "manifest", "/images/1.png")
+	require.NoError(t, err)
+	b, err := io.ReadAll(file.Data)
+	require.NoError(t, err)
+	require.Equal(t, "png", string(b))
+
+	file, err = c.DownloadPath(context.Background(), "manifest", "")
+	require.NoError(t, err)
+	b, err = io.ReadAll(file.Data)
+	require.NoError(t, err)
+	require.Equal(t, "<html></html>", string(b))
+
+	_, err = c.DownloadPath(context.Background(), "manifest", "missing.txt")
+	require.ErrorIs(t, err, errs.ErrManifestPathNotFound)
+}
@@ -0,0 +1,43 @@
+package irys
+
+import (
+	"encoding/json"
+)
+
+const (
+	_metaCacheTxPrefix      = "tx/"
+	_metaCacheReceiptPrefix = "receipt/"
+)
+
+// cachedMeta decode cached metadata of key, metadata of transaction is immutable so served without revalidation
+func cachedMeta[T any](c *Client, key string) (T, bool) {
+	var v T
+	if c.metaCache == nil {
+		return v, false
+	}
+
+	entry, ok := c.metaCache.get(key)
+	if !ok {
+		return v, false
+	}
+
+	if err := json.Unmarshal(entry.Data, &v); err != nil {
+		return v, false
+	}
+
+	return v, true
+}
+
+// cacheMeta store metadata of key in cache, values not fit in cache ignored
+func (c *Client) cacheMeta(key string, v any) {
+	if c.metaCache == nil {
+		return
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil || int64(len(b)) > c.metaCache.maxBytes() {
+		return
+	}
+
+	c.metaCache.put(key, &cacheEntry{Data: b})
+}
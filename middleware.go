@@ -0,0 +1,49 @@
+package irys
+
+import "net/http"
+
+// RoundTripper is http.RoundTripper of client transport chain
+type RoundTripper = http.RoundTripper
+
+// RoundTripperFunc adapt function to RoundTripper, like http.HandlerFunc
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wrap next transport of client, e.g. for auth, audit logging, fault injection or caching
+type Middleware func(next RoundTripper) RoundTripper
+
+// middlewareTransport is chain of middlewares over base transport, idle connections of
+// base transport closed even when middlewares not pass CloseIdleConnections.
+type middlewareTransport struct {
+	chain RoundTripper
+	base  RoundTripper
+}
+
+// newMiddlewareTransport wrap base with middlewares, first middleware is outermost and see request first
+func newMiddlewareTransport(base RoundTripper, middlewares []Middleware) *middlewareTransport {
+	chain := base
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		chain = middlewares[i](chain)
+	}
+	return &middlewareTransport{
+		chain: chain,
+		base:  base,
+	}
+}
+
+func (t *middlewareTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.chain.RoundTrip(req)
+}
+
+// CloseIdleConnections pass to base transport
+func (t *middlewareTransport) CloseIdleConnections() {
+	type closeIdler interface {
+		CloseIdleConnections()
+	}
+	if tr, ok := t.base.(closeIdler); ok {
+		tr.CloseIdleConnections()
+	}
+}
@@ -0,0 +1,73 @@
+package irys
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddlewareTransport(t *testing.T) {
+	var order []string
+	mark := func(name string) Middleware {
+		return func(next RoundTripper) RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				req.Header.Set("X-Middleware", name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	var got string
+	base := &closeCounter{RoundTripper: RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		got = req.Header.Get("X-Middleware")
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})}
+
+	tr := newMiddlewareTransport(base, []Middleware{mark("auth"), mark("audit")})
+
+	req, err := http.NewRequest(http.MethodGet, "http://localhost", nil)
+	require.NoError(t, err)
+
+	_, err = tr.RoundTrip(req)
+	require.NoError(t, err)
+	require.Equal(t, []string{"auth", "audit"}, order)
+	require.Equal(t, "audit", got)
+
+	tr.CloseIdleConnections()
+	require.Equal(t, 1, base.closed)
+}
+
+func TestMiddlewareShortCircuit(t *testing.T) {
+	injected := func(next RoundTripper) RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: http.NoBody}, nil
+		})
+	}
+
+	c := new(Client)
+	WithMiddleware(injected)(c)
+	require.Len(t, c.middlewares, 1)
+
+	tr := newMiddlewareTransport(RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		t.Fatal("base transport called")
+		return nil, nil
+	}), c.middlewares)
+
+	req, err := http.NewRequest(http.MethodGet, "http://localhost", nil)
+	require.NoError(t, err)
+
+	resp, err := tr.RoundTrip(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+}
+
+type closeCounter struct {
+	http.RoundTripper
+	closed int
+}
+
+func (c *closeCounter) CloseIdleConnections() {
+	c.closed++
+}
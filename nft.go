@@ -0,0 +1,67 @@
+package irys
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Ja7ad/irys/tags"
+	"github.com/Ja7ad/irys/types"
+)
+
+const (
+	NFTMediaType    = "nft-media"    // NFTMediaType is Type tag value of nft media
+	NFTMetadataType = "nft-metadata" // NFTMetadataType is Type tag value of nft metadata
+	MediaIDTag      = "Media-Id"     // MediaIDTag link nft metadata to its media transaction
+)
+
+// UploadNFT upload media then ERC-721/1155 metadata json with gateway url of media as image, or as animation_url
+// for video, audio and 3d models. Tags are added to both transactions, media Content-Type is detected from content.
+func (c *Client) UploadNFT(ctx context.Context, media []byte, metadata types.NFTMetadata, extraTags ...types.Tag) (types.NFTUpload, error) {
+	contentType := sniffContentType(media)
+
+	mediaTags := append([]types.Tag{tags.WithContentType(contentType), tags.New(tags.Type, NFTMediaType)}, extraTags...)
+	mediaTx, err := c.Upload(ctx, media, mediaTags...)
+	if err != nil {
+		return types.NFTUpload{}, fmt.Errorf("upload nft media: %w", err)
+	}
+
+	mediaURL := c.GatewayURL(mediaTx.ID)
+	if isAnimation(contentType) {
+		metadata.AnimationURL = mediaURL
+	} else {
+		metadata.Image = mediaURL
+	}
+
+	b, err := json.Marshal(metadata)
+	if err != nil {
+		return types.NFTUpload{}, err
+	}
+
+	c.debugMsg("[UploadNFT] media uploaded with transaction %s, upload metadata", mediaTx.ID)
+
+	metadataTags := append([]types.Tag{
+		tags.WithContentType("application/json"),
+		tags.New(tags.Type, NFTMetadataType),
+		{Name: MediaIDTag, Value: mediaTx.ID},
+	}, extraTags...)
+	metadataTx, err := c.Upload(ctx, b, metadataTags...)
+	if err != nil {
+		return types.NFTUpload{Media: mediaTx, MediaURL: mediaURL}, fmt.Errorf("upload nft metadata: %w", err)
+	}
+
+	return types.NFTUpload{
+		Media:    mediaTx,
+		Metadata: metadataTx,
+		MediaURL: mediaURL,
+		TokenURI: c.GatewayURL(metadataTx.ID),
+	}, nil
+}
+
+// isAnimation report media which marketplaces show from animation_url
+func isAnimation(contentType string) bool {
+	return strings.HasPrefix(contentType, "video/") ||
+		strings.HasPrefix(contentType, "audio/") ||
+		strings.HasPrefix(contentType, "model/")
+}
@@ -0,0 +1,61 @@
+package irys
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Ja7ad/irys/currency"
+	"github.com/Ja7ad/irys/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadNFT(t *testing.T) {
+	var uploads []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		uploads = append(uploads, string(body))
+		_ = json.NewEncoder(w).Encode(types.Transaction{ID: fmt.Sprintf("tx%d", len(uploads))})
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	c := &Client{
+		client:   retryablehttp.NewClient(),
+		network:  Node(srv.URL),
+		gateway:  "https://gateway.irys.xyz",
+		currency: matic,
+	}
+
+	result, err := c.UploadNFT(context.Background(), []byte("\x89PNG\r\n\x1a\n"), types.NFTMetadata{
+		Name:       "Token #1",
+		Attributes: []types.NFTAttribute{{TraitType: "Level", Value: 5}},
+	}, types.Tag{Name: "App-Name", Value: "mint"})
+	require.NoError(t, err)
+	require.Equal(t, "tx1", result.Media.ID)
+	require.Equal(t, "tx2", result.Metadata.ID)
+	require.Equal(t, "https://gateway.irys.xyz/tx1", result.MediaURL)
+	require.Equal(t, "https://gateway.irys.xyz/tx2", result.TokenURI)
+
+	require.Len(t, uploads, 2)
+	require.Contains(t, uploads[0], "image/png")
+	require.Contains(t, uploads[0], NFTMediaType)
+	require.Contains(t, uploads[1], `{"name":"Token #1","image":"https://gateway.irys.xyz/tx1","attributes":[{"trait_type":"Level","value":5}]}`)
+	require.Contains(t, uploads[1], NFTMetadataType)
+	require.Contains(t, uploads[1], "mint")
+
+	require.True(t, isAnimation("video/mp4"))
+	require.False(t, isAnimation("image/gif"))
+}
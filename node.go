@@ -1,11 +1,51 @@
 package irys
 
+import (
+	"net/url"
+	"strings"
+
+	"github.com/Ja7ad/irys/errors"
+)
+
 type Node string
 
 const (
-	DefaultNode1  Node = "https://node1.irys.xyz"  // DefaultNode1 is node 1 irys
-	DefaultNode2  Node = "https://node2.irys.xyz"  // DefaultNode2 is node 2 irys
-	DefaultDevNet Node = "https://devnet.irys.xyz" // DefaultDevNet is a testnet for test irys
+	DefaultUploader Node = "https://uploader.irys.xyz" // DefaultUploader is irys mainnet uploader
+	DefaultDevNet   Node = "https://devnet.irys.xyz"   // DefaultDevNet is a testnet for test irys
+
+	DefaultNode1 Node = "https://node1.irys.xyz" // DefaultNode1 is legacy bundler node 1 irys
+	DefaultNode2 Node = "https://node2.irys.xyz" // DefaultNode2 is legacy bundler node 2 irys
 )
 
-const _defaultGateway = "https://gateway.irys.xyz"
+// DefaultGateway is used when node report no gateway (or arweave.net) and WithGateway not set
+const DefaultGateway = "https://gateway.irys.xyz"
+
+const _infoPath = "%s/info"
+
+// IsLegacy return true for legacy bundler nodes (node1, node2)
+func (n Node) IsLegacy() bool {
+	return n == DefaultNode1 || n == DefaultNode2
+}
+
+// gatewayURL normalize gateway reported by node info, legacy nodes report arweave.net
+// which not serve irys transactions, so default gateway is used.
+func gatewayURL(gateway string) string {
+	gateway = strings.TrimRight(gateway, "/")
+	if len(gateway) == 0 || strings.Contains(gateway, "arweave.net") {
+		return DefaultGateway
+	}
+	if !strings.HasPrefix(gateway, "http://") && !strings.HasPrefix(gateway, "https://") {
+		gateway = "https://" + gateway
+	}
+	return gateway
+}
+
+// validateGateway check gateway is absolute http(s) url, so misconfigured private gateway fail on New
+// instead of on first download.
+func validateGateway(gateway string) error {
+	u, err := url.Parse(gateway)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+		return errors.ErrInvalidGateway
+	}
+	return nil
+}
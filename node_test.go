@@ -0,0 +1,30 @@
+package irys
+
+import (
+	"testing"
+
+	"github.com/Ja7ad/irys/errors"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGatewayURL(t *testing.T) {
+	require.Equal(t, DefaultGateway, gatewayURL(""))
+	require.Equal(t, DefaultGateway, gatewayURL("arweave.net"))
+	require.Equal(t, "https://gateway.irys.xyz", gatewayURL("gateway.irys.xyz/"))
+	require.Equal(t, "http://localhost:1984", gatewayURL("http://localhost:1984"))
+}
+
+func TestValidateGateway(t *testing.T) {
+	require.NoError(t, validateGateway("https://gateway.example.com"))
+	require.NoError(t, validateGateway("http://localhost:8080/prefix"))
+	require.ErrorIs(t, validateGateway("gateway.example.com"), errors.ErrInvalidGateway)
+	require.ErrorIs(t, validateGateway("ftp://gateway.example.com"), errors.ErrInvalidGateway)
+	require.ErrorIs(t, validateGateway("https://"), errors.ErrInvalidGateway)
+}
+
+func TestNodeIsLegacy(t *testing.T) {
+	require.True(t, DefaultNode1.IsLegacy())
+	require.True(t, DefaultNode2.IsLegacy())
+	require.False(t, DefaultUploader.IsLegacy())
+}
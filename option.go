@@ -1,15 +1,21 @@
 package irys
 
 import (
+	"log/slog"
+	"math/big"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/Ja7ad/irys/signer"
+	"github.com/Ja7ad/irys/types"
 	"github.com/Ja7ad/irys/utils/logger"
 )
 
 type Option func(irys *Client)
 
-// WithCustomClient set custom http client for irys
+// WithCustomClient set custom http client for irys, client and its *http.Transport copied by New so they
+// stay unchanged and can be shared with other clients
 func WithCustomClient(c *http.Client) Option {
 	return func(irys *Client) {
 		irys.client.HTTPClient = c
@@ -43,3 +49,449 @@ func WithCustomLogging(logging logger.Logger) Option {
 		irys.logging = logging
 	}
 }
+
+// WithLogLevel set minimum level of default logger
+func WithLogLevel(level slog.Level) Option {
+	return func(irys *Client) {
+		irys.logLevel = level
+	}
+}
+
+// WithLogFormat set handler type of default logger (console, text or json)
+func WithLogFormat(handler logger.HandleType) Option {
+	return func(irys *Client) {
+		irys.logFormat = handler
+	}
+}
+
+// WithLogHandler use any slog.Handler for client logs
+func WithLogHandler(handler slog.Handler) Option {
+	return func(irys *Client) {
+		irys.logging = logger.NewWithHandler(handler, _defaultSkipCaller)
+	}
+}
+
+// WithCircuitBreaker open circuit after threshold consecutive failures and fail fast until cooldown passed
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(irys *Client) {
+		irys.breakerThreshold = threshold
+		irys.breakerCooldown = cooldown
+	}
+}
+
+// WithDefaultTags add tags to every upload (e.g. tags.App), tag with same name set on upload take precedence
+func WithDefaultTags(tags ...types.Tag) Option {
+	return func(irys *Client) {
+		irys.defaultTags = append(irys.defaultTags, tags...)
+	}
+}
+
+// WithTagInjector set injector which change tags of every upload after default tags added, so tagging standard
+// enforced centrally (e.g. add Unix-Time). Many injectors run in order of registration.
+func WithTagInjector(inject TagInjector) Option {
+	return func(irys *Client) {
+		irys.tagInjectors = append(irys.tagInjectors, inject)
+	}
+}
+
+// WithCorrelationID stamp every upload with tag name and value generated by gen (e.g. UUIDv7, snowflake)
+func WithCorrelationID(tagName string, gen func() string) Option {
+	return func(irys *Client) {
+		irys.correlationTag = tagName
+		irys.correlationID = gen
+	}
+}
+
+// WithChunkSize set size of each chunk in bytes for ChunkUpload (default is file size divided by concurrency)
+func WithChunkSize(size int) Option {
+	return func(irys *Client) {
+		irys.chunkSize = size
+	}
+}
+
+// WithChunkConcurrency set number of concurrent workers for ChunkUpload
+func WithChunkConcurrency(n int) Option {
+	return func(irys *Client) {
+		irys.chunkConcurrency = n
+	}
+}
+
+// WithChunkMaxRetries maximum number of attempts per chunk on timeout error
+func WithChunkMaxRetries(retry int) Option {
+	return func(irys *Client) {
+		irys.chunkMaxRetries = retry
+	}
+}
+
+// WithReceiptFields request additional transaction fields in GetReceipt
+func WithReceiptFields(fields ...types.ReceiptField) Option {
+	return func(irys *Client) {
+		irys.receiptFields = fields
+	}
+}
+
+// WithReceiptVerifier verify signature of receipts by node signer in VerifyReceipts, signer of node is built by
+// signer.GetSigner(signer.Arweave, public key of node), public key returned as public of upload response
+func WithReceiptVerifier(node signer.Signer) Option {
+	return func(irys *Client) {
+		irys.receiptVerifier = node
+	}
+}
+
+// WithHooks register callbacks for upload lifecycle events (start, complete, funding, error)
+func WithHooks(hooks Hooks) Option {
+	return func(irys *Client) {
+		irys.hooks = hooks
+	}
+}
+
+// WithPaidBy pay uploads from balance of addresses approved this wallet by CreateApproval
+func WithPaidBy(addresses ...string) Option {
+	return func(irys *Client) {
+		irys.paidBy = addresses
+	}
+}
+
+// WithAuthHeader set header on download requests with value generated per request (e.g. signed message by currency.SignMessage)
+func WithAuthHeader(name string, value func() (string, error)) Option {
+	return func(irys *Client) {
+		irys.authHeader = name
+		irys.authValue = value
+	}
+}
+
+// WithDryRun sign, price and check balance for uploads without post data item and funding,
+// returned transaction has id and cost.
+func WithDryRun() Option {
+	return func(irys *Client) {
+		irys.dryRun = true
+	}
+}
+
+// WithUploadStats fill timing and retry stats in transaction of uploads
+func WithUploadStats() Option {
+	return func(irys *Client) {
+		irys.uploadStats = true
+	}
+}
+
+// WithAutoContentType sniff mime type (including json and svg) and charset from payload
+// and set Content-Type tag if not supplied in tags
+func WithAutoContentType() Option {
+	return func(irys *Client) {
+		irys.autoContentType = true
+	}
+}
+
+// WithProxy set http, https or socks5 proxy url for requests, user info of url used for proxy authentication
+// and hosts in NO_PROXY environment bypass proxy
+func WithProxy(proxyURL string) Option {
+	return func(irys *Client) {
+		irys.proxy = proxyURL
+	}
+}
+
+// WithDialer set custom dialer of transport (e.g. socks5 dialer from golang.org/x/net/proxy)
+func WithDialer(dialer Dialer) Option {
+	return func(irys *Client) {
+		irys.dialer = dialer
+	}
+}
+
+// WithMaxIdleConnsPerHost set max idle (keep-alive) connections kept per host, default transport
+// keep few connections and reopen them under high concurrent uploads
+func WithMaxIdleConnsPerHost(n int) Option {
+	return func(irys *Client) {
+		irys.maxIdleConnsPerHost = n
+	}
+}
+
+// WithMaxConnsPerHost limit total connections per host, zero is no limit
+func WithMaxConnsPerHost(n int) Option {
+	return func(irys *Client) {
+		irys.maxConnsPerHost = n
+	}
+}
+
+// WithIdleConnTimeout set how long idle connection kept in pool before closed
+func WithIdleConnTimeout(timeout time.Duration) Option {
+	return func(irys *Client) {
+		irys.idleConnTimeout = timeout
+	}
+}
+
+// WithExpectContinueTimeout set time wait for 100-continue response before send request body,
+// negative timeout send body immediately.
+func WithExpectContinueTimeout(timeout time.Duration) Option {
+	return func(irys *Client) {
+		irys.expectContinueTimeout = timeout
+	}
+}
+
+// WithForceHTTP2 attempt http/2 even with custom dialer or proxy, which otherwise disable it
+func WithForceHTTP2() Option {
+	return func(irys *Client) {
+		irys.forceHTTP2 = true
+	}
+}
+
+// WithLocalPricing fetch price rate of node once and compute upload prices locally,
+// rate refreshed from node when older than ttl, zero ttl disable local pricing.
+func WithLocalPricing(ttl time.Duration) Option {
+	return func(irys *Client) {
+		if ttl <= 0 {
+			irys.pricing = nil
+			return
+		}
+		irys.pricing = &localPricing{ttl: ttl}
+	}
+}
+
+// WithArweaveL1 set arweave wallet and node url (default https://arweave.net) for post data as
+// arweave L1 transaction, used by StrategyArweave and StrategyBundlerWithFallback upload strategies.
+func WithArweaveL1(wallet *signer.ArweaveSigner, arweaveURL string) Option {
+	return func(irys *Client) {
+		irys.arweaveSigner = wallet
+		irys.arweaveURL = strings.TrimRight(arweaveURL, "/")
+		if len(irys.arweaveURL) == 0 {
+			irys.arweaveURL = _defaultArweaveURL
+		}
+	}
+}
+
+// WithUploadStrategy select where Upload post data, default is StrategyBundler
+func WithUploadStrategy(strategy UploadStrategy) Option {
+	return func(irys *Client) {
+		irys.uploadStrategy = strategy
+	}
+}
+
+// WithJournal record pending uploads in dir before post, use Recover to resume them after crash
+func WithJournal(dir string) Option {
+	return func(irys *Client) {
+		irys.journalDir = dir
+	}
+}
+
+// WithAccountLog record fundings, upload prices and balance snapshots in log (e.g. NewFileAccountLog) for Reconcile
+func WithAccountLog(log AccountLog) Option {
+	return func(irys *Client) {
+		irys.accountLog = log
+	}
+}
+
+// WithReceiptStore persist receipt of each successful upload in store (e.g. NewFileReceiptStore)
+func WithReceiptStore(store ReceiptStore) Option {
+	return func(irys *Client) {
+		irys.receiptStore = store
+	}
+}
+
+// WithUploadIndex record each successful upload with tags in index (e.g. NewFileUploadIndex), query it by FindLocal
+func WithUploadIndex(index UploadIndex) Option {
+	return func(irys *Client) {
+		irys.uploadIndex = index
+	}
+}
+
+// WithGateway set gateway for download and transaction metadata (e.g. private gateway), default is gateway
+// reported by node or DefaultGateway. New return ErrInvalidGateway when url is not absolute http(s) url.
+func WithGateway(url string) Option {
+	return func(irys *Client) {
+		irys.gateway = strings.TrimRight(url, "/")
+	}
+}
+
+// WithDelegation sign uploads with session key on behalf of master key by delegation tags of Delegate,
+// so master key kept offline. Upload paid by session key address unless master approved it and set by WithPaidBy.
+func WithDelegation(session signer.Signer, delegation []types.Tag) Option {
+	return func(irys *Client) {
+		irys.sessionSigner = session
+		irys.delegation = delegation
+	}
+}
+
+// WithRedirectPolicy set policy of following redirects (e.g. NoRedirect), default follow up to 10 redirects
+func WithRedirectPolicy(policy RedirectPolicy) Option {
+	return func(irys *Client) {
+		irys.redirectPolicy = policy
+	}
+}
+
+// WithAllowedRedirectHosts follow redirects only to hosts (e.g. "gateway.irys.xyz", "*.irys.xyz") or host
+// of original request, redirect of gateway download to other host fail with ErrRedirectNotAllowed
+func WithAllowedRedirectHosts(hosts ...string) Option {
+	return func(irys *Client) {
+		irys.redirectHosts = append(irys.redirectHosts, hosts...)
+	}
+}
+
+// WithOperationTimeouts set default timeout per operation for calls with context without deadline,
+// e.g. WithOperationTimeouts(OperationTimeouts{Upload: 10 * time.Minute, Price: 5 * time.Second}),
+// default 300s timeout of http client dropped so operation timeouts may be longer (custom client kept)
+func WithOperationTimeouts(timeouts OperationTimeouts) Option {
+	return func(irys *Client) {
+		irys.timeouts = timeouts
+	}
+}
+
+// WithDrainTimeout set max time Close wait for in-flight requests before cancel them
+func WithDrainTimeout(timeout time.Duration) Option {
+	return func(irys *Client) {
+		irys.drainTimeout = timeout
+	}
+}
+
+// WithSpendLimit limit price of single upload and total price of uploads per utc day (nil for no limit), upload
+// over limit fail with ErrSpendLimitExceeded before funding or post. Limits are in atomic unit of currency,
+// L1 posts to arweave counted at node price. Funding (TopUpBalance, FundToTarget, auto fund) per utc day must
+// fit daily limit too, counted apart from uploads so top up of BasicUpload and upload paid by it counted once each.
+func WithSpendLimit(perUpload, perDay *big.Int) Option {
+	return func(irys *Client) {
+		irys.spend = newSpendLedger(perUpload, perDay)
+	}
+}
+
+// WithAutoFundOn402 top up shortfall and retry upload once when node return 402 (not enough balance),
+// shortfall more than limit not funded and upload fail with ErrAutoFundLimitExceeded.
+// Not supported with WithDelegation, New fail with ErrAutoFundWithDelegation.
+func WithAutoFundOn402(limit *big.Int) Option {
+	return func(irys *Client) {
+		irys.autoFundLimit = limit
+	}
+}
+
+// WithIPFSCID compute ipfs CIDv1 of payload and add it as IPFS-CID tag on upload
+func WithIPFSCID() Option {
+	return func(irys *Client) {
+		irys.ipfsCID = true
+	}
+}
+
+// WithFileHash compute sha256 of payload and add it as File-Hash tag on upload, searchable by FindByHash
+func WithFileHash() Option {
+	return func(irys *Client) {
+		irys.fileHash = true
+	}
+}
+
+// WithMaxBodySize set max response body size in byte of endpoint, bigger response fail with ErrResponseTooLarge
+func WithMaxBodySize(endpoint Endpoint, size int64) Option {
+	return func(irys *Client) {
+		if irys.bodyLimits == nil {
+			irys.bodyLimits = make(map[Endpoint]int64)
+		}
+		irys.bodyLimits[endpoint] = size
+	}
+}
+
+// WithHeaders add headers to every request (e.g. api keys or trace ids of fronting proxies)
+func WithHeaders(headers map[string]string) Option {
+	return func(irys *Client) {
+		if irys.headers == nil {
+			irys.headers = make(map[string]string, len(headers))
+		}
+		for k, v := range headers {
+			irys.headers[k] = v
+		}
+	}
+}
+
+// WithUserAgent set user agent of requests, default is irys-go/<sdk version>
+func WithUserAgent(userAgent string) Option {
+	return func(irys *Client) {
+		irys.userAgent = userAgent
+	}
+}
+
+// WithDownloadCache cache downloads in memory (lru) up to maxBytes, cached files revalidated with ETag
+func WithDownloadCache(maxBytes int64) Option {
+	return func(irys *Client) {
+		irys.cacheMaxBytes = maxBytes
+	}
+}
+
+// WithMetadataCache cache GetMetaData and GetReceipt responses in memory (lru) by txId up to maxBytes
+func WithMetadataCache(maxBytes int64) Option {
+	return func(irys *Client) {
+		irys.metaCacheMaxBytes = maxBytes
+	}
+}
+
+// WithDiskDownloadCache cache downloads in dir up to maxBytes, least recently used files removed first
+func WithDiskDownloadCache(dir string, maxBytes int64) Option {
+	return func(irys *Client) {
+		irys.cacheDir = dir
+		irys.cacheMaxBytes = maxBytes
+	}
+}
+
+// WithRetryHook call hook for every retry of requests, for observe retry rate per endpoint
+func WithRetryHook(hook RetryHook) Option {
+	return func(irys *Client) {
+		irys.retryHook = hook
+	}
+}
+
+// WithMiddleware wrap every request of client (node, gateway and arweave) with middleware, middlewares
+// run in order of options after client headers are set and inside circuit breaker and retries.
+func WithMiddleware(fn func(next RoundTripper) RoundTripper) Option {
+	return func(irys *Client) {
+		irys.middlewares = append(irys.middlewares, fn)
+	}
+}
+
+// WithDeadlineWarning call Hooks.OnDeadlineWarning when TimeToDeadline find unfinalized transaction
+// with deadline height within duration
+func WithDeadlineWarning(within time.Duration) Option {
+	return func(irys *Client) {
+		irys.deadlineWarning = within
+	}
+}
+
+// WithDownloadHandler decode downloaded data of transactions with tag name by handler (e.g. ContentEncodingTag
+// with GunzipHandler), handlers applied in order of registration. VerifyDownload always verify raw data.
+func WithDownloadHandler(tag string, handler DownloadHandler) Option {
+	return func(irys *Client) {
+		irys.downloadHandlers = append(irys.downloadHandlers, downloadHandler{tag: tag, handler: handler})
+	}
+}
+
+// WithFreeUploadLimit set size (in bytes) which uploads under it are free on node, BasicUpload skip price and
+// balance requests for them, default is DefaultFreeUploadLimit and zero disable free uploads.
+func WithFreeUploadLimit(size int) Option {
+	return func(irys *Client) {
+		irys.freeUploadLimit = size
+	}
+}
+
+// WithCodec set json codec of requests and responses (e.g. adapter of json-iterator), default is encoding/json
+func WithCodec(codec Codec) Option {
+	return func(irys *Client) {
+		irys.codec = codec
+	}
+}
+
+// WithMmap map files of UploadFile to memory (on unix) instead of read them, data signed and sent from mapping
+// without copy, useful for huge files. File must not be changed during upload.
+func WithMmap() Option {
+	return func(irys *Client) {
+		irys.mmap = true
+	}
+}
+
+// WithContractAddress set bundler address of currency (funding destination) and skip node info lookup of New,
+// so client created offline (e.g. unit tests) without startup request. Gateway is DefaultGateway unless
+// WithGateway set.
+func WithContractAddress(address string) Option {
+	return func(irys *Client) {
+		irys.contract = address
+	}
+}
+
+// WithMaxSourceSize limit size of remote resource fetched by UploadFromURL, default is DefaultMaxSourceSize
+func WithMaxSourceSize(size int64) Option {
+	return func(irys *Client) {
+		irys.maxSourceSize = size
+	}
+}
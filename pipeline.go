@@ -0,0 +1,86 @@
+package irys
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"io"
+	"strings"
+
+	"github.com/Ja7ad/irys/types"
+)
+
+// ContentEncodingTag is tag of compressed upload (e.g. gzip), decoded on download by GunzipHandler
+const ContentEncodingTag = "Content-Encoding"
+
+// DownloadHandler transform downloaded file with value of registered tag (e.g. decompress, decrypt or parse),
+// handler own file and close its data when return error.
+type DownloadHandler func(file *types.File, value string) (*types.File, error)
+
+type downloadHandler struct {
+	tag     string
+	handler DownloadHandler
+}
+
+// applyDownloadHandlers run handlers in order of registration for tags of transaction, tags fetched only
+// when any handler registered.
+func (c *Client) applyDownloadHandlers(ctx context.Context, txId string, file *types.File) (*types.File, error) {
+	if len(c.downloadHandlers) == 0 {
+		return file, nil
+	}
+
+	tags, err := c.GetTags(ctx, txId)
+	if err != nil {
+		file.Data.Close()
+		return nil, err
+	}
+
+	for _, h := range c.downloadHandlers {
+		for _, tag := range tags {
+			if !strings.EqualFold(tag.Name, h.tag) {
+				continue
+			}
+
+			file, err = h.handler(file, tag.Value)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return file, nil
+}
+
+// GunzipHandler decompress file of Content-Encoding gzip tag, data without gzip header
+// (e.g. already decompressed by transport) returned as is.
+func GunzipHandler(file *types.File, value string) (*types.File, error) {
+	if !strings.EqualFold(value, "gzip") {
+		return file, nil
+	}
+
+	br := bufio.NewReader(file.Data)
+	magic, _ := br.Peek(2)
+	if len(magic) < 2 || magic[0] != 0x1f || magic[1] != 0x8b {
+		file.Data = struct {
+			io.Reader
+			io.Closer
+		}{br, file.Data}
+		return file, nil
+	}
+
+	zr, err := gzip.NewReader(br)
+	if err != nil {
+		file.Data.Close()
+		return nil, err
+	}
+
+	return &types.File{
+		Data: struct {
+			io.Reader
+			io.Closer
+		}{zr, file.Data},
+		Header:        file.Header,
+		ContentLength: -1,
+		ContentType:   file.ContentType,
+	}, nil
+}
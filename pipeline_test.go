@@ -0,0 +1,76 @@
+package irys
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Ja7ad/irys/types"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownloadHandlers(t *testing.T) {
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	_, err := zw.Write([]byte("hello irys"))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	encode := base64.RawURLEncoding.EncodeToString
+	tx := types.Transaction{ID: "tx1", Tags: []types.Tag{
+		{Name: encode([]byte(ContentEncodingTag)), Value: encode([]byte("gzip"))},
+		{Name: encode([]byte("Text-Case")), Value: encode([]byte("upper"))},
+	}}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/tx/tx1" {
+			_ = json.NewEncoder(w).Encode(tx)
+			return
+		}
+		_, _ = w.Write(compressed.Bytes())
+	}))
+	defer srv.Close()
+
+	upper := func(file *types.File, value string) (*types.File, error) {
+		defer file.Data.Close()
+		b, err := io.ReadAll(file.Data)
+		if err != nil {
+			return nil, err
+		}
+		if value == "upper" {
+			b = bytes.ToUpper(b)
+		}
+		file.Data = io.NopCloser(bytes.NewReader(b))
+		file.ContentLength = int64(len(b))
+		return file, nil
+	}
+
+	c := &Client{client: retryablehttp.NewClient(), gateway: srv.URL}
+	WithDownloadHandler(ContentEncodingTag, GunzipHandler)(c)
+	WithDownloadHandler("text-case", upper)(c)
+
+	file, err := c.Download(context.Background(), "tx1")
+	require.NoError(t, err)
+	b, err := io.ReadAll(file.Data)
+	require.NoError(t, err)
+	require.NoError(t, file.Data.Close())
+	require.Equal(t, "HELLO IRYS", string(b))
+}
+
+func TestGunzipHandlerPlainData(t *testing.T) {
+	file := &types.File{Data: io.NopCloser(strings.NewReader("plain")), ContentLength: 5}
+
+	file, err := GunzipHandler(file, "gzip")
+	require.NoError(t, err)
+	b, err := io.ReadAll(file.Data)
+	require.NoError(t, err)
+	require.Equal(t, "plain", string(b))
+}
@@ -0,0 +1,141 @@
+package irys
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+)
+
+// Plan is batch of uploads funded once for aggregate cost, created by Client.NewPlan
+type Plan struct {
+	c     *Client
+	mu    sync.Mutex
+	items []planItem
+}
+
+type planItem struct {
+	file []byte
+	tags []types.Tag
+}
+
+// NewPlan create empty upload plan
+func (c *Client) NewPlan() *Plan {
+	return &Plan{c: c}
+}
+
+// Add files to plan without custom tags
+func (p *Plan) Add(files ...[]byte) *Plan {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, file := range files {
+		p.items = append(p.items, planItem{file: file})
+	}
+	return p
+}
+
+// AddWithTags add file with tags to plan
+func (p *Plan) AddWithTags(file []byte, tags ...types.Tag) *Plan {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.items = append(p.items, planItem{file: file, tags: tags})
+	return p
+}
+
+// Len return number of items in plan
+func (p *Plan) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.items)
+}
+
+// Estimate return aggregate and per item cost of plan
+func (p *Plan) Estimate(ctx context.Context) (types.BulkPrice, error) {
+	return p.estimate(ctx, p.snapshot())
+}
+
+// Execute fund balance once for aggregate cost of plan and upload all items in order.
+//
+// Uploads can not be reverted, on partial failure result report uploaded and failed items
+// with unspent cost of failed items and error wrap ErrPlanPartialFailure.
+func (p *Plan) Execute(ctx context.Context) (types.PlanResult, error) {
+	items := p.snapshot()
+	if len(items) == 0 {
+		return types.PlanResult{}, errors.ErrEmptyPlan
+	}
+
+	price, err := p.estimate(ctx, items)
+	if err != nil {
+		return types.PlanResult{}, err
+	}
+	p.c.debugMsg("[Plan] estimate %d items cost %s", len(items), price.Total.String())
+
+	result := types.PlanResult{
+		Cost:    price.Total,
+		Funded:  new(big.Int),
+		Unspent: new(big.Int),
+		Items:   make([]types.PlanItemResult, len(items)),
+	}
+
+	if !p.c.dryRun {
+		result.Funded, err = p.c.FundToTarget(ctx, price.Total)
+		if err != nil {
+			return types.PlanResult{}, err
+		}
+		p.c.debugMsg("[Plan] funded %s", result.Funded.String())
+	}
+
+	url := fmt.Sprintf(_uploadPath, p.c.network, p.c.currency.GetName())
+	failed := 0
+	for i, item := range items {
+		result.Items[i].Index = i
+		if err := ctx.Err(); err != nil {
+			result.Items[i].Err = err
+		} else {
+			result.Items[i].Tx, result.Items[i].Err = p.c.upload(ctx, url, item.file, item.tags...)
+		}
+
+		if result.Items[i].Err != nil {
+			failed++
+			result.Unspent.Add(result.Unspent, price.Items[i])
+		} else {
+			p.c.useUnspentCredit(price.Items[i])
+		}
+	}
+
+	if failed == 0 {
+		return result, nil
+	}
+
+	if result.Funded.Sign() > 0 && ctx.Err() != nil {
+		// plan canceled after funding, keep track of credit for AccountSummary
+		p.c.addUnspentCredit(minBig(result.Funded, result.Unspent))
+	}
+
+	return result, fmt.Errorf("%w: %d of %d items failed, unspent %s",
+		errors.ErrPlanPartialFailure, failed, len(items), result.Unspent)
+}
+
+func (p *Plan) snapshot() []planItem {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]planItem(nil), p.items...)
+}
+
+func (p *Plan) estimate(ctx context.Context, items []planItem) (types.BulkPrice, error) {
+	sizes := make([]int, len(items))
+	for i, item := range items {
+		sizes[i] = len(item.file)
+	}
+	return p.c.GetBulkPrice(ctx, sizes)
+}
+
+func minBig(a, b *big.Int) *big.Int {
+	if a.Cmp(b) < 0 {
+		return new(big.Int).Set(a)
+	}
+	return new(big.Int).Set(b)
+}
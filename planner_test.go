@@ -0,0 +1,79 @@
+package irys
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Ja7ad/irys/currency"
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanExecute(t *testing.T) {
+	uploads := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/price/"):
+			fmt.Fprint(w, 100)
+		case r.URL.Path == "/account/balance/matic":
+			fmt.Fprint(w, `{"balance":"1000"}`)
+		case r.URL.Path == "/tx/matic":
+			uploads++
+			if uploads == 2 {
+				w.WriteHeader(http.StatusPaymentRequired)
+				return
+			}
+			fmt.Fprintf(w, `{"id":"tx-%d"}`, uploads)
+		}
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	c := &Client{
+		client:        retryablehttp.NewClient(),
+		network:       Node(srv.URL),
+		mu:            new(sync.Mutex),
+		currency:      matic,
+		unspentCredit: new(big.Int),
+	}
+
+	_, err = c.NewPlan().Execute(context.Background())
+	require.ErrorIs(t, err, errs.ErrEmptyPlan)
+
+	plan := c.NewPlan().Add([]byte("a"), []byte("b")).AddWithTags([]byte("c"))
+	require.Equal(t, 3, plan.Len())
+
+	price, err := plan.Estimate(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(300), price.Total)
+
+	result, err := plan.Execute(context.Background())
+	require.ErrorIs(t, err, errs.ErrPlanPartialFailure)
+	require.Equal(t, 3, uploads)
+	require.Equal(t, big.NewInt(300), result.Cost)
+	require.Zero(t, result.Funded.Sign())
+	require.Equal(t, big.NewInt(100), result.Unspent)
+
+	require.Len(t, result.Uploaded(), 2)
+	require.Equal(t, "tx-1", result.Items[0].Tx.ID)
+	require.Equal(t, "tx-3", result.Items[2].Tx.ID)
+
+	failed := result.Failed()
+	require.Len(t, failed, 1)
+	require.Equal(t, 1, failed[0].Index)
+	require.ErrorIs(t, failed[0].Err, errs.ErrNotEnoughBalance)
+}
@@ -0,0 +1,154 @@
+package irys
+
+import (
+	"container/list"
+	"context"
+	"sync"
+
+	"github.com/Ja7ad/irys/currency"
+)
+
+// TenantResolver return currency (wallet) of tenant, called on first Get of tenant
+type TenantResolver func(ctx context.Context, tenant string) (currency.Currency, error)
+
+// ClientPool hold clients of many tenants (wallets) derived from base client by WithCurrency, so all
+// tenants share one http transport. Client of tenant created lazily on first Get and reused after.
+// Derived clients reuse node info cached by base client, so new tenant not cost /info request.
+type ClientPool struct {
+	base       Irys
+	resolve    TenantResolver
+	maxTenants int
+
+	mu      sync.Mutex
+	tenants map[string]*list.Element
+	lru     *list.List
+}
+
+type poolEntry struct {
+	tenant string
+	ready  chan struct{}
+	client Irys
+	err    error
+}
+
+// PoolOption customize ClientPool created by NewClientPool
+type PoolOption func(p *ClientPool)
+
+// WithMaxTenants keep at most n tenants in pool, least recently used tenant dropped when new tenant added.
+// Dropped client not closed because it share transport of pool, next Get of tenant resolve it again.
+func WithMaxTenants(n int) PoolOption {
+	return func(p *ClientPool) {
+		p.maxTenants = n
+	}
+}
+
+// NewClientPool create pool of tenants clients derived from base, close pool (or base) close shared transport.
+// Pool is unbounded unless WithMaxTenants set, tenants dropped explicitly by Remove.
+func NewClientPool(base Irys, resolve TenantResolver, opts ...PoolOption) *ClientPool {
+	p := &ClientPool{
+		base:    base,
+		resolve: resolve,
+		tenants: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Get return client of tenant, concurrent Get of new tenant resolve it once.
+// Failed tenant not kept, so next Get try again.
+func (p *ClientPool) Get(ctx context.Context, tenant string) (Irys, error) {
+	p.mu.Lock()
+	elem, ok := p.tenants[tenant]
+	if !ok {
+		entry := &poolEntry{tenant: tenant, ready: make(chan struct{})}
+		elem = p.lru.PushFront(entry)
+		p.tenants[tenant] = elem
+		p.evictLocked()
+		p.mu.Unlock()
+
+		entry.client, entry.err = p.create(ctx, tenant)
+		if entry.err != nil {
+			p.mu.Lock()
+			p.removeLocked(elem)
+			p.mu.Unlock()
+		}
+		close(entry.ready)
+	} else {
+		p.lru.MoveToFront(elem)
+		p.mu.Unlock()
+	}
+
+	entry := elem.Value.(*poolEntry)
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-entry.ready:
+		return entry.client, entry.err
+	}
+}
+
+func (p *ClientPool) create(ctx context.Context, tenant string) (Irys, error) {
+	cur, err := p.resolve(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+	return p.base.WithCurrency(ctx, cur)
+}
+
+// Remove drop client of tenant from pool (e.g. wallet rotated), next Get resolve tenant again
+func (p *ClientPool) Remove(tenant string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if elem, ok := p.tenants[tenant]; ok {
+		p.removeLocked(elem)
+	}
+}
+
+// evictLocked drop least recently used tenants while pool exceed WithMaxTenants
+func (p *ClientPool) evictLocked() {
+	for p.maxTenants > 0 && p.lru.Len() > p.maxTenants {
+		p.removeLocked(p.lru.Back())
+	}
+}
+
+// removeLocked drop tenant of elem, elem already dropped (evicted or replaced) is ignored
+func (p *ClientPool) removeLocked(elem *list.Element) {
+	tenant := elem.Value.(*poolEntry).tenant
+	if p.tenants[tenant] != elem {
+		return
+	}
+	delete(p.tenants, tenant)
+	p.lru.Remove(elem)
+}
+
+// Len return number of tenants in pool
+func (p *ClientPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.tenants)
+}
+
+// Close stop all tenants and close shared transport
+func (p *ClientPool) Close() {
+	p.mu.Lock()
+	p.reset()
+	p.mu.Unlock()
+	p.base.Close()
+}
+
+// Shutdown wait in-flight requests of all tenants until ctx done and close shared transport
+func (p *ClientPool) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	p.reset()
+	p.mu.Unlock()
+	return p.base.Shutdown(ctx)
+}
+
+func (p *ClientPool) reset() {
+	p.tenants = make(map[string]*list.Element)
+	p.lru.Init()
+}
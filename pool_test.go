@@ -0,0 +1,125 @@
+package irys
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Ja7ad/irys/currency"
+	"github.com/Ja7ad/irys/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientPool(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(types.NodeInfo{Addresses: map[string]string{"matic": "0x1"}})
+	}))
+	defer srv.Close()
+
+	newMatic := func() currency.Currency {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		c, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+		require.NoError(t, err)
+		return c
+	}
+
+	base := &Client{client: retryablehttp.NewClient(), network: Node(srv.URL), currency: newMatic(), mu: new(sync.Mutex)}
+
+	var resolved int32
+	pool := NewClientPool(base, func(ctx context.Context, tenant string) (currency.Currency, error) {
+		atomic.AddInt32(&resolved, 1)
+		if tenant == "unknown" {
+			return nil, fmt.Errorf("tenant %s not found", tenant)
+		}
+		return newMatic(), nil
+	})
+
+	var wg sync.WaitGroup
+	clients := make([]Irys, 10)
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c, err := pool.Get(context.Background(), "alice")
+			require.NoError(t, err)
+			clients[i] = c
+		}(i)
+	}
+	wg.Wait()
+
+	require.Equal(t, int32(1), atomic.LoadInt32(&resolved))
+	for _, c := range clients {
+		require.Same(t, clients[0], c)
+	}
+
+	bob, err := pool.Get(context.Background(), "bob")
+	require.NoError(t, err)
+	require.NotSame(t, clients[0], bob)
+	require.Equal(t, 2, pool.Len())
+
+	_, err = pool.Get(context.Background(), "unknown")
+	require.Error(t, err)
+	require.Equal(t, 2, pool.Len())
+
+	pool.Remove("bob")
+	require.Equal(t, 1, pool.Len())
+}
+
+func TestClientPoolMaxTenants(t *testing.T) {
+	var infos int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&infos, 1)
+		_ = json.NewEncoder(w).Encode(types.NodeInfo{Addresses: map[string]string{"matic": "0x1"}})
+	}))
+	defer srv.Close()
+
+	newMatic := func() currency.Currency {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		c, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+		require.NoError(t, err)
+		return c
+	}
+
+	base, err := New(Node(srv.URL), newMatic(), false, WithContractAddress("0x1"), WithCustomRetryMax(0))
+	require.NoError(t, err)
+
+	resolved := make(map[string]int)
+	pool := NewClientPool(base, func(ctx context.Context, tenant string) (currency.Currency, error) {
+		resolved[tenant]++
+		return newMatic(), nil
+	}, WithMaxTenants(2))
+	defer pool.Close()
+
+	alice, err := pool.Get(context.Background(), "alice")
+	require.NoError(t, err)
+	_, err = pool.Get(context.Background(), "bob")
+	require.NoError(t, err)
+
+	// alice used recently, so bob dropped for carol
+	again, err := pool.Get(context.Background(), "alice")
+	require.NoError(t, err)
+	require.Same(t, alice, again)
+	_, err = pool.Get(context.Background(), "carol")
+	require.NoError(t, err)
+	require.Equal(t, 2, pool.Len())
+
+	_, err = pool.Get(context.Background(), "alice")
+	require.NoError(t, err)
+	_, err = pool.Get(context.Background(), "bob")
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"alice": 1, "bob": 2, "carol": 1}, resolved)
+	require.Equal(t, 2, pool.Len())
+
+	// node info of base reused by all tenants
+	require.Equal(t, int32(1), atomic.LoadInt32(&infos))
+}
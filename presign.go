@@ -0,0 +1,74 @@
+package irys
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+)
+
+// SignDataItem sign data item of file with signer of client currency and return serialized bytes,
+// so backend sign and untrusted frontend (or edge worker) post it with UploadSignedItem without key.
+func (c *Client) SignDataItem(file []byte, tags ...types.Tag) ([]byte, error) {
+	item, err := signItem(file, c.uploadSigner(), false, c.uploadTags(file, tags...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := item.Reader()
+	if err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// UploadSignedItem verify and post serialized data item signed by SignDataItem, upload is paid by signer of item.
+func (c *Client) UploadSignedItem(ctx context.Context, raw []byte) (types.Transaction, error) {
+	item := new(types.BundleItem)
+	if err := item.Unmarshal(raw); err != nil {
+		return types.Transaction{}, fmt.Errorf("%w: %v", errors.ErrInvalidSignedItem, err)
+	}
+
+	if err := item.Verify(); err != nil {
+		return types.Transaction{}, fmt.Errorf("%w: %v", errors.ErrInvalidSignedItem, err)
+	}
+
+	if err := item.VerifySignature(); err != nil {
+		return types.Transaction{}, fmt.Errorf("%w: %v", errors.ErrInvalidSignedItem, err)
+	}
+
+	ctx, cancel := withTimeout(ctx, c.timeouts.Upload)
+	defer cancel()
+
+	c.uploadStarted(ctx, len(item.Data), item.Tags)
+
+	if c.dryRun {
+		tx, err := c.dryRunUpload(ctx, item, len(item.Data))
+		c.uploadCompleted(ctx, "UploadSignedItem", tx, err)
+		return tx, err
+	}
+
+	release, err := c.reserveSpend(ctx, len(item.Data), item.Tags)
+	if err != nil {
+		c.uploadCompleted(ctx, "UploadSignedItem", types.Transaction{}, err)
+		return types.Transaction{}, err
+	}
+
+	url := fmt.Sprintf(_uploadPath, c.network, c.currency.GetName())
+	tx, err := c.postItem(ctx, url, item.Id.Base64(), raw)
+	if err == nil {
+		tx = withItemTags(tx, item)
+		c.storeReceipt(ctx, tx)
+		c.indexUpload(ctx, tx, int64(len(item.Data)))
+		// item of other signer not paid by balance of client
+		if owner, err := c.uploadSigner().GetOwner(); err == nil && bytes.Equal(owner, item.Owner) {
+			c.recordUpload(ctx, tx, int64(len(item.Data)))
+		}
+	} else {
+		release()
+	}
+	c.uploadCompleted(ctx, "UploadSignedItem", tx, err)
+	return tx, err
+}
@@ -0,0 +1,57 @@
+package irys
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Ja7ad/irys/currency"
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadSignedItem(t *testing.T) {
+	var posted []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/tx/matic", r.URL.Path)
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		posted = b
+
+		item := new(types.BundleItem)
+		require.NoError(t, item.Unmarshal(b))
+		_ = json.NewEncoder(w).Encode(types.Transaction{ID: item.Id.Base64()})
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	backend := &Client{client: retryablehttp.NewClient(), network: Node(srv.URL), currency: matic}
+
+	raw, err := backend.SignDataItem([]byte("hello irys"), types.Tag{Name: "App-Name", Value: "irys"})
+	require.NoError(t, err)
+
+	item := new(types.BundleItem)
+	require.NoError(t, item.Unmarshal(raw))
+
+	tx, err := backend.UploadSignedItem(context.Background(), raw)
+	require.NoError(t, err)
+	require.Equal(t, item.Id.Base64(), tx.ID)
+	require.Equal(t, raw, posted)
+
+	tampered := append([]byte(nil), raw...)
+	tampered[len(tampered)-1] ^= 0xff
+	_, err = backend.UploadSignedItem(context.Background(), tampered)
+	require.ErrorIs(t, err, errs.ErrInvalidSignedItem)
+}
@@ -0,0 +1,127 @@
+package irys
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/Ja7ad/irys/types"
+	"github.com/Ja7ad/irys/utils/amount"
+)
+
+// DefaultFreeUploadLimit is size of irys free tier, uploads under 100 KiB are free on node
+const DefaultFreeUploadLimit = 100 * 1024
+
+// IsFreeUpload return true when size under free upload limit (WithFreeUploadLimit), node limit size of signed
+// data item (data with signature, owner and tags), not size of data
+func (c *Client) IsFreeUpload(size int) bool {
+	return size < c.freeUploadLimit
+}
+
+// itemSize return size of signed data item of data size with tags, tags added on upload not counted
+func (c *Client) itemSize(size int, tags []types.Tag) int {
+	item := types.BundleItem{
+		SignatureType: c.uploadSigner().GetType(),
+		Anchor:        make([]byte, 32),
+		Tags:          tags,
+	}
+	return item.Size() + size
+}
+
+// priceRate is node price model, base fee plus linear rate per 256 KiB chunk
+type priceRate struct {
+	base      *big.Int
+	perChunk  *big.Int
+	fetchedAt time.Time
+}
+
+// price compute upload price of size with node rounding, partial chunk charged as full and
+// empty data charged as one chunk
+func (r priceRate) price(size int) *big.Int {
+	price := new(big.Int).Mul(r.perChunk, big.NewInt(r.chunks(size)))
+	return price.Add(price, r.base)
+}
+
+// chunks return number of charged chunks of size
+func (r priceRate) chunks(size int) int64 {
+	chunks := int64((size + _priceChunkSize - 1) / _priceChunkSize)
+	if chunks < 1 {
+		chunks = 1
+	}
+	return chunks
+}
+
+// localPricing cache price rate of node for ttl (WithLocalPricing)
+type localPricing struct {
+	mu   sync.Mutex
+	ttl  time.Duration
+	rate *priceRate
+}
+
+// fetchPriceRate compute price rate from price of one and two chunks
+func (c *Client) fetchPriceRate(ctx context.Context) (priceRate, error) {
+	one, err := c.fetchPrice(ctx, _priceChunkSize)
+	if err != nil {
+		return priceRate{}, err
+	}
+
+	two, err := c.fetchPrice(ctx, 2*_priceChunkSize)
+	if err != nil {
+		return priceRate{}, err
+	}
+
+	perChunk := new(big.Int).Sub(two, one)
+	return priceRate{
+		base:      new(big.Int).Sub(one, perChunk),
+		perChunk:  perChunk,
+		fetchedAt: time.Now(),
+	}, nil
+}
+
+// priceRate return cached rate when local pricing enabled and rate is fresh, otherwise fetch it from node
+func (c *Client) priceRate(ctx context.Context) (priceRate, error) {
+	if c.pricing == nil {
+		return c.fetchPriceRate(ctx)
+	}
+
+	// hold lock while fetch, so concurrent uploads refresh stale rate once
+	c.pricing.mu.Lock()
+	defer c.pricing.mu.Unlock()
+
+	if c.pricing.rate != nil && time.Since(c.pricing.rate.fetchedAt) < c.pricing.ttl {
+		return *c.pricing.rate, nil
+	}
+
+	rate, err := c.fetchPriceRate(ctx)
+	if err != nil {
+		return priceRate{}, err
+	}
+	c.debugMsg("[LocalPricing] refresh rate base %s per chunk %s", rate.base, rate.perChunk)
+
+	c.pricing.rate = &rate
+	return rate, nil
+}
+
+// GetPriceBreakdown return itemized price of size, base fee, rate per chunk and byte and currency decimals,
+// total is equal to GetPrice of size.
+func (c *Client) GetPriceBreakdown(ctx context.Context, size int) (types.PriceBreakdown, error) {
+	rate, err := c.priceRate(ctx)
+	if err != nil {
+		return types.PriceBreakdown{}, err
+	}
+
+	chunks := rate.chunks(size)
+	return types.PriceBreakdown{
+		Size:      size,
+		Chunks:    chunks,
+		ChunkSize: _priceChunkSize,
+		BaseFee:   new(big.Int).Set(rate.base),
+		PerChunk:  new(big.Int).Set(rate.perChunk),
+		PerByte:   new(big.Rat).SetFrac(rate.perChunk, big.NewInt(_priceChunkSize)),
+		DataFee:   new(big.Int).Mul(rate.perChunk, big.NewInt(chunks)),
+		Total:     rate.price(size),
+		Currency:  c.currency.GetName(),
+		Decimals:  amount.Decimals(c.currency),
+	}, nil
+}
@@ -0,0 +1,179 @@
+package irys
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ja7ad/irys/currency"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalPricing(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		size, err := strconv.Atoi(r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+		require.NoError(t, err)
+		// base fee 100 and 10 per chunk
+		fmt.Fprint(w, 100+10*size/_priceChunkSize)
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	c := &Client{
+		client:   retryablehttp.NewClient(),
+		network:  Node(srv.URL),
+		currency: matic,
+	}
+	WithLocalPricing(time.Hour)(c)
+
+	for size, want := range map[int]int64{
+		0:                       110,
+		_priceChunkSize:         110,
+		_priceChunkSize + 1:     120,
+		10 * _priceChunkSize:    200,
+		10*_priceChunkSize - 10: 200,
+	} {
+		price, err := c.GetPrice(context.Background(), size)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(want), price, "size %d", size)
+	}
+	require.Equal(t, 2, calls)
+
+	// stale rate refreshed from node
+	c.pricing.rate.fetchedAt = time.Now().Add(-2 * time.Hour)
+	_, err = c.GetPrice(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, 4, calls)
+
+	WithLocalPricing(0)(c)
+	_, err = c.GetPrice(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, 5, calls)
+}
+
+func TestGetPriceBreakdown(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		size, err := strconv.Atoi(r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+		require.NoError(t, err)
+		// base fee 100 and 2^20 per chunk
+		fmt.Fprint(w, 100+(1<<20)*size/_priceChunkSize)
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	c := &Client{
+		client:   retryablehttp.NewClient(),
+		network:  Node(srv.URL),
+		currency: matic,
+	}
+
+	breakdown, err := c.GetPriceBreakdown(context.Background(), 3*_priceChunkSize-1)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), breakdown.Chunks)
+	require.Equal(t, big.NewInt(100), breakdown.BaseFee)
+	require.Equal(t, big.NewInt(1<<20), breakdown.PerChunk)
+	require.Equal(t, big.NewRat(4, 1), breakdown.PerByte)
+	require.Equal(t, big.NewInt(3<<20), breakdown.DataFee)
+	require.Equal(t, big.NewInt(100+3<<20), breakdown.Total)
+	require.Equal(t, "matic", breakdown.Currency)
+	require.Equal(t, 18, breakdown.Decimals)
+}
+
+func TestBasicUploadFree(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/tx/matic", r.URL.Path, "free upload must skip price and balance")
+		fmt.Fprint(w, `{"id":"tx1"}`)
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	c := &Client{
+		client:   retryablehttp.NewClient(),
+		network:  Node(srv.URL),
+		currency: matic,
+	}
+	WithFreeUploadLimit(DefaultFreeUploadLimit)(c)
+
+	require.True(t, c.IsFreeUpload(DefaultFreeUploadLimit-1))
+	require.False(t, c.IsFreeUpload(DefaultFreeUploadLimit))
+
+	tx, err := c.BasicUpload(context.Background(), []byte("small file"))
+	require.NoError(t, err)
+	require.Equal(t, "tx1", tx.ID)
+}
+
+func TestBasicUploadFreeItemSize(t *testing.T) {
+	var (
+		paths   []string
+		charged bool
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/price/"):
+			fmt.Fprint(w, 10)
+		case r.URL.Path == "/account/balance/matic":
+			fmt.Fprint(w, `{"balance":"100"}`)
+		case r.URL.Path == "/tx/matic" && charged:
+			charged = false
+			w.WriteHeader(http.StatusPaymentRequired)
+		default:
+			fmt.Fprint(w, `{"id":"tx1"}`)
+		}
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	c := &Client{
+		client:        retryablehttp.NewClient(),
+		network:       Node(srv.URL),
+		currency:      matic,
+		mu:            new(sync.Mutex),
+		unspentCredit: new(big.Int),
+	}
+	c.client.RetryMax = 0
+	WithFreeUploadLimit(DefaultFreeUploadLimit)(c)
+
+	// data under limit, signed item over limit
+	_, err = c.BasicUpload(context.Background(), make([]byte, DefaultFreeUploadLimit-10))
+	require.NoError(t, err)
+	require.Equal(t, []string{"/price/matic/102390", "/account/balance/matic", "/tx/matic"}, paths)
+
+	// node charge item of free size (e.g. with tags added on upload)
+	paths, charged = nil, true
+	_, err = c.BasicUpload(context.Background(), []byte("small file"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"/tx/matic", "/price/matic/10", "/account/balance/matic", "/tx/matic"}, paths)
+}
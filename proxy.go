@@ -0,0 +1,95 @@
+package irys
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/Ja7ad/irys/errors"
+)
+
+// Dialer is network dialer of transport, *net.Dialer and golang.org/x/net/proxy dialers implement it
+type Dialer interface {
+	DialContext(ctx context.Context, network, addr string) (net.Conn, error)
+}
+
+// configureTransport set proxy, dialer and connection pool options on http transport
+func (c *Client) configureTransport() error {
+	if len(c.proxy) == 0 && c.dialer == nil && !c.transportTuned() {
+		return nil
+	}
+
+	tr, ok := c.client.HTTPClient.Transport.(*http.Transport)
+	if !ok {
+		return errors.ErrTransportNotConfigurable
+	}
+
+	if len(c.proxy) != 0 {
+		proxyURL, err := url.Parse(c.proxy)
+		if err != nil {
+			return err
+		}
+		// user info of proxy url used for proxy authentication, socks5 scheme supported by transport
+		tr.Proxy = proxyFunc(proxyURL, noProxy())
+		c.debugMsg("set proxy %s", proxyURL.Redacted())
+	}
+
+	if c.dialer != nil {
+		tr.DialContext = c.dialer.DialContext
+	}
+
+	c.tuneTransport(tr)
+
+	return nil
+}
+
+// copyHTTPClient return shallow copy of client with clone of *http.Transport, other transports only wrapped
+// so kept as is
+func copyHTTPClient(client *http.Client) *http.Client {
+	cp := *client
+	if tr, ok := client.Transport.(*http.Transport); ok {
+		cp.Transport = tr.Clone()
+	}
+	return &cp
+}
+
+func proxyFunc(proxyURL *url.URL, noProxy []string) func(*http.Request) (*url.URL, error) {
+	return func(req *http.Request) (*url.URL, error) {
+		if bypassProxy(req.URL.Hostname(), noProxy) {
+			return nil, nil
+		}
+		return proxyURL, nil
+	}
+}
+
+func noProxy() []string {
+	v := os.Getenv("NO_PROXY")
+	if len(v) == 0 {
+		v = os.Getenv("no_proxy")
+	}
+
+	var hosts []string
+	for _, h := range strings.Split(v, ",") {
+		if h = strings.ToLower(strings.TrimSpace(h)); len(h) != 0 {
+			hosts = append(hosts, h)
+		}
+	}
+	return hosts
+}
+
+// bypassProxy report host match NO_PROXY entries (exact host, domain suffix or *)
+func bypassProxy(host string, noProxy []string) bool {
+	host = strings.ToLower(host)
+	for _, h := range noProxy {
+		if h == "*" || h == host {
+			return true
+		}
+		if strings.HasSuffix(host, "."+strings.TrimPrefix(h, ".")) {
+			return true
+		}
+	}
+	return false
+}
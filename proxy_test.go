@@ -0,0 +1,76 @@
+package irys
+
+import (
+	"encoding/hex"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Ja7ad/irys/currency"
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBypassProxy(t *testing.T) {
+	noProxy := []string{"localhost", ".internal.net", "irys.xyz"}
+
+	require.True(t, bypassProxy("localhost", noProxy))
+	require.True(t, bypassProxy("api.internal.net", noProxy))
+	require.True(t, bypassProxy("node1.irys.xyz", noProxy))
+	require.False(t, bypassProxy("gateway.arweave.net", noProxy))
+	require.True(t, bypassProxy("gateway.arweave.net", []string{"*"}))
+}
+
+func TestConfigureTransport(t *testing.T) {
+	tr := &http.Transport{MaxIdleConns: 10, ExpectContinueTimeout: time.Second}
+	c := &Client{client: retryablehttp.NewClient()}
+	c.client.HTTPClient.Transport = tr
+
+	for _, opt := range []Option{
+		WithMaxIdleConnsPerHost(64),
+		WithMaxConnsPerHost(128),
+		WithIdleConnTimeout(time.Minute),
+		WithExpectContinueTimeout(-1),
+		WithForceHTTP2(),
+	} {
+		opt(c)
+	}
+
+	require.NoError(t, c.configureTransport())
+	require.Equal(t, 64, tr.MaxIdleConnsPerHost)
+	require.Equal(t, 64, tr.MaxIdleConns)
+	require.Equal(t, 128, tr.MaxConnsPerHost)
+	require.Equal(t, time.Minute, tr.IdleConnTimeout)
+	require.Zero(t, tr.ExpectContinueTimeout)
+	require.True(t, tr.ForceAttemptHTTP2)
+
+	c.client.HTTPClient.Transport = roundTripFunc(nil)
+	require.ErrorIs(t, c.configureTransport(), errs.ErrTransportNotConfigurable)
+}
+
+func TestCustomClientNotChanged(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	tr := &http.Transport{MaxIdleConns: 10}
+	hc := &http.Client{Transport: tr}
+
+	// client shared by two irys clients
+	for i := 0; i < 2; i++ {
+		c, err := New(Node("http://127.0.0.1:1"), matic, false, WithContractAddress("0x1"), WithCustomClient(hc),
+			WithMaxConnsPerHost(128), WithRedirectPolicy(NoRedirect))
+		require.NoError(t, err)
+		require.NotSame(t, hc, c.(*Client).client.HTTPClient)
+		c.Close()
+	}
+
+	// transport and redirect policy of shared client kept as is
+	require.Same(t, tr, hc.Transport)
+	require.Zero(t, tr.MaxConnsPerHost)
+	require.Nil(t, hc.CheckRedirect)
+}
@@ -0,0 +1,137 @@
+package irys
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+)
+
+const _receiptExt = ".json"
+
+// ReceiptStore persist receipts of uploaded items (WithReceiptStore), use types.WriteReceiptsJSON or
+// types.WriteReceiptsCSV to export listed receipts.
+type ReceiptStore interface {
+	// Save receipt, existing receipt with same id replaced
+	Save(receipt types.Receipt) error
+	// Get receipt by transaction id, error ErrReceiptNotFound if not exists
+	Get(id string) (types.Receipt, error)
+	// List all receipts ordered by timestamp
+	List() ([]types.Receipt, error)
+}
+
+// FileReceiptStore is ReceiptStore keep each receipt as json file in directory
+type FileReceiptStore struct {
+	mu  sync.RWMutex
+	dir string
+}
+
+var _ ReceiptStore = (*FileReceiptStore)(nil)
+
+// NewFileReceiptStore create receipt store in dir, dir created if not exists
+func NewFileReceiptStore(dir string) (*FileReceiptStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &FileReceiptStore{dir: dir}, nil
+}
+
+func (s *FileReceiptStore) Save(receipt types.Receipt) error {
+	if !validCacheKey(receipt.ID) {
+		return fmt.Errorf("%w: invalid id %q", errors.ErrInvalidReceipt, receipt.ID)
+	}
+
+	b, err := json.Marshal(receipt)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// write to temp file and rename, so receipt never left half written on crash
+	tmp := s.path(receipt.ID) + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path(receipt.ID))
+}
+
+func (s *FileReceiptStore) Get(id string) (types.Receipt, error) {
+	if !validCacheKey(id) {
+		return types.Receipt{}, errors.ErrReceiptNotFound
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.read(id)
+}
+
+func (s *FileReceiptStore) List() ([]types.Receipt, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	files, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	receipts := make([]types.Receipt, 0, len(files))
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), _receiptExt) {
+			continue
+		}
+
+		receipt, err := s.read(strings.TrimSuffix(f.Name(), _receiptExt))
+		if err != nil {
+			return nil, err
+		}
+		receipts = append(receipts, receipt)
+	}
+
+	sort.Slice(receipts, func(i, j int) bool {
+		if receipts[i].Timestamp != receipts[j].Timestamp {
+			return receipts[i].Timestamp < receipts[j].Timestamp
+		}
+		return receipts[i].ID < receipts[j].ID
+	})
+
+	return receipts, nil
+}
+
+func (s *FileReceiptStore) read(id string) (types.Receipt, error) {
+	b, err := os.ReadFile(s.path(id))
+	if os.IsNotExist(err) {
+		return types.Receipt{}, errors.ErrReceiptNotFound
+	}
+	if err != nil {
+		return types.Receipt{}, err
+	}
+
+	var receipt types.Receipt
+	return receipt, json.Unmarshal(b, &receipt)
+}
+
+func (s *FileReceiptStore) path(id string) string {
+	return filepath.Join(s.dir, id+_receiptExt)
+}
+
+// storeReceipt save receipt of uploaded transaction, store failure reported to OnError hook
+// and not fail upload because item already accepted by node
+func (c *Client) storeReceipt(ctx context.Context, tx types.Transaction) {
+	if c.receiptStore == nil || c.dryRun {
+		return
+	}
+
+	if err := c.receiptStore.Save(tx.Receipt()); err != nil {
+		c.debugMsg("[ReceiptStore] save receipt of %s failed: %v", tx.ID, err)
+		c.failed(ctx, "StoreReceipt", err)
+	}
+}
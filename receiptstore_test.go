@@ -0,0 +1,88 @@
+package irys
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Ja7ad/irys/currency"
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileReceiptStore(t *testing.T) {
+	store, err := NewFileReceiptStore(t.TempDir())
+	require.NoError(t, err)
+
+	_, err = store.Get("missing")
+	require.ErrorIs(t, err, errs.ErrReceiptNotFound)
+	require.ErrorIs(t, store.Save(types.Receipt{ID: "../escape"}), errs.ErrInvalidReceipt)
+
+	require.NoError(t, store.Save(types.Receipt{ID: "tx-b", Timestamp: 2, Signature: "sig,b"}))
+	require.NoError(t, store.Save(types.Receipt{ID: "tx-a", Timestamp: 1, Version: "1.0.0"}))
+
+	receipt, err := store.Get("tx-b")
+	require.NoError(t, err)
+	require.Equal(t, "sig,b", receipt.Signature)
+
+	list, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, "tx-a", list[0].ID)
+
+	var buf bytes.Buffer
+	require.NoError(t, types.WriteReceiptsCSV(&buf, list))
+	require.Equal(t, "id,address,currency,signature,timestamp,version,deadline_height,transaction_timestamp\n"+
+		"tx-a,,,,1,1.0.0,0,0\n"+
+		"tx-b,,,\"sig,b\",2,,0,0\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, types.WriteReceiptsJSON(&buf, nil))
+	require.Equal(t, "[]\n", buf.String())
+}
+
+func TestUploadStoreReceipt(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/tx/") {
+			fmt.Fprint(w, `{"id":"tx-1","signature":"sig","timestamp":1700000000000,"version":"1.0.0","deadlineHeight":42}`)
+		}
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	store, err := NewFileReceiptStore(t.TempDir())
+	require.NoError(t, err)
+
+	c := &Client{
+		client:       retryablehttp.NewClient(),
+		network:      Node(srv.URL),
+		currency:     matic,
+		receiptStore: store,
+	}
+
+	_, err = c.Upload(context.Background(), []byte("hello"))
+	require.NoError(t, err)
+
+	receipt, err := store.Get("tx-1")
+	require.NoError(t, err)
+	require.Equal(t, types.Receipt{
+		ID:             "tx-1",
+		Signature:      "sig",
+		Timestamp:      1700000000000,
+		Version:        "1.0.0",
+		DeadlineHeight: 42,
+	}, receipt)
+}
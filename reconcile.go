@@ -0,0 +1,216 @@
+package irys
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+)
+
+// AccountLog persist fundings and balance snapshots of client (WithAccountLog) for Reconcile
+type AccountLog interface {
+	// Append record to log
+	Append(record types.AccountRecord) error
+	// List all records in order of append
+	List() ([]types.AccountRecord, error)
+}
+
+// FileAccountLog is AccountLog append records to file as json lines
+type FileAccountLog struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+var _ AccountLog = (*FileAccountLog)(nil)
+
+// NewFileAccountLog open account log of path, file and parent dir created if not exists
+func NewFileAccountLog(path string) (*FileAccountLog, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileAccountLog{file: f}, nil
+}
+
+func (l *FileAccountLog) Append(record types.AccountRecord) error {
+	b, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	_, err = l.file.Write(append(b, '\n'))
+	return err
+}
+
+func (l *FileAccountLog) List() ([]types.AccountRecord, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, err := l.file.Seek(0, 0); err != nil {
+		return nil, err
+	}
+
+	var records []types.AccountRecord
+	scanner := bufio.NewScanner(l.file)
+	for scanner.Scan() {
+		var record types.AccountRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			// last line may be half written on crash
+			continue
+		}
+		records = append(records, record)
+	}
+
+	return records, scanner.Err()
+}
+
+// Close close file of log
+func (l *FileAccountLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.file.Close()
+}
+
+// recordFunding append funding to account log, log failure reported to OnError hook and not fail funding
+// because transaction already sent
+func (c *Client) recordFunding(ctx context.Context, amount *big.Int, hash string) {
+	if c.accountLog == nil {
+		return
+	}
+
+	err := c.accountLog.Append(types.AccountRecord{
+		Kind:     types.AccountFunding,
+		Currency: c.currency.GetName(),
+		Amount:   new(big.Int).Set(amount),
+		TxHash:   hash,
+		Time:     time.Now(),
+	})
+	if err != nil {
+		c.debugMsg("[AccountLog] record funding %s failed: %v", hash, err)
+		c.failed(ctx, "RecordFunding", err)
+	}
+}
+
+// Snapshot record current balance in account log as opening balance for Reconcile
+func (c *Client) Snapshot(ctx context.Context) (types.AccountRecord, error) {
+	if c.accountLog == nil {
+		return types.AccountRecord{}, errors.ErrAccountLogNotConfigured
+	}
+
+	balance, err := c.GetBalance(ctx)
+	if err != nil {
+		return types.AccountRecord{}, err
+	}
+
+	record := types.AccountRecord{
+		Kind:     types.AccountSnapshot,
+		Currency: c.currency.GetName(),
+		Amount:   balance,
+		Time:     time.Now(),
+	}
+	return record, c.accountLog.Append(record)
+}
+
+// recordUpload append price of upload at upload time to account log, so Reconcile sum price paid instead of
+// price uploads again. Free uploads recorded with zero amount, log failure reported to OnError hook.
+func (c *Client) recordUpload(ctx context.Context, tx types.Transaction, size int64) {
+	if c.accountLog == nil || c.dryRun {
+		return
+	}
+
+	// L1 post paid by arweave wallet, not by balance of currency on node
+	if len(tx.Currency) != 0 && tx.Currency != c.currency.GetName() {
+		return
+	}
+
+	price := new(big.Int)
+	if !c.IsFreeUpload(c.itemSize(int(size), tx.Tags)) {
+		p, err := c.GetPrice(ctx, int(size))
+		if err != nil {
+			c.debugMsg("[AccountLog] price upload %s failed: %v", tx.ID, err)
+			c.failed(ctx, "RecordUpload", err)
+			return
+		}
+		price = p
+	}
+
+	err := c.accountLog.Append(types.AccountRecord{
+		Kind:     types.AccountUpload,
+		Currency: c.currency.GetName(),
+		Amount:   price,
+		TxHash:   tx.ID,
+		Time:     time.Now(),
+	})
+	if err != nil {
+		c.debugMsg("[AccountLog] record upload %s failed: %v", tx.ID, err)
+		c.failed(ctx, "RecordUpload", err)
+	}
+}
+
+// Reconcile combine fundings and uploads of account log (WithAccountLog) and current balance since time to
+// report of expected and actual balance. Opening balance is last snapshot (Snapshot) at or before since,
+// uploads counted at price recorded at upload time.
+func (c *Client) Reconcile(ctx context.Context, since time.Time) (types.ReconcileReport, error) {
+	if c.accountLog == nil {
+		return types.ReconcileReport{}, errors.ErrAccountLogNotConfigured
+	}
+
+	records, err := c.accountLog.List()
+	if err != nil {
+		return types.ReconcileReport{}, err
+	}
+
+	report := types.ReconcileReport{
+		Since:          since,
+		OpeningBalance: new(big.Int),
+		TotalFunded:    new(big.Int),
+		TotalSpent:     new(big.Int),
+	}
+
+	var opening time.Time
+	for _, record := range records {
+		if record.Currency != c.currency.GetName() || record.Amount == nil {
+			continue
+		}
+
+		switch {
+		case record.Kind == types.AccountSnapshot && !record.Time.After(since) && !record.Time.Before(opening):
+			opening = record.Time
+			report.OpeningBalance.Set(record.Amount)
+		case record.Kind == types.AccountFunding && !record.Time.Before(since):
+			report.Fundings++
+			report.TotalFunded.Add(report.TotalFunded, record.Amount)
+		case record.Kind == types.AccountUpload && !record.Time.Before(since):
+			report.Uploads++
+			report.TotalSpent.Add(report.TotalSpent, record.Amount)
+		}
+	}
+
+	report.ActualBalance, err = c.GetBalance(ctx)
+	if err != nil {
+		return types.ReconcileReport{}, err
+	}
+
+	report.ExpectedBalance = new(big.Int).Add(report.OpeningBalance, report.TotalFunded)
+	report.ExpectedBalance.Sub(report.ExpectedBalance, report.TotalSpent)
+	report.Difference = new(big.Int).Sub(report.ActualBalance, report.ExpectedBalance)
+
+	c.debugMsg("[Reconcile] funded %s, spent %s, expected %s, actual %s", report.TotalFunded,
+		report.TotalSpent, report.ExpectedBalance, report.ActualBalance)
+	return report, nil
+}
@@ -0,0 +1,96 @@
+package irys
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ja7ad/irys/currency"
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcile(t *testing.T) {
+	balance, price, posts := "1000", 110, 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/price/"):
+			fmt.Fprint(w, price)
+		case r.URL.Path == "/account/balance/matic":
+			fmt.Fprintf(w, `{"balance":%q}`, balance)
+		case r.URL.Path == "/tx/matic" && r.Method == http.MethodPost:
+			posts++
+			_ = json.NewEncoder(w).Encode(types.Transaction{ID: fmt.Sprintf("tx%d", posts)})
+		default:
+			// uploads not priced again by metadata of receipts
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	c := &Client{
+		client:   retryablehttp.NewClient(),
+		network:  Node(srv.URL),
+		gateway:  srv.URL,
+		currency: matic,
+	}
+
+	_, err = c.Reconcile(context.Background(), time.Now())
+	require.ErrorIs(t, err, errs.ErrAccountLogNotConfigured)
+
+	log, err := NewFileAccountLog(filepath.Join(t.TempDir(), "account.log"))
+	require.NoError(t, err)
+	defer log.Close()
+
+	WithAccountLog(log)(c)
+
+	snapshot, err := c.Snapshot(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1000), snapshot.Amount)
+
+	// upload before since not counted
+	_, err = c.Upload(context.Background(), []byte("old"))
+	require.NoError(t, err)
+
+	since := time.Now()
+	c.fundingCompleted(context.Background(), big.NewInt(500), "0xhash", nil)
+
+	_, err = c.Upload(context.Background(), []byte("hello"))
+	require.NoError(t, err)
+	_, err = c.Upload(context.Background(), []byte("hello"))
+	require.NoError(t, err)
+
+	// L1 post paid by arweave wallet
+	c.recordUpload(context.Background(), types.Transaction{ID: "l1", Currency: "arweave"}, 5)
+
+	// price of node changed after uploads
+	price = 200
+	balance = "1250"
+	report, err := c.Reconcile(context.Background(), since)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1000), report.OpeningBalance)
+	require.Equal(t, big.NewInt(500), report.TotalFunded)
+	require.Equal(t, 1, report.Fundings)
+	require.Equal(t, 2, report.Uploads)
+	require.Equal(t, big.NewInt(220), report.TotalSpent)
+	require.Equal(t, big.NewInt(1280), report.ExpectedBalance)
+	require.Equal(t, big.NewInt(1250), report.ActualBalance)
+	require.Equal(t, big.NewInt(-30), report.Difference)
+}
@@ -0,0 +1,83 @@
+package irys
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+const _maxRedirects = 10
+
+// RedirectPolicy decide to follow redirect of req like CheckRedirect of http.Client, via is requests
+// made so far oldest first. Request fail with ErrRedirectNotAllowed when policy return error.
+type RedirectPolicy func(req *http.Request, via []*http.Request) error
+
+// NoRedirect is RedirectPolicy never follow redirects
+func NoRedirect(req *http.Request, _ []*http.Request) error {
+	return fmt.Errorf("redirect to %s", req.URL.Host)
+}
+
+// configureRedirects set redirect policy and allowed hosts (WithRedirectPolicy, WithAllowedRedirectHosts)
+// as CheckRedirect of http client
+func (c *Client) configureRedirects() {
+	if c.redirectPolicy == nil && len(c.redirectHosts) == 0 {
+		return
+	}
+	c.client.HTTPClient.CheckRedirect = checkRedirect(c.redirectPolicy, c.redirectHosts)
+}
+
+func checkRedirect(policy RedirectPolicy, hosts []string) func(req *http.Request, via []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		// host of original request always allowed
+		if len(hosts) != 0 && !strings.EqualFold(req.URL.Hostname(), via[0].URL.Hostname()) &&
+			!hostAllowed(hosts, req.URL.Hostname()) {
+			return fmt.Errorf("%w: host %s not allowed", errors.ErrRedirectNotAllowed, req.URL.Host)
+		}
+
+		if policy == nil {
+			if len(via) >= _maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", _maxRedirects)
+			}
+			return nil
+		}
+
+		if err := policy(req, via); err != nil {
+			if err == http.ErrUseLastResponse {
+				return err
+			}
+			return fmt.Errorf("%w: %v", errors.ErrRedirectNotAllowed, err)
+		}
+		return nil
+	}
+}
+
+// hostAllowed match host with allowed hosts, "*.example.com" match subdomains of example.com
+func hostAllowed(hosts []string, host string) bool {
+	for _, allowed := range hosts {
+		if strings.HasPrefix(allowed, "*.") {
+			if suffix := allowed[1:]; len(host) > len(suffix) && strings.HasSuffix(strings.ToLower(host), strings.ToLower(suffix)) {
+				return true
+			}
+			continue
+		}
+		if strings.EqualFold(allowed, host) {
+			return true
+		}
+	}
+	return false
+}
+
+// redirectRetryPolicy not retry requests failed for redirect not allowed
+func redirectRetryPolicy(next retryablehttp.CheckRetry) retryablehttp.CheckRetry {
+	return func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		if stderrors.Is(err, errors.ErrRedirectNotAllowed) {
+			return false, err
+		}
+		return next(ctx, resp, err)
+	}
+}
@@ -0,0 +1,66 @@
+package irys
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHostAllowed(t *testing.T) {
+	hosts := []string{"gateway.irys.xyz", "*.example.com"}
+	require.True(t, hostAllowed(hosts, "Gateway.Irys.xyz"))
+	require.True(t, hostAllowed(hosts, "cdn.example.com"))
+	require.False(t, hostAllowed(hosts, "example.com"))
+	require.False(t, hostAllowed(hosts, "evilexample.com"))
+	require.False(t, hostAllowed(hosts, "irys.xyz"))
+}
+
+func TestRedirectPolicy(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "data")
+	}))
+	defer target.Close()
+
+	requests := 0
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		// other hostname of target server
+		http.Redirect(w, r, strings.Replace(target.URL, "127.0.0.1", "localhost", 1)+r.URL.Path, http.StatusFound)
+	}))
+	defer gateway.Close()
+
+	newClient := func(policy RedirectPolicy, hosts ...string) *Client {
+		c := &Client{
+			client:         retryablehttp.NewClient(),
+			gateway:        gateway.URL,
+			redirectPolicy: policy,
+			redirectHosts:  hosts,
+		}
+		c.client.RetryWaitMax = 0
+		c.client.CheckRetry = redirectRetryPolicy(c.client.CheckRetry)
+		c.configureRedirects()
+		return c
+	}
+
+	_, err := newClient(nil, "gateway.irys.xyz").Download(context.Background(), "tx")
+	require.ErrorIs(t, err, errs.ErrRedirectNotAllowed)
+	require.Equal(t, 1, requests)
+
+	_, err = newClient(NoRedirect).Download(context.Background(), "tx")
+	require.ErrorIs(t, err, errs.ErrRedirectNotAllowed)
+
+	file, err := newClient(nil, "localhost").Download(context.Background(), "tx")
+	require.NoError(t, err)
+	defer file.Data.Close()
+
+	b, err := io.ReadAll(file.Data)
+	require.NoError(t, err)
+	require.Equal(t, "data", string(b))
+}
@@ -0,0 +1,61 @@
+package irys
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// RetryHook called when request is going to be retried, attempt is number of next retry (starts from 1)
+// and err is failure of previous attempt (transport error or unexpected status).
+type RetryHook func(attempt int, req *http.Request, err error)
+
+type retryCtxKey struct{}
+
+type retryState struct {
+	attempt int
+	req     *http.Request
+}
+
+// trackRetry is retryablehttp request hook keep current attempt of request in its context for retry hook
+func trackRetry(next retryablehttp.RequestLogHook) retryablehttp.RequestLogHook {
+	return func(logger retryablehttp.Logger, req *http.Request, attempt int) {
+		state, ok := req.Context().Value(retryCtxKey{}).(*retryState)
+		if !ok {
+			state = new(retryState)
+			// request changed in place, so retryablehttp pass new context to CheckRetry
+			*req = *req.WithContext(context.WithValue(req.Context(), retryCtxKey{}, state))
+		}
+		state.attempt = attempt
+		state.req = req
+
+		if next != nil {
+			next(logger, req, attempt)
+		}
+	}
+}
+
+// retryHookPolicy call hook when next policy decide to retry and retries are not exhausted
+func retryHookPolicy(next retryablehttp.CheckRetry, retryMax int, hook RetryHook) retryablehttp.CheckRetry {
+	return func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		retry, checkErr := next(ctx, resp, err)
+		if !retry {
+			return retry, checkErr
+		}
+
+		state, ok := ctx.Value(retryCtxKey{}).(*retryState)
+		if !ok || state.attempt >= retryMax {
+			return retry, checkErr
+		}
+
+		cause := err
+		if cause == nil && resp != nil {
+			cause = fmt.Errorf("unexpected status %d", resp.StatusCode)
+		}
+		hook(state.attempt+1, state.req, cause)
+
+		return retry, checkErr
+	}
+}
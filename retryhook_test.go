@@ -0,0 +1,43 @@
+package irys
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryHook(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	var attempts []int
+	hook := func(attempt int, req *http.Request, err error) {
+		require.Equal(t, "/tx", req.URL.Path)
+		require.EqualError(t, err, "unexpected status 503")
+		attempts = append(attempts, attempt)
+	}
+
+	c := &Client{client: retryablehttp.NewClient(), gateway: srv.URL}
+	c.client.RetryWaitMin = time.Millisecond
+	c.client.RetryWaitMax = time.Millisecond
+	c.client.RequestLogHook = trackRetry(nil)
+	c.client.CheckRetry = retryHookPolicy(c.client.CheckRetry, c.client.RetryMax, hook)
+
+	exists, err := c.Exists(context.Background(), "tx")
+	require.NoError(t, err)
+	require.True(t, exists)
+	require.Equal(t, []int{1, 2}, attempts)
+}
@@ -0,0 +1,52 @@
+package irys
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+type idempotencyCtxKey struct{}
+
+// withIdempotencyKey mark non-idempotent method request of ctx safe to retry, key identify content
+// which node accept once (e.g. id of signed data item), so retry after timeout not duplicate upload.
+func withIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyCtxKey{}, key)
+}
+
+// idempotencyKey return key of request marked by withIdempotencyKey
+func idempotencyKey(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(idempotencyCtxKey{}).(string)
+	return key, ok && len(key) != 0
+}
+
+// idempotentRetryPolicy retry only idempotent methods (GET, HEAD, OPTIONS, PUT, DELETE) and requests with
+// idempotency key, other requests (e.g. funding confirmation) never retried blindly to avoid double spend.
+// Method of request read from retry state of trackRetry.
+func idempotentRetryPolicy(next retryablehttp.CheckRetry) retryablehttp.CheckRetry {
+	return func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		retry, checkErr := next(ctx, resp, err)
+		if !retry || retryable(ctx) {
+			return retry, checkErr
+		}
+		return false, checkErr
+	}
+}
+
+func retryable(ctx context.Context) bool {
+	if _, ok := idempotencyKey(ctx); ok {
+		return true
+	}
+
+	state, ok := ctx.Value(retryCtxKey{}).(*retryState)
+	if !ok || state.req == nil {
+		return false
+	}
+
+	switch state.req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package irys
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotentRetryPolicy(t *testing.T) {
+	calls := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls[r.Method+r.URL.Path]++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	client := retryablehttp.NewClient()
+	client.RetryMax = 2
+	client.RetryWaitMin = time.Millisecond
+	client.RetryWaitMax = time.Millisecond
+	client.ErrorHandler = retryablehttp.PassthroughErrorHandler
+	client.RequestLogHook = trackRetry(nil)
+	client.CheckRetry = idempotentRetryPolicy(client.CheckRetry)
+
+	do := func(ctx context.Context, method, path string) {
+		req, err := retryablehttp.NewRequestWithContext(ctx, method, srv.URL+path, []byte("{}"))
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	do(context.Background(), http.MethodGet, "/info")
+	do(context.Background(), http.MethodPost, "/account/balance/matic")
+	do(withIdempotencyKey(context.Background(), "item"), http.MethodPost, "/tx/matic")
+
+	require.Equal(t, 3, calls["GET/info"])
+	require.Equal(t, 1, calls["POST/account/balance/matic"])
+	require.Equal(t, 3, calls["POST/tx/matic"])
+}
@@ -0,0 +1,93 @@
+package irys
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/Ja7ad/irys/types"
+)
+
+// SearchIterator iterate pages of graphql search, cursor of last page can be persisted and
+// passed as SearchQuery.After to continue indexing later.
+type SearchIterator struct {
+	c      *Client
+	query  types.SearchQuery
+	cursor string
+	done   bool
+}
+
+// Search return iterator over pages of transactions matching query
+func (c *Client) Search(query types.SearchQuery) *SearchIterator {
+	return &SearchIterator{
+		c:      c,
+		query:  query,
+		cursor: query.After,
+	}
+}
+
+// Next fetch next page, return io.EOF when no page is left
+func (it *SearchIterator) Next(ctx context.Context) (types.SearchPage, error) {
+	if it.done {
+		return types.SearchPage{}, io.EOF
+	}
+
+	data, err := it.fetch(ctx)
+	if err != nil {
+		return types.SearchPage{}, err
+	}
+
+	edges := data.Transactions.Edges
+	page := types.SearchPage{
+		Transactions: make([]types.TransactionNode, 0, len(edges)),
+		Cursor:       it.cursor,
+		HasNextPage:  data.Transactions.PageInfo.HasNextPage,
+	}
+	for _, edge := range edges {
+		page.Transactions = append(page.Transactions, edge.Node)
+	}
+
+	if len(edges) != 0 {
+		page.Cursor = edges[len(edges)-1].Cursor
+		it.cursor = page.Cursor
+	}
+
+	if !page.HasNextPage || len(edges) == 0 {
+		it.done = true
+		if len(edges) == 0 {
+			return types.SearchPage{}, io.EOF
+		}
+	}
+
+	return page, nil
+}
+
+// fetch query page after cursor on node, or on gateway when query filter block height
+func (it *SearchIterator) fetch(ctx context.Context) (types.TransactionsData, error) {
+	ctx, cancel := withTimeout(ctx, it.c.timeouts.Query)
+	defer cancel()
+
+	if blockSearch(it.query) {
+		query, err := blockSearchQuery(it.query, it.cursor)
+		if err != nil {
+			return types.TransactionsData{}, err
+		}
+
+		data, err := graphqlQueryAt[arweaveTransactionsData](ctx, it.c, fmt.Sprintf(_graphql, it.c.gateway), query)
+		if err != nil {
+			return types.TransactionsData{}, err
+		}
+		return data.transactions(), nil
+	}
+
+	query, err := searchQuery(it.query, it.cursor)
+	if err != nil {
+		return types.TransactionsData{}, err
+	}
+	return graphqlQuery[types.TransactionsData](ctx, it.c, query)
+}
+
+// Cursor return cursor of last fetched transaction
+func (it *SearchIterator) Cursor() string {
+	return it.cursor
+}
@@ -0,0 +1,124 @@
+package irys
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchQuery(t *testing.T) {
+	from := time.UnixMilli(1700000000000)
+	query, err := searchQuery(types.SearchQuery{
+		Tags:  []types.TagFilter{{Name: "App-Name", Values: []string{"app"}}},
+		From:  from,
+		Order: types.SortAsc,
+	}, "cursor")
+	require.NoError(t, err)
+	require.Contains(t, query.Query, "$tags: [TagFilter!], $timestamp: TimestampFilter, $order: SortOrder, $limit: Int, $after: String")
+	require.NotContains(t, query.Query, "owners")
+	require.NotContains(t, query.Query, "block")
+	require.Equal(t, map[string]int64{"from": 1700000000000}, query.Variables["timestamp"])
+	require.Equal(t, "cursor", query.Variables["after"])
+	require.Equal(t, _defaultSearchPageSize, query.Variables["limit"])
+
+	_, err = searchQuery(types.SearchQuery{Order: "RANDOM"}, "")
+	require.ErrorIs(t, err, errs.ErrInvalidSearchQuery)
+
+	_, err = searchQuery(types.SearchQuery{From: from, To: from.Add(-time.Second)}, "")
+	require.ErrorIs(t, err, errs.ErrInvalidSearchQuery)
+}
+
+func TestBlockSearchQuery(t *testing.T) {
+	query, err := blockSearchQuery(types.SearchQuery{
+		Tags:           []types.TagFilter{{Name: "App-Name", Values: []string{"app"}}},
+		MinBlockHeight: 100,
+		Order:          types.SortAsc,
+	}, "cursor")
+	require.NoError(t, err)
+	require.Contains(t, query.Query, "$tags: [TagFilter!], $block: BlockFilter, $sort: SortOrder, $first: Int, $after: String")
+	require.Contains(t, query.Query, "block { height timestamp }")
+	require.Equal(t, map[string]int64{"min": 100}, query.Variables["block"])
+	require.Equal(t, "HEIGHT_ASC", query.Variables["sort"])
+	require.Equal(t, _defaultSearchPageSize, query.Variables["first"])
+
+	_, err = blockSearchQuery(types.SearchQuery{MinBlockHeight: 10, MaxBlockHeight: 5}, "")
+	require.ErrorIs(t, err, errs.ErrInvalidSearchQuery)
+
+	// gateway schema not filter currency and timestamp
+	_, err = blockSearchQuery(types.SearchQuery{MinBlockHeight: 10, Currency: "matic"}, "")
+	require.ErrorIs(t, err, errs.ErrInvalidSearchQuery)
+	_, err = blockSearchQuery(types.SearchQuery{MinBlockHeight: 10, From: time.Now()}, "")
+	require.ErrorIs(t, err, errs.ErrInvalidSearchQuery)
+}
+
+func TestSearchBlockHeight(t *testing.T) {
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("block search sent to node: %s", r.URL.Path)
+	}))
+	defer node.Close()
+
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/graphql", r.URL.Path)
+		_, _ = fmt.Fprint(w, `{"data":{"transactions":{"edges":[{"cursor":"c1","node":{"id":"tx1","owner":{"address":"addr"},`+
+			`"tags":[{"name":"App-Name","value":"app"}],"block":{"height":120,"timestamp":1700000000}}}],"pageInfo":{"hasNextPage":false}}}}`)
+	}))
+	defer gateway.Close()
+
+	c := &Client{client: retryablehttp.NewClient(), network: Node(node.URL), gateway: gateway.URL}
+	it := c.Search(types.SearchQuery{MinBlockHeight: 100})
+
+	page, err := it.Next(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []types.TransactionNode{{
+		ID:          "tx1",
+		Address:     "addr",
+		Timestamp:   1700000000000,
+		Tags:        []types.Tag{{Name: "App-Name", Value: "app"}},
+		BlockHeight: 120,
+	}}, page.Transactions)
+	require.Equal(t, "c1", page.Cursor)
+	require.False(t, page.HasNextPage)
+}
+
+func TestSearchIterator(t *testing.T) {
+	var afters []any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request types.GraphqlRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		afters = append(afters, request.Variables["after"])
+
+		page := len(afters)
+		_, _ = fmt.Fprintf(w, `{"data":{"transactions":{"edges":[{"cursor":"c%d","node":{"id":"tx%d","timestamp":%d}}],"pageInfo":{"hasNextPage":%t}}}}`,
+			page, page, page, page < 2)
+	}))
+	defer srv.Close()
+
+	c := &Client{client: retryablehttp.NewClient(), network: Node(srv.URL)}
+	it := c.Search(types.SearchQuery{PageSize: 1, After: "start"})
+
+	page, err := it.Next(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "tx1", page.Transactions[0].ID)
+	require.Equal(t, "c1", page.Cursor)
+	require.True(t, page.HasNextPage)
+
+	page, err = it.Next(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "tx2", page.Transactions[0].ID)
+	require.False(t, page.HasNextPage)
+	require.Equal(t, "c2", it.Cursor())
+
+	_, err = it.Next(context.Background())
+	require.ErrorIs(t, err, io.EOF)
+	require.Equal(t, []any{"start", "c1"}, afters)
+}
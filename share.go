@@ -0,0 +1,62 @@
+package irys
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/Ja7ad/irys/utils/qrcode"
+)
+
+const (
+	_filenameQuery       = "filename"
+	_defaultQRCodeScale  = 8
+	_gatewayURLSeparator = "/"
+)
+
+type gatewayURLOptions struct {
+	host     string
+	filename string
+}
+
+// GatewayURLOption customize url created by GatewayURL
+type GatewayURLOption func(opts *gatewayURLOptions)
+
+// WithURLHost set custom gateway host (e.g. https://arweave.net) for shared url
+func WithURLHost(host string) GatewayURLOption {
+	return func(opts *gatewayURLOptions) {
+		opts.host = strings.TrimRight(host, _gatewayURLSeparator)
+	}
+}
+
+// WithURLFilename add filename hint query param, used by browsers as download name
+func WithURLFilename(filename string) GatewayURLOption {
+	return func(opts *gatewayURLOptions) {
+		opts.filename = filename
+	}
+}
+
+func (c *Client) Gateway() string {
+	return c.gateway
+}
+
+func (c *Client) GatewayURL(txId string, opts ...GatewayURLOption) string {
+	options := &gatewayURLOptions{host: c.gateway}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	link := options.host + _gatewayURLSeparator + url.PathEscape(txId)
+	if len(options.filename) != 0 {
+		link += "?" + url.Values{_filenameQuery: {options.filename}}.Encode()
+	}
+
+	return link
+}
+
+func (c *Client) GatewayQRCode(txId string, opts ...GatewayURLOption) ([]byte, error) {
+	code, err := qrcode.Encode([]byte(c.GatewayURL(txId, opts...)))
+	if err != nil {
+		return nil, err
+	}
+	return code.PNG(_defaultQRCodeScale)
+}
@@ -0,0 +1,23 @@
+package irys
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientGatewayURL(t *testing.T) {
+	c := &Client{gateway: DefaultGateway}
+
+	require.Equal(t, "https://gateway.irys.xyz/tx", c.GatewayURL("tx"))
+	require.Equal(t, "https://arweave.net/tx?filename=my+file.png",
+		c.GatewayURL("tx", WithURLHost("https://arweave.net/"), WithURLFilename("my file.png")))
+
+	b, err := c.GatewayQRCode("tx")
+	require.NoError(t, err)
+
+	_, err = png.Decode(bytes.NewReader(b))
+	require.NoError(t, err)
+}
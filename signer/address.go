@@ -0,0 +1,45 @@
+package signer
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+
+	"github.com/Ja7ad/irys/errors"
+	ethereum_crypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// TypeByOwner detect signature type base on owner length
+func TypeByOwner(owner []byte) (SignatureType, error) {
+	switch len(owner) {
+	case (&ArweaveSigner{}).GetOwnerLength():
+		return Arweave, nil
+	case (&EthereumSigner{}).GetOwnerLength():
+		return Ethereum, nil
+	case (&Ed25519Signer{}).GetOwnerLength():
+		return ED25519, nil
+	case (&MultiAptosSigner{}).GetOwnerLength():
+		return MultiAptos, nil
+	}
+	return 0, errors.ErrUnsupportedSignatureType
+}
+
+// OwnerAddress return normalized address of owner, checksum hex for ethereum and
+// base64url sha256 of public key for arweave, aptos account address for ed25519 and multi aptos
+func OwnerAddress(signatureType SignatureType, owner []byte) (string, error) {
+	switch signatureType {
+	case Arweave:
+		hash := sha256.Sum256(owner)
+		return base64.RawURLEncoding.EncodeToString(hash[:]), nil
+	case Ethereum:
+		pub, err := ethereum_crypto.UnmarshalPubkey(owner)
+		if err != nil {
+			return "", err
+		}
+		return ethereum_crypto.PubkeyToAddress(*pub).Hex(), nil
+	case ED25519:
+		return AptosAddress(owner), nil
+	case MultiAptos:
+		return MultiAptosAddress(owner)
+	}
+	return "", errors.ErrUnsupportedSignatureType
+}
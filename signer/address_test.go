@@ -0,0 +1,50 @@
+package signer
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+
+	"github.com/Ja7ad/irys/errors"
+	ethereum_crypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOwnerAddress(t *testing.T) {
+	signer, err := NewEthereumSigner(ETHEREUM_PRIVATE_KEY)
+	require.NoError(t, err)
+
+	owner, err := signer.GetOwner()
+	require.NoError(t, err)
+
+	sigType, err := TypeByOwner(owner)
+	require.NoError(t, err)
+	require.Equal(t, Ethereum, sigType)
+
+	address, err := OwnerAddress(sigType, owner)
+	require.NoError(t, err)
+	require.Equal(t, ethereum_crypto.PubkeyToAddress(signer.PrivateKey.PublicKey).Hex(), address)
+
+	owner = make([]byte, 512)
+	sigType, err = TypeByOwner(owner)
+	require.NoError(t, err)
+	require.Equal(t, Arweave, sigType)
+
+	hash := sha256.Sum256(owner)
+	address, err = OwnerAddress(sigType, owner)
+	require.NoError(t, err)
+	require.Equal(t, base64.RawURLEncoding.EncodeToString(hash[:]), address)
+
+	// 32 bytes owner is ed25519 public key of aptos account
+	owner = make([]byte, 32)
+	sigType, err = TypeByOwner(owner)
+	require.NoError(t, err)
+	require.Equal(t, ED25519, sigType)
+
+	address, err = OwnerAddress(sigType, owner)
+	require.NoError(t, err)
+	require.Equal(t, AptosAddress(owner), address)
+
+	_, err = TypeByOwner(make([]byte, 31))
+	require.ErrorIs(t, err, errors.ErrUnsupportedSignatureType)
+}
@@ -0,0 +1,85 @@
+package signer
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"strings"
+
+	"github.com/Ja7ad/irys/errors"
+	"golang.org/x/crypto/sha3"
+)
+
+// _aptosEd25519Scheme is authentication key scheme of single ed25519 aptos account
+const _aptosEd25519Scheme = 0x00
+
+// Ed25519Signer sign data items with ed25519 key, used by aptos and other ed25519 chains
+type Ed25519Signer struct {
+	PrivateKey ed25519.PrivateKey
+	Owner      []byte
+}
+
+// NewEd25519Signer create signer from hex of 32 bytes seed (aptos private key) or 64 bytes private key,
+// 0x prefix is optional
+func NewEd25519Signer(privateKeyHex string) (*Ed25519Signer, error) {
+	buf, err := hex.DecodeString(strings.TrimPrefix(privateKeyHex, "0x"))
+	if err != nil {
+		return nil, err
+	}
+
+	var prKey ed25519.PrivateKey
+	switch len(buf) {
+	case ed25519.SeedSize:
+		prKey = ed25519.NewKeyFromSeed(buf)
+	case ed25519.PrivateKeySize:
+		prKey = ed25519.NewKeyFromSeed(buf[:ed25519.SeedSize])
+	default:
+		return nil, errors.ErrInvalidEd25519Key
+	}
+
+	return &Ed25519Signer{
+		PrivateKey: prKey,
+		Owner:      []byte(prKey.Public().(ed25519.PublicKey)),
+	}, nil
+}
+
+func (self *Ed25519Signer) Sign(data []byte) ([]byte, error) {
+	if len(self.PrivateKey) != ed25519.PrivateKeySize {
+		return nil, errors.ErrInvalidEd25519Key
+	}
+	return ed25519.Sign(self.PrivateKey, data), nil
+}
+
+func (self *Ed25519Signer) Verify(data []byte, signature []byte) error {
+	if len(self.Owner) != ed25519.PublicKeySize {
+		return errors.ErrInvalidEd25519Key
+	}
+	if !ed25519.Verify(self.Owner, data, signature) {
+		return errors.ErrEd25519SignatureMismatch
+	}
+	return nil
+}
+
+func (self *Ed25519Signer) GetOwner() ([]byte, error) {
+	if len(self.Owner) != ed25519.PublicKeySize {
+		return nil, errors.ErrInvalidEd25519Key
+	}
+	return self.Owner, nil
+}
+
+func (self *Ed25519Signer) GetType() SignatureType {
+	return ED25519
+}
+
+func (self *Ed25519Signer) GetSignatureLength() int {
+	return ed25519.SignatureSize
+}
+
+func (self *Ed25519Signer) GetOwnerLength() int {
+	return ed25519.PublicKeySize
+}
+
+// AptosAddress return 0x hex aptos account address of ed25519 public key, sha3-256 of key and scheme
+func AptosAddress(owner []byte) string {
+	hash := sha3.Sum256(append(append([]byte{}, owner...), _aptosEd25519Scheme))
+	return "0x" + hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,52 @@
+package signer
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// aptos sdk ed25519 test vector
+const (
+	APTOS_PRIVATE_KEY = "0xc5338cd251c22daa8c9c9cc94f498cc8a5c7e1d2e75287a5dda91096fe64efa5"
+	APTOS_PUBLIC_KEY  = "de19e5d1880cac87d57484ce9ed2e84cf0f9599f12e7cc3a52e4e7657a763f2c"
+	APTOS_ADDRESS     = "0x978c213990c4833df71548df7ce49d54c759d6b6d932de22b24d56060b7af2aa"
+)
+
+func TestEd25519Signer(t *testing.T) {
+	s, err := NewEd25519Signer(APTOS_PRIVATE_KEY)
+	require.NoError(t, err)
+
+	owner, err := s.GetOwner()
+	require.NoError(t, err)
+	require.Equal(t, APTOS_PUBLIC_KEY, hex.EncodeToString(owner))
+	require.Equal(t, s.GetOwnerLength(), len(owner))
+
+	data := []byte("irys data item")
+	signature, err := s.Sign(data)
+	require.NoError(t, err)
+	require.Len(t, signature, s.GetSignatureLength())
+
+	verifier, err := GetSigner(ED25519, owner)
+	require.NoError(t, err)
+	require.NoError(t, verifier.Verify(data, signature))
+	require.Error(t, verifier.Verify([]byte("other"), signature))
+
+	_, err = NewEd25519Signer("0x0102")
+	require.Error(t, err)
+}
+
+func TestEd25519OwnerAddress(t *testing.T) {
+	owner, err := hex.DecodeString(APTOS_PUBLIC_KEY)
+	require.NoError(t, err)
+
+	sigType, err := TypeByOwner(owner)
+	require.NoError(t, err)
+	require.Equal(t, ED25519, sigType)
+	require.Equal(t, APTOS, sigType)
+
+	address, err := OwnerAddress(sigType, owner)
+	require.NoError(t, err)
+	require.Equal(t, APTOS_ADDRESS, address)
+}
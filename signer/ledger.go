@@ -0,0 +1,273 @@
+package signer
+
+import (
+	"crypto/ecdsa"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/big"
+	"sync"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/core/types"
+	ethereum_crypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	LedgerVendorID = 0x2c97 // LedgerVendorID is usb vendor id of ledger devices
+
+	_ledgerCLA             = 0xe0
+	_ledgerInsGetAddress   = 0x02
+	_ledgerInsSignTx       = 0x04
+	_ledgerInsSignPersonal = 0x08
+	_ledgerP1First         = 0x00
+	_ledgerP1More          = 0x80
+	_ledgerMaxAPDU         = 255
+
+	_hidChannel    = 0x0101
+	_hidTag        = 0x05
+	_hidPacketSize = 64
+	_hidHeaderSize = 5
+)
+
+// LedgerSigner sign data items and funding transactions on ledger ethereum app, every signature
+// need approval on device. Device is opened hid device of ledger (e.g. usb.EnumerateHid(LedgerVendorID, 0)
+// of github.com/karalabe/usb), signer is safe for concurrent use but requests are serialized.
+type LedgerSigner struct {
+	mu     sync.Mutex
+	device io.ReadWriter
+	path   accounts.DerivationPath
+	pubKey *ecdsa.PublicKey
+}
+
+// NewLedgerSigner create signer of account in derivation path (e.g. m/44'/60'/0'/0/0) on ledger device
+func NewLedgerSigner(device io.ReadWriter, path string) (*LedgerSigner, error) {
+	derivationPath, err := accounts.ParseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	self := &LedgerSigner{device: device, path: derivationPath}
+
+	reply, err := self.exchangeChunks(_ledgerInsGetAddress, self.serializePath())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(reply) < 1 || len(reply) < 1+int(reply[0]) {
+		return nil, errors.ErrLedgerInvalidReply
+	}
+
+	self.pubKey, err = ethereum_crypto.UnmarshalPubkey(reply[1 : 1+int(reply[0])])
+	if err != nil {
+		return nil, err
+	}
+
+	return self, nil
+}
+
+// PublicKey return public key of ledger account
+func (self *LedgerSigner) PublicKey() *ecdsa.PublicKey {
+	return self.pubKey
+}
+
+// Sign data as personal message (EIP-191), same as EthereumSigner
+func (self *LedgerSigner) Sign(data []byte) (signature []byte, err error) {
+	payload := self.serializePath()
+	payload = appendUint32(payload, uint32(len(data)))
+	payload = append(payload, data...)
+
+	reply, err := self.exchangeChunks(_ledgerInsSignPersonal, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := ledgerSignature(reply)
+	if err != nil {
+		return nil, err
+	}
+	sig[64] -= 27
+
+	return sig, nil
+}
+
+// SignTx sign legacy EIP-155 transaction on device
+func (self *LedgerSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	rawTx, err := rlp.EncodeToBytes([]interface{}{
+		tx.Nonce(), tx.GasPrice(), tx.Gas(), tx.To(), tx.Value(), tx.Data(), chainID, uint(0), uint(0),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := self.exchangeChunks(_ledgerInsSignTx, append(self.serializePath(), rawTx...))
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := ledgerSignature(reply)
+	if err != nil {
+		return nil, err
+	}
+
+	// device return only low byte of EIP-155 v for big chain ids
+	sig[64] -= byte(chainID.Uint64()*2 + 35)
+
+	signer := types.NewEIP155Signer(chainID)
+	signed, err := tx.WithSignature(signer, sig)
+	if err != nil {
+		return nil, err
+	}
+
+	sender, err := types.Sender(signer, signed)
+	if err != nil {
+		return nil, err
+	}
+	if sender != ethereum_crypto.PubkeyToAddress(*self.pubKey) {
+		return nil, errors.ErrLedgerInvalidReply
+	}
+
+	return signed, nil
+}
+
+func (self *LedgerSigner) Verify(data []byte, signature []byte) (err error) {
+	owner, err := self.GetOwner()
+	if err != nil {
+		return err
+	}
+	return (&EthereumSigner{Owner: owner}).Verify(data, signature)
+}
+
+func (self *LedgerSigner) GetOwner() ([]byte, error) {
+	return ethereum_crypto.FromECDSAPub(self.pubKey), nil
+}
+
+func (self *LedgerSigner) GetType() SignatureType {
+	return Ethereum
+}
+
+func (self *LedgerSigner) GetSignatureLength() int {
+	return 65
+}
+
+func (self *LedgerSigner) GetOwnerLength() int {
+	return 65
+}
+
+func (self *LedgerSigner) serializePath() []byte {
+	path := []byte{byte(len(self.path))}
+	for _, index := range self.path {
+		path = appendUint32(path, index)
+	}
+	return path
+}
+
+// ledgerSignature convert v, r, s reply of device to r, s, v signature
+func ledgerSignature(reply []byte) ([]byte, error) {
+	if len(reply) != 65 {
+		return nil, errors.ErrLedgerInvalidReply
+	}
+	return append(reply[1:], reply[0]), nil
+}
+
+// exchangeChunks send payload in chunks of max apdu size, reply of last chunk returned. Device is locked
+// for all chunks, since chunks of concurrent request would be appended to pending payload of device.
+func (self *LedgerSigner) exchangeChunks(ins byte, payload []byte) ([]byte, error) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	p1 := byte(_ledgerP1First)
+	for {
+		chunk := payload
+		if len(chunk) > _ledgerMaxAPDU {
+			chunk = chunk[:_ledgerMaxAPDU]
+		}
+		payload = payload[len(chunk):]
+
+		reply, err := self.exchange(ins, p1, chunk)
+		if err != nil || len(payload) == 0 {
+			return reply, err
+		}
+		p1 = _ledgerP1More
+	}
+}
+
+// exchange send apdu to device framed in hid packets and return reply data without status word,
+// caller hold self.mu
+func (self *LedgerSigner) exchange(ins, p1 byte, data []byte) ([]byte, error) {
+	apdu := append([]byte{_ledgerCLA, ins, p1, 0x00, byte(len(data))}, data...)
+	if err := writeHIDFrames(self.device, apdu); err != nil {
+		return nil, err
+	}
+
+	reply, err := readHIDFrames(self.device)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(reply) < 2 {
+		return nil, errors.ErrLedgerInvalidReply
+	}
+
+	status := binary.BigEndian.Uint16(reply[len(reply)-2:])
+	if status != 0x9000 {
+		return nil, fmt.Errorf("%w: status 0x%04x", errors.ErrLedgerRejected, status)
+	}
+
+	return reply[:len(reply)-2], nil
+}
+
+// writeHIDFrames split message to 64 bytes hid packets, first packet contain message length
+func writeHIDFrames(w io.Writer, msg []byte) error {
+	payload := append([]byte{byte(len(msg) >> 8), byte(len(msg))}, msg...)
+
+	for seq := uint16(0); len(payload) > 0; seq++ {
+		packet := make([]byte, _hidPacketSize)
+		binary.BigEndian.PutUint16(packet, _hidChannel)
+		packet[2] = _hidTag
+		binary.BigEndian.PutUint16(packet[3:], seq)
+
+		n := copy(packet[_hidHeaderSize:], payload)
+		payload = payload[n:]
+
+		if _, err := w.Write(packet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readHIDFrames read hid packets until message of length in first packet completed
+func readHIDFrames(r io.Reader) ([]byte, error) {
+	var (
+		msg    []byte
+		length = -1
+	)
+
+	packet := make([]byte, _hidPacketSize)
+	for seq := uint16(0); length < 0 || len(msg) < length; seq++ {
+		if _, err := io.ReadFull(r, packet); err != nil {
+			return nil, err
+		}
+
+		if binary.BigEndian.Uint16(packet) != _hidChannel || packet[2] != _hidTag ||
+			binary.BigEndian.Uint16(packet[3:]) != seq {
+			return nil, errors.ErrLedgerInvalidReply
+		}
+
+		data := packet[_hidHeaderSize:]
+		if seq == 0 {
+			length = int(binary.BigEndian.Uint16(data))
+			data = data[2:]
+		}
+		msg = append(msg, data...)
+	}
+
+	return msg[:length], nil
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	return append(b, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
@@ -0,0 +1,132 @@
+package signer
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/binary"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	ethereum_crypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+)
+
+// mockLedger emulate ledger ethereum app over hid frames with software key
+type mockLedger struct {
+	key     *ecdsa.PrivateKey
+	chainID uint64
+	reject  bool
+	in, out bytes.Buffer
+	pending []byte
+}
+
+func (m *mockLedger) Write(p []byte) (int, error) {
+	m.in.Write(p)
+	if length := int(binary.BigEndian.Uint16(m.in.Bytes()[_hidHeaderSize:])); (m.in.Len()/_hidPacketSize)*(_hidPacketSize-_hidHeaderSize)-2 < length {
+		return len(p), nil
+	}
+
+	apdu, err := readHIDFrames(&m.in)
+	if err != nil {
+		return 0, err
+	}
+	return len(p), writeHIDFrames(&m.out, m.handle(apdu))
+}
+
+func (m *mockLedger) Read(p []byte) (int, error) {
+	return m.out.Read(p)
+}
+
+func (m *mockLedger) handle(apdu []byte) []byte {
+	if m.reject {
+		return []byte{0x69, 0x85}
+	}
+
+	ins, p1, data := apdu[1], apdu[2], apdu[5:]
+	if p1 == _ledgerP1First {
+		// skip derivation path
+		data = data[1+4*int(data[0]):]
+		m.pending = nil
+	}
+	m.pending = append(m.pending, data...)
+
+	ok := []byte{0x90, 0x00}
+	switch ins {
+	case _ledgerInsGetAddress:
+		pub := ethereum_crypto.FromECDSAPub(&m.key.PublicKey)
+		return append(append([]byte{byte(len(pub))}, pub...), ok...)
+	case _ledgerInsSignPersonal:
+		msg := m.pending[4:]
+		if len(msg) < int(binary.BigEndian.Uint32(m.pending)) {
+			return ok
+		}
+		return m.sign(accounts.TextHash(msg), 27)
+	case _ledgerInsSignTx:
+		if _, _, rest, err := rlp.Split(m.pending); err != nil || len(rest) != 0 {
+			return ok
+		}
+		return m.sign(ethereum_crypto.Keccak256(m.pending), byte(m.chainID*2+35))
+	}
+	return []byte{0x6d, 0x00}
+}
+
+func (m *mockLedger) sign(hash []byte, v byte) []byte {
+	sig, err := ethereum_crypto.Sign(hash, m.key)
+	if err != nil {
+		panic(err)
+	}
+	return append(append([]byte{sig[64] + v}, sig[:64]...), 0x90, 0x00)
+}
+
+func TestLedgerSigner(t *testing.T) {
+	key, err := ethereum_crypto.GenerateKey()
+	require.NoError(t, err)
+
+	device := &mockLedger{key: key, chainID: 80001}
+	s, err := NewLedgerSigner(device, "m/44'/60'/0'/0/0")
+	require.NoError(t, err)
+	require.Equal(t, key.PublicKey, *s.PublicKey())
+
+	// message bigger than single apdu
+	data := bytes.Repeat([]byte("irys"), 200)
+	sig, err := s.Sign(data)
+	require.NoError(t, err)
+	require.NoError(t, s.Verify(data, sig))
+
+	want, err := (&EthereumSigner{PrivateKey: key}).Sign(data)
+	require.NoError(t, err)
+	require.Equal(t, want, sig)
+
+	chainID := new(big.Int).SetUint64(device.chainID)
+	tx := types.NewTransaction(1, common.HexToAddress("0x1"), big.NewInt(100), 21000, big.NewInt(1), make([]byte, 300))
+	signed, err := s.SignTx(tx, chainID)
+	require.NoError(t, err)
+
+	sender, err := types.Sender(types.NewEIP155Signer(chainID), signed)
+	require.NoError(t, err)
+	require.Equal(t, ethereum_crypto.PubkeyToAddress(key.PublicKey), sender)
+
+	// chunks of concurrent messages not interleaved on device
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			msg := bytes.Repeat([]byte{byte(i)}, 600)
+			sig, err := s.Sign(msg)
+			require.NoError(t, err)
+			require.NoError(t, s.Verify(msg, sig))
+		}(i)
+	}
+	wg.Wait()
+
+	device.reject = true
+	_, err = s.Sign(data)
+	require.ErrorIs(t, err, errors.ErrLedgerRejected)
+}
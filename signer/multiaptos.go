@@ -0,0 +1,193 @@
+package signer
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/Ja7ad/irys/errors"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	// MultiAptosMaxKeys is max number of public keys of multi aptos (multi-ed25519) signer
+	MultiAptosMaxKeys = 32
+
+	_multiAptosBitmapSize = 4
+	// _aptosMultiEd25519Scheme is authentication key scheme of multi-ed25519 aptos account
+	_aptosMultiEd25519Scheme = 0x01
+)
+
+// PartialSignature is ed25519 signature of participant, Index is position of its public key in signer
+type PartialSignature struct {
+	Index     int
+	Signature []byte
+}
+
+// SignatureCollector gather partial signatures of participants for message (e.g. send message to
+// approvers and wait on channel for their signatures).
+type SignatureCollector func(message []byte) ([]PartialSignature, error)
+
+// MultiAptosSigner is threshold signer (multi-ed25519), data item signed only when at least threshold of
+// participants signed message. Owner is 32 public key slots followed by threshold, signature is 32
+// signature slots followed by bitmap of signed slots.
+type MultiAptosSigner struct {
+	Owner   []byte
+	collect SignatureCollector
+}
+
+// NewMultiAptosSigner create threshold signer of public keys (up to 32), signatures collected by collect on Sign
+func NewMultiAptosSigner(publicKeys []ed25519.PublicKey, threshold int, collect SignatureCollector) (*MultiAptosSigner, error) {
+	if len(publicKeys) == 0 || len(publicKeys) > MultiAptosMaxKeys || threshold < 1 || threshold > len(publicKeys) {
+		return nil, errors.ErrInvalidThreshold
+	}
+
+	owner := make([]byte, (&MultiAptosSigner{}).GetOwnerLength())
+	for i, key := range publicKeys {
+		if len(key) != ed25519.PublicKeySize {
+			return nil, errors.ErrInvalidEd25519Key
+		}
+		copy(owner[i*ed25519.PublicKeySize:], key)
+	}
+	owner[len(owner)-1] = byte(threshold)
+
+	return &MultiAptosSigner{Owner: owner, collect: collect}, nil
+}
+
+func (self *MultiAptosSigner) Sign(data []byte) ([]byte, error) {
+	if self.collect == nil {
+		return nil, errors.ErrSignerNotSpecified
+	}
+
+	keys, threshold, err := parseMultiAptosOwner(self.Owner)
+	if err != nil {
+		return nil, err
+	}
+
+	partials, err := self.collect(data)
+	if err != nil {
+		return nil, err
+	}
+
+	signature := make([]byte, self.GetSignatureLength())
+	bitmap := signature[len(signature)-_multiAptosBitmapSize:]
+	signed := 0
+	for _, partial := range partials {
+		if partial.Index < 0 || partial.Index >= len(keys) {
+			return nil, fmt.Errorf("%w: participant %d", errors.ErrInvalidEd25519Key, partial.Index)
+		}
+		if !ed25519.Verify(keys[partial.Index], data, partial.Signature) {
+			return nil, fmt.Errorf("%w: participant %d", errors.ErrEd25519SignatureMismatch, partial.Index)
+		}
+		if bitmapSet(bitmap, partial.Index) {
+			continue
+		}
+
+		copy(signature[partial.Index*ed25519.SignatureSize:], partial.Signature)
+		setBitmap(bitmap, partial.Index)
+		signed++
+	}
+
+	if signed < threshold {
+		return nil, fmt.Errorf("%w: %d of %d", errors.ErrSignatureThresholdNotMet, signed, threshold)
+	}
+	return signature, nil
+}
+
+func (self *MultiAptosSigner) Verify(data []byte, signature []byte) error {
+	keys, threshold, err := parseMultiAptosOwner(self.Owner)
+	if err != nil {
+		return err
+	}
+	if len(signature) != self.GetSignatureLength() {
+		return errors.ErrNotEnoughBytesForSignature
+	}
+
+	bitmap := signature[len(signature)-_multiAptosBitmapSize:]
+	signed := 0
+	for i := 0; i < MultiAptosMaxKeys; i++ {
+		if !bitmapSet(bitmap, i) {
+			continue
+		}
+		if i >= len(keys) {
+			return errors.ErrEd25519SignatureMismatch
+		}
+		sig := signature[i*ed25519.SignatureSize : (i+1)*ed25519.SignatureSize]
+		if !ed25519.Verify(keys[i], data, sig) {
+			return errors.ErrEd25519SignatureMismatch
+		}
+		signed++
+	}
+
+	if signed < threshold {
+		return errors.ErrSignatureThresholdNotMet
+	}
+	return nil
+}
+
+func (self *MultiAptosSigner) GetOwner() ([]byte, error) {
+	if _, _, err := parseMultiAptosOwner(self.Owner); err != nil {
+		return nil, err
+	}
+	return self.Owner, nil
+}
+
+func (self *MultiAptosSigner) GetType() SignatureType {
+	return MultiAptos
+}
+
+func (self *MultiAptosSigner) GetSignatureLength() int {
+	return MultiAptosMaxKeys*ed25519.SignatureSize + _multiAptosBitmapSize
+}
+
+func (self *MultiAptosSigner) GetOwnerLength() int {
+	return MultiAptosMaxKeys*ed25519.PublicKeySize + 1
+}
+
+// MultiAptosAddress return 0x hex aptos account address of multi-ed25519 owner, sha3-256 of public keys,
+// threshold and scheme
+func MultiAptosAddress(owner []byte) (string, error) {
+	keys, threshold, err := parseMultiAptosOwner(owner)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha3.New256()
+	for _, key := range keys {
+		h.Write(key)
+	}
+	h.Write([]byte{byte(threshold), _aptosMultiEd25519Scheme})
+	return "0x" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// parseMultiAptosOwner return public keys (empty slots dropped) and threshold of owner
+func parseMultiAptosOwner(owner []byte) ([]ed25519.PublicKey, int, error) {
+	if len(owner) != (&MultiAptosSigner{}).GetOwnerLength() {
+		return nil, 0, errors.ErrInvalidEd25519Key
+	}
+
+	empty := make([]byte, ed25519.PublicKeySize)
+	keys := make([]ed25519.PublicKey, 0, MultiAptosMaxKeys)
+	for i := 0; i < MultiAptosMaxKeys; i++ {
+		key := owner[i*ed25519.PublicKeySize : (i+1)*ed25519.PublicKeySize]
+		if string(key) == string(empty) {
+			break
+		}
+		keys = append(keys, ed25519.PublicKey(key))
+	}
+
+	threshold := int(owner[len(owner)-1])
+	if len(keys) == 0 || threshold < 1 || threshold > len(keys) {
+		return nil, 0, errors.ErrInvalidThreshold
+	}
+	return keys, threshold, nil
+}
+
+// bitmapSet report bit of index is set, bits ordered from most significant bit of first byte as aptos
+func bitmapSet(bitmap []byte, index int) bool {
+	return bitmap[index/8]&(0x80>>(index%8)) != 0
+}
+
+func setBitmap(bitmap []byte, index int) {
+	bitmap[index/8] |= 0x80 >> (index % 8)
+}
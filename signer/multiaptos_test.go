@@ -0,0 +1,62 @@
+package signer
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiAptosSigner(t *testing.T) {
+	var (
+		publicKeys  []ed25519.PublicKey
+		privateKeys []ed25519.PrivateKey
+	)
+	for i := 0; i < 3; i++ {
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		publicKeys = append(publicKeys, pub)
+		privateKeys = append(privateKeys, priv)
+	}
+
+	approvers := []int{0, 2}
+	s, err := NewMultiAptosSigner(publicKeys, 2, func(message []byte) ([]PartialSignature, error) {
+		var partials []PartialSignature
+		for _, i := range approvers {
+			partials = append(partials, PartialSignature{Index: i, Signature: ed25519.Sign(privateKeys[i], message)})
+		}
+		return partials, nil
+	})
+	require.NoError(t, err)
+
+	data := []byte("irys data item")
+	signature, err := s.Sign(data)
+	require.NoError(t, err)
+	require.Len(t, signature, s.GetSignatureLength())
+	require.Equal(t, []byte{0xa0, 0, 0, 0}, signature[len(signature)-4:])
+
+	owner, err := s.GetOwner()
+	require.NoError(t, err)
+
+	sigType, err := TypeByOwner(owner)
+	require.NoError(t, err)
+	require.Equal(t, MultiAptos, sigType)
+
+	verifier, err := GetSigner(MultiAptos, owner)
+	require.NoError(t, err)
+	require.NoError(t, verifier.Verify(data, signature))
+	require.ErrorIs(t, verifier.Verify([]byte("other"), signature), errors.ErrEd25519SignatureMismatch)
+
+	address, err := OwnerAddress(MultiAptos, owner)
+	require.NoError(t, err)
+	require.Len(t, address, 66)
+
+	approvers = []int{1, 1}
+	_, err = s.Sign(data)
+	require.ErrorIs(t, err, errors.ErrSignatureThresholdNotMet)
+
+	_, err = NewMultiAptosSigner(publicKeys, 4, nil)
+	require.ErrorIs(t, err, errors.ErrInvalidThreshold)
+}
@@ -8,10 +8,19 @@ type SignatureType int
 // https://github.com/Bundlr-Network/arbundles/blob/5413fe576098355f7502a5fa9456f8db6a861492/src/constants.ts#L4
 const (
 	Arweave SignatureType = iota + 1
-	APTOS
+	ED25519
 	Ethereum
 	SOLANA
-	NEAR
+	InjectedAptos
+	MultiAptos
+	TypedEthereum
+)
+
+const (
+	// APTOS data items are signed with plain ed25519 key
+	APTOS = ED25519
+	// Deprecated: NEAR has no own signature type in arbundles, value 5 is InjectedAptos
+	NEAR = InjectedAptos
 )
 
 func (self SignatureType) Bytes() []byte {
@@ -19,10 +19,18 @@ func GetSigner(SignatureType SignatureType, owner []byte) (signer Signer, err er
 		signer = &ArweaveSigner{
 			Owner: owner,
 		}
+	case ED25519:
+		signer = &Ed25519Signer{
+			Owner: owner,
+		}
 	case Ethereum:
 		signer = &EthereumSigner{
 			Owner: owner,
 		}
+	case MultiAptos:
+		signer = &MultiAptosSigner{
+			Owner: owner,
+		}
 	default:
 		err = errors.ErrUnsupportedSignatureType
 	}
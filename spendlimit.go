@@ -0,0 +1,177 @@
+package irys
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+)
+
+// spendLedger track upload spend and funding of client per utc day against limits of WithSpendLimit, each of
+// them checked against daily limit by withinDayLocked. Funding tracked apart from upload spend since uploads
+// are paid by funded balance, so top up paying an upload not counted twice.
+type spendLedger struct {
+	perUpload *big.Int
+	perDay    *big.Int
+
+	mu     sync.Mutex
+	day    time.Time
+	spent  *big.Int
+	funded *big.Int
+	now    func() time.Time
+}
+
+func newSpendLedger(perUpload, perDay *big.Int) *spendLedger {
+	return &spendLedger{
+		perUpload: perUpload,
+		perDay:    perDay,
+		spent:     new(big.Int),
+		funded:    new(big.Int),
+		now:       time.Now,
+	}
+}
+
+// check return ErrSpendLimitExceeded when amount exceed per upload limit or remained daily limit
+func (l *spendLedger) check(amount *big.Int) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.checkLocked(amount)
+}
+
+// reserve record amount as spent of day when it is in limits, spend released by release when upload failed
+func (l *spendLedger) reserve(amount *big.Int) (time.Time, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.checkLocked(amount); err != nil {
+		return time.Time{}, err
+	}
+
+	l.spent.Add(l.spent, amount)
+	return l.day, nil
+}
+
+// reserveFunding record amount as funded of day when funding of day stay in daily limit, per upload limit
+// not applied because funding may pay many uploads. Funding released by releaseFunding when not sent.
+func (l *spendLedger) reserveFunding(amount *big.Int) (time.Time, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.rollLocked()
+	if err := l.withinDayLocked("funding", l.funded, amount); err != nil {
+		return time.Time{}, err
+	}
+
+	l.funded.Add(l.funded, amount)
+	return l.day, nil
+}
+
+func (l *spendLedger) releaseFunding(amount *big.Int, day time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.day.Equal(day) {
+		l.funded.Sub(l.funded, amount)
+	}
+}
+
+func (l *spendLedger) release(amount *big.Int, day time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// reservation of previous day already reset
+	if l.day.Equal(day) {
+		l.spent.Sub(l.spent, amount)
+	}
+}
+
+func (l *spendLedger) checkLocked(amount *big.Int) error {
+	l.rollLocked()
+
+	if l.perUpload != nil && amount.Cmp(l.perUpload) > 0 {
+		return fmt.Errorf("%w: amount %s, per upload limit %s", errors.ErrSpendLimitExceeded, amount, l.perUpload)
+	}
+
+	return l.withinDayLocked("upload", l.spent, amount)
+}
+
+// withinDayLocked return ErrSpendLimitExceeded when amount over used of day exceed daily limit, same rule
+// applied to upload spend and to funding
+func (l *spendLedger) withinDayLocked(kind string, used, amount *big.Int) error {
+	if l.perDay != nil && new(big.Int).Add(used, amount).Cmp(l.perDay) > 0 {
+		return fmt.Errorf("%w: %s %s, %s today %s, daily limit %s",
+			errors.ErrSpendLimitExceeded, kind, amount, kind, used, l.perDay)
+	}
+	return nil
+}
+
+// rollLocked reset spend when utc day changed
+func (l *spendLedger) rollLocked() {
+	now := l.now().UTC()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if !day.Equal(l.day) {
+		l.day = day
+		l.spent.SetInt64(0)
+		l.funded.SetInt64(0)
+	}
+}
+
+// reserveSpend reserve price of upload of size with tags in spend ledger (WithSpendLimit), returned release
+// give back reservation when upload failed. Free and dry run uploads are not spend.
+func (c *Client) reserveSpend(ctx context.Context, size int, tags []types.Tag) (func(), error) {
+	if c.spend == nil || c.dryRun || c.IsFreeUpload(c.itemSize(size, tags)) {
+		return func() {}, nil
+	}
+	return c.reservePrice(ctx, size)
+}
+
+// reserveL1Spend reserve upload of size posted to arweave as L1 transaction (WithArweaveL1), counted at node
+// price in currency of client as bundler upload but never free, fail closed when price not available.
+func (c *Client) reserveL1Spend(ctx context.Context, size int) (func(), error) {
+	if c.spend == nil || c.dryRun {
+		return func() {}, nil
+	}
+	return c.reservePrice(ctx, size)
+}
+
+func (c *Client) reservePrice(ctx context.Context, size int) (func(), error) {
+	price, err := c.GetPrice(ctx, size)
+	if err != nil {
+		return nil, err
+	}
+
+	day, err := c.spend.reserve(price)
+	if err != nil {
+		return nil, err
+	}
+
+	c.debugMsg("[SpendLimit] reserved %s for upload of %d bytes", price.String(), size)
+	return func() { c.spend.release(price, day) }, nil
+}
+
+// checkSpend return ErrSpendLimitExceeded when amount not fit limits of WithSpendLimit, without record spend
+func (c *Client) checkSpend(amount *big.Int) error {
+	if c.spend == nil {
+		return nil
+	}
+	return c.spend.check(amount)
+}
+
+// reserveFunding record funding amount in daily limit of WithSpendLimit, returned release give back
+// reservation when funding transaction not sent
+func (c *Client) reserveFunding(amount *big.Int) (func(), error) {
+	if c.spend == nil {
+		return func() {}, nil
+	}
+
+	day, err := c.spend.reserveFunding(amount)
+	if err != nil {
+		return nil, err
+	}
+	return func() { c.spend.releaseFunding(amount, day) }, nil
+}
@@ -0,0 +1,173 @@
+package irys
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ja7ad/irys/currency"
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpendLedger(t *testing.T) {
+	now := time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)
+	l := newSpendLedger(big.NewInt(100), big.NewInt(250))
+	l.now = func() time.Time { return now }
+
+	_, err := l.reserve(big.NewInt(101))
+	require.ErrorIs(t, err, errs.ErrSpendLimitExceeded)
+
+	day, err := l.reserve(big.NewInt(100))
+	require.NoError(t, err)
+	_, err = l.reserve(big.NewInt(100))
+	require.NoError(t, err)
+
+	require.ErrorIs(t, l.check(big.NewInt(51)), errs.ErrSpendLimitExceeded)
+	require.NoError(t, l.check(big.NewInt(50)))
+
+	l.release(big.NewInt(100), day)
+	require.NoError(t, l.check(big.NewInt(100)))
+
+	// spend reset on next utc day
+	now = now.Add(2 * time.Hour)
+	_, err = l.reserve(big.NewInt(100))
+	require.NoError(t, err)
+	_, err = l.reserve(big.NewInt(100))
+	require.NoError(t, err)
+
+	// release of previous day not change spend of today
+	l.release(big.NewInt(100), day)
+	require.ErrorIs(t, l.check(big.NewInt(51)), errs.ErrSpendLimitExceeded)
+}
+
+func TestFundingSpendLedger(t *testing.T) {
+	now := time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)
+	l := newSpendLedger(big.NewInt(100), big.NewInt(250))
+	l.now = func() time.Time { return now }
+
+	// per upload limit not applied on funding
+	day, err := l.reserveFunding(big.NewInt(200))
+	require.NoError(t, err)
+	_, err = l.reserveFunding(big.NewInt(100))
+	require.ErrorIs(t, err, errs.ErrSpendLimitExceeded)
+
+	// funding not counted in upload spend
+	require.NoError(t, l.check(big.NewInt(100)))
+
+	l.releaseFunding(big.NewInt(200), day)
+	_, err = l.reserveFunding(big.NewInt(250))
+	require.NoError(t, err)
+
+	// funding reset on next utc day
+	now = now.Add(2 * time.Hour)
+	_, err = l.reserveFunding(big.NewInt(250))
+	require.NoError(t, err)
+}
+
+func TestMixedSpendLedger(t *testing.T) {
+	l := newSpendLedger(nil, big.NewInt(250))
+	l.now = func() time.Time { return time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC) }
+
+	// top up of BasicUpload and upload paid by it counted once each
+	_, err := l.reserveFunding(big.NewInt(200))
+	require.NoError(t, err)
+	_, err = l.reserve(big.NewInt(200))
+	require.NoError(t, err)
+
+	// uploads and fundings each bounded by daily limit
+	_, err = l.reserve(big.NewInt(100))
+	require.ErrorIs(t, err, errs.ErrSpendLimitExceeded)
+	require.NoError(t, l.check(big.NewInt(50)))
+	_, err = l.reserveFunding(big.NewInt(100))
+	require.ErrorIs(t, err, errs.ErrSpendLimitExceeded)
+	_, err = l.reserveFunding(big.NewInt(50))
+	require.NoError(t, err)
+}
+
+func TestFundingSpendLimit(t *testing.T) {
+	aptos, err := currency.NewAptos("c5338cd251c22daa8c9c9cc94f498cc8a5c7e1d2e75287a5dda91096fe64efa5", "")
+	require.NoError(t, err)
+
+	var confirmed string
+	srv := balanceNode(t, aptos.GetName(), &confirmed)
+	defer srv.Close()
+
+	c, err := New(Node(srv.URL), aptos, false, WithContractAddress("bundler"), WithCustomRetryMax(0),
+		WithSpendLimit(nil, big.NewInt(1500)))
+	require.NoError(t, err)
+	defer c.Close()
+	c.(*Client).currency = transferStub{Currency: aptos, hash: "0xhash"}
+
+	// each funding fit daily limit, both together exceed it
+	require.NoError(t, c.TopUpBalance(context.Background(), big.NewInt(1000)))
+	require.Equal(t, "0xhash", confirmed)
+
+	confirmed = ""
+	err = c.TopUpBalance(context.Background(), big.NewInt(1000))
+	require.ErrorIs(t, err, errs.ErrSpendLimitExceeded)
+	require.Empty(t, confirmed)
+
+	require.NoError(t, c.TopUpBalance(context.Background(), big.NewInt(500)))
+}
+
+func TestUploadSpendLimit(t *testing.T) {
+	var posts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/price/"):
+			_, _ = fmt.Fprint(w, 100)
+		case r.URL.Path == "/account/balance/matic":
+			_, _ = fmt.Fprint(w, `{"balance":"0"}`)
+		case r.URL.Path == "/tx/matic":
+			posts++
+			_ = json.NewEncoder(w).Encode(types.Transaction{ID: fmt.Sprintf("tx%d", posts)})
+		}
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	c := &Client{
+		client:   retryablehttp.NewClient(),
+		network:  Node(srv.URL),
+		currency: matic,
+	}
+	WithSpendLimit(nil, big.NewInt(150))(c)
+
+	_, err = c.Upload(context.Background(), []byte("hello"))
+	require.NoError(t, err)
+
+	_, err = c.Upload(context.Background(), []byte("hello"))
+	require.ErrorIs(t, err, errs.ErrSpendLimitExceeded)
+
+	raw, err := c.SignDataItem([]byte("hello"))
+	require.NoError(t, err)
+	_, err = c.UploadSignedItem(context.Background(), raw)
+	require.ErrorIs(t, err, errs.ErrSpendLimitExceeded)
+	require.Equal(t, 1, posts)
+
+	// funding checked against daily limit before chain transaction
+	require.ErrorIs(t, c.TopUpBalance(context.Background(), big.NewInt(200)), errs.ErrSpendLimitExceeded)
+	_, err = c.FundToTarget(context.Background(), big.NewInt(200))
+	require.ErrorIs(t, err, errs.ErrSpendLimitExceeded)
+
+	// L1 post counted at node price
+	c.uploadStrategy = StrategyArweave
+	_, err = c.Upload(context.Background(), []byte("hello"))
+	require.ErrorIs(t, err, errs.ErrSpendLimitExceeded)
+}
@@ -0,0 +1,64 @@
+package irys
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/Ja7ad/irys/types"
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+type statsCtxKey struct{}
+
+type uploadTimer struct {
+	start   time.Time
+	signed  time.Time
+	retries int32
+}
+
+// startStats attach timer to ctx for count retries of upload requests, nil timer if stats disabled
+func (c *Client) startStats(ctx context.Context) (context.Context, *uploadTimer) {
+	if !c.uploadStats {
+		return ctx, nil
+	}
+	t := &uploadTimer{start: time.Now()}
+	return context.WithValue(ctx, statsCtxKey{}, t), t
+}
+
+func (t *uploadTimer) signDone() {
+	if t != nil {
+		t.signed = time.Now()
+	}
+}
+
+func (t *uploadTimer) finish(size int) *types.UploadStats {
+	if t == nil {
+		return nil
+	}
+
+	stats := &types.UploadStats{
+		Size:            size,
+		Duration:        time.Since(t.start),
+		SignDuration:    t.signed.Sub(t.start),
+		NetworkDuration: time.Since(t.signed),
+		Retries:         int(atomic.LoadInt32(&t.retries)),
+	}
+
+	if stats.NetworkDuration > 0 {
+		stats.BytesPerSecond = float64(size) / stats.NetworkDuration.Seconds()
+	}
+
+	return stats
+}
+
+// countRetries is retryablehttp request hook count retry attempts of requests with upload timer
+func countRetries(_ retryablehttp.Logger, req *http.Request, attempt int) {
+	if attempt == 0 {
+		return
+	}
+	if t, ok := req.Context().Value(statsCtxKey{}).(*uploadTimer); ok {
+		atomic.AddInt32(&t.retries, 1)
+	}
+}
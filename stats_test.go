@@ -0,0 +1,74 @@
+package irys
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Ja7ad/irys/currency"
+	"github.com/Ja7ad/irys/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadStats(t *testing.T) {
+	var (
+		posts int
+		size  int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		posts++
+		if posts == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		size = len(b)
+		time.Sleep(10 * time.Millisecond)
+		item := new(types.BundleItem)
+		require.NoError(t, item.Unmarshal(b))
+		_ = json.NewEncoder(w).Encode(types.Transaction{ID: item.Id.Base64()})
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	c := &Client{client: retryablehttp.NewClient(), network: Node(srv.URL), currency: matic}
+	c.client.RetryWaitMin = time.Millisecond
+	c.client.RetryWaitMax = time.Millisecond
+
+	tx, err := c.Upload(context.Background(), []byte("hello irys"))
+	require.NoError(t, err)
+	require.Nil(t, tx.Stats)
+
+	posts = 0
+	c.uploadStats = true
+	c.client.RequestLogHook = countRetries
+
+	tx, err = c.Upload(context.Background(), []byte("hello irys"))
+	require.NoError(t, err)
+	require.Equal(t, 2, posts)
+
+	stats := tx.Stats
+	require.NotNil(t, stats)
+	require.Equal(t, size, stats.Size)
+	// upload retried once after 5xx
+	require.Equal(t, 1, stats.Retries)
+	require.GreaterOrEqual(t, stats.NetworkDuration, 10*time.Millisecond)
+	require.Positive(t, stats.SignDuration)
+	require.GreaterOrEqual(t, stats.Duration, stats.NetworkDuration)
+	require.Positive(t, stats.BytesPerSecond)
+}
@@ -0,0 +1,114 @@
+package irys
+
+import (
+	"context"
+	"crypto/rand"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+)
+
+// _sniffSize is size of data head used for content type detection
+const _sniffSize = 512
+
+// UploadReader upload data of size from r without load it to memory, data read once for signing
+// (deep hash computed in chunks) and again for each attempt of request, so memory not depend on size.
+// Content type detected from first 512 bytes. Upload not journaled and not fallback to arweave L1.
+func (c *Client) UploadReader(ctx context.Context, r io.ReaderAt, size int64, tags ...types.Tag) (types.Transaction, error) {
+	ctx, cancel := withTimeout(ctx, c.timeouts.Upload)
+	defer cancel()
+
+	c.uploadStarted(ctx, int(size), tags)
+
+	release, err := c.reserveSpend(ctx, int(size), tags)
+	if err != nil {
+		c.uploadCompleted(ctx, "UploadReader", types.Transaction{}, err)
+		return types.Transaction{}, err
+	}
+
+	url := fmt.Sprintf(_uploadPath, c.network, c.currency.GetName())
+	tx, err := c.doStreamUpload(ctx, url, r, size, tags...)
+	if stderrors.Is(err, errors.ErrNotEnoughBalance) && c.autoFund() {
+		if err = c.fundShortfall(ctx, int(size)); err == nil {
+			tx, err = c.doStreamUpload(ctx, url, r, size, tags...)
+		}
+	}
+	if err == nil {
+		c.storeReceipt(ctx, tx)
+		c.indexUpload(ctx, tx, size)
+		c.recordUpload(ctx, tx, size)
+	} else {
+		release()
+	}
+	c.uploadCompleted(ctx, "UploadReader", tx, err)
+	return tx, err
+}
+
+func (c *Client) doStreamUpload(ctx context.Context, url string, r io.ReaderAt, size int64, tags ...types.Tag) (types.Transaction, error) {
+	ctx, timer := c.startStats(ctx)
+
+	item, err := c.signItemReader(r, size, false, tags...)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+	timer.signDone()
+
+	if c.dryRun {
+		return c.dryRunUpload(ctx, item, int(size))
+	}
+
+	body, err := newStreamItemBody(item, r, size)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+	defer body.close()
+
+	tx, err := c.postBody(ctx, url, item.Id.Base64(), body.reader, body.size)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+
+	tx.Stats = timer.finish(int(body.size))
+	return withItemTags(tx, item), nil
+}
+
+// signItemReader is signItem of data read from r with tags of client options
+func (c *Client) signItemReader(r io.ReaderAt, size int64, withAnchor bool, tags ...types.Tag) (*types.BundleItem, error) {
+	head := make([]byte, _sniffSize)
+	if size < _sniffSize {
+		head = head[:size]
+	}
+	if _, err := r.ReadAt(head, 0); err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	tags = c.addDelegationTags(c.addCorrelationTag(c.injectTags(tags...)...)...)
+	tags, err := c.addIPFSCIDTagReader(io.NewSectionReader(r, 0, size), tags...)
+	if err != nil {
+		return nil, err
+	}
+	tags, err = c.addFileHashTagReader(io.NewSectionReader(r, 0, size), tags...)
+	if err != nil {
+		return nil, err
+	}
+	if c.autoContentType {
+		tags = addContentType(sniffContentType(head), tags...)
+	}
+	tags = addContentType(http.DetectContentType(head), tags...)
+
+	item := &types.BundleItem{Tags: tags}
+	if withAnchor {
+		item.Anchor = make([]byte, 32)
+		if _, err := rand.Read(item.Anchor); err != nil {
+			return nil, err
+		}
+	}
+	if err := item.SignReader(c.uploadSigner(), io.NewSectionReader(r, 0, size), size); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
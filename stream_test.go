@@ -0,0 +1,65 @@
+package irys
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Ja7ad/irys/currency"
+	"github.com/Ja7ad/irys/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+// readerAtOnly hide Read and Seek of reader, so data only read at offsets
+type readerAtOnly struct {
+	r io.ReaderAt
+}
+
+func (r readerAtOnly) ReadAt(p []byte, off int64) (int, error) {
+	return r.r.ReadAt(p, off)
+}
+
+func TestUploadReader(t *testing.T) {
+	// bigger than chunk of deep hash
+	data := bytes.Repeat([]byte("irys"), 200*1024)
+
+	var posted *types.BundleItem
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, int64(len(b)), r.ContentLength)
+
+		posted = new(types.BundleItem)
+		require.NoError(t, posted.Unmarshal(b))
+		_ = json.NewEncoder(w).Encode(types.Transaction{ID: posted.Id.Base64()})
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	c := &Client{client: retryablehttp.NewClient(), network: Node(srv.URL), currency: matic}
+
+	tx, err := c.UploadReader(context.Background(), readerAtOnly{bytes.NewReader(data)}, int64(len(data)),
+		types.Tag{Name: "App-Name", Value: "irys"})
+	require.NoError(t, err)
+	require.NotNil(t, posted)
+	require.Equal(t, data, []byte(posted.Data))
+	require.NoError(t, posted.VerifySignature())
+
+	// ethereum signature is deterministic, so item signed in memory has same id
+	item, err := signItem(data, matic.GetSinger(), false, posted.Tags...)
+	require.NoError(t, err)
+	require.Equal(t, item.Id.Base64(), tx.ID)
+	require.Equal(t, item.Id, posted.Id)
+}
@@ -0,0 +1,91 @@
+package irys
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/Ja7ad/irys/currency"
+	"github.com/Ja7ad/irys/currency/simulated"
+	"github.com/Ja7ad/irys/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+// TestClientConcurrentUse run with -race to check client is safe for concurrent use
+func TestClientConcurrentUse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/account/balance/matic":
+			_ = json.NewEncoder(w).Encode(types.BalanceResponse{Balance: "1000"})
+		case r.Method == http.MethodPost && r.URL.Path == "/tx/matic":
+			_ = json.NewEncoder(w).Encode(types.Transaction{ID: "tx"})
+		}
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	backend := simulated.New(new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18)), key)
+	defer backend.Close()
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), backend)
+	require.NoError(t, err)
+
+	c := &Client{
+		mu:            new(sync.Mutex),
+		client:        retryablehttp.NewClient(),
+		network:       Node(srv.URL),
+		currency:      matic,
+		contract:      "0x853758425e953739F5438fd6fd0Efe04A477b039",
+		unspentCredit: new(big.Int),
+	}
+
+	const workers = 10
+
+	ctx := context.Background()
+	errCh := make(chan error, 3*workers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			_, err := c.Upload(ctx, []byte("hello irys"))
+			errCh <- err
+		}()
+		go func() {
+			defer wg.Done()
+			errCh <- c.TopUpBalance(ctx, big.NewInt(1000))
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := c.AccountSummary(ctx)
+			errCh <- err
+			c.addUnspentCredit(big.NewInt(1))
+		}()
+	}
+	wg.Wait()
+	close(errCh)
+
+	for err := range errCh {
+		require.NoError(t, err)
+	}
+
+	summary, err := c.AccountSummary(ctx)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(workers), summary.UnspentCredit)
+
+	// each top up must get own nonce, so all of them are mined
+	backend.Commit()
+	nonce, err := backend.PendingNonceAt(ctx, crypto.PubkeyToAddress(key.PublicKey))
+	require.NoError(t, err)
+	require.Equal(t, uint64(workers), nonce)
+}
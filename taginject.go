@@ -0,0 +1,30 @@
+package irys
+
+import (
+	"github.com/Ja7ad/irys/types"
+)
+
+// TagInjector return tags of upload with tags enforced by organization (e.g. App-Name, Unix-Time) added to
+// existing, tags already injected may be passed again so injector must not duplicate them.
+type TagInjector func(existing []types.Tag) []types.Tag
+
+// injectTags add default tags not set by caller and run tag injectors in order of registration
+func (c *Client) injectTags(tags ...types.Tag) []types.Tag {
+	if len(c.defaultTags) == 0 && len(c.tagInjectors) == 0 {
+		return tags
+	}
+
+	// copy so caller tags slice not modified by append
+	injected := append([]types.Tag(nil), tags...)
+	for _, tag := range c.defaultTags {
+		if !hasTag(injected, tag.Name) {
+			injected = append(injected, tag)
+		}
+	}
+
+	for _, inject := range c.tagInjectors {
+		injected = inject(injected)
+	}
+
+	return injected
+}
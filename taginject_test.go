@@ -0,0 +1,55 @@
+package irys
+
+import (
+	"testing"
+
+	"github.com/Ja7ad/irys/tags"
+	"github.com/Ja7ad/irys/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInjectTags(t *testing.T) {
+	c := &Client{}
+	require.Empty(t, c.injectTags())
+
+	WithDefaultTags(tags.App("app", "1.0.0")...)(c)
+	WithTagInjector(func(existing []types.Tag) []types.Tag {
+		if _, ok := tags.Get(existing, tags.UnixTime); ok {
+			return existing
+		}
+		return append(existing, tags.New(tags.UnixTime, "1700000000"))
+	})(c)
+
+	userTags := []types.Tag{tags.New(tags.AppVersion, "2.0.0")}
+	injected := c.injectTags(userTags...)
+	require.Equal(t, []types.Tag{
+		{Name: tags.AppVersion, Value: "2.0.0"},
+		{Name: tags.AppName, Value: "app"},
+		{Name: tags.UnixTime, Value: "1700000000"},
+	}, injected)
+	require.Len(t, userTags, 1)
+
+	// injected again (e.g. tags resolved before upload) not duplicated
+	require.Equal(t, injected, c.injectTags(injected...))
+}
+
+func TestAddCorrelationTag(t *testing.T) {
+	c := &Client{}
+	userTags := make([]types.Tag, 1, 2)
+	userTags[0] = tags.New(tags.AppName, "app")
+	require.Equal(t, userTags, c.addCorrelationTag(userTags...))
+
+	WithCorrelationID("Request-Id", func() string { return "id-1" })(c)
+	tagged := c.addCorrelationTag(userTags...)
+	require.Equal(t, []types.Tag{
+		{Name: tags.AppName, Value: "app"},
+		{Name: "Request-Id", Value: "id-1"},
+	}, tagged)
+
+	// spare capacity of caller slice not written
+	require.Empty(t, userTags[:2][1])
+
+	// tag set by caller kept
+	own := []types.Tag{{Name: "Request-Id", Value: "own"}}
+	require.Equal(t, own, c.addCorrelationTag(own...))
+}
@@ -0,0 +1,57 @@
+// Package tags define common tag names of irys and arweave ecosystem and helpers to build them
+package tags
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/Ja7ad/irys/types"
+)
+
+const (
+	AppName     = "App-Name"     // AppName is name of application uploaded data
+	AppVersion  = "App-Version"  // AppVersion is version of application uploaded data
+	ContentType = "Content-Type" // ContentType is mime type of data, used by gateways for serve data
+	UnixTime    = "Unix-Time"    // UnixTime is upload time in seconds since epoch
+	RootTX      = "Root-TX"      // RootTX link new version of mutable reference to its root transaction
+	Type        = "Type"         // Type is kind of data (e.g. manifest)
+)
+
+// New create tag with name and value
+func New(name, value string) types.Tag {
+	return types.Tag{Name: name, Value: value}
+}
+
+// App return App-Name and App-Version tags, App-Version omitted when version is empty
+func App(name, version string) []types.Tag {
+	tags := []types.Tag{New(AppName, name)}
+	if len(version) != 0 {
+		tags = append(tags, New(AppVersion, version))
+	}
+	return tags
+}
+
+// WithContentType return Content-Type tag
+func WithContentType(contentType string) types.Tag {
+	return New(ContentType, contentType)
+}
+
+// WithUnixTime return Unix-Time tag of t in seconds
+func WithUnixTime(t time.Time) types.Tag {
+	return New(UnixTime, strconv.FormatInt(t.Unix(), 10))
+}
+
+// WithRootTX return Root-TX tag link transaction to root transaction of mutable reference
+func WithRootTX(txId string) types.Tag {
+	return New(RootTX, txId)
+}
+
+// Get return value of first tag with name
+func Get(tags []types.Tag, name string) (string, bool) {
+	for _, tag := range tags {
+		if tag.Name == name {
+			return tag.Value, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,34 @@
+package tags
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Ja7ad/irys/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTags(t *testing.T) {
+	list := append(App("my-app", "1.0.0"),
+		WithContentType("image/png"),
+		WithUnixTime(time.Unix(1700000000, 0)),
+		WithRootTX("root"),
+	)
+
+	require.Equal(t, []types.Tag{
+		{Name: "App-Name", Value: "my-app"},
+		{Name: "App-Version", Value: "1.0.0"},
+		{Name: "Content-Type", Value: "image/png"},
+		{Name: "Unix-Time", Value: "1700000000"},
+		{Name: "Root-TX", Value: "root"},
+	}, list)
+
+	v, ok := Get(list, RootTX)
+	require.True(t, ok)
+	require.Equal(t, "root", v)
+
+	require.Len(t, App("my-app", ""), 1)
+
+	_, ok = Get(list, Type)
+	require.False(t, ok)
+}
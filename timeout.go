@@ -0,0 +1,40 @@
+package irys
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// OperationTimeouts default timeout of operations (WithOperationTimeouts), applied only when context of
+// call has no deadline. Zero timeout disable default of operation.
+type OperationTimeouts struct {
+	Upload   time.Duration // Upload is timeout of uploads (Upload, ChunkUpload, UploadReader, UploadSignedItem)
+	Download time.Duration // Download is timeout of Download include read of file data
+	Price    time.Duration // Price is timeout of GetPrice
+	Balance  time.Duration // Balance is timeout of GetBalance and GetBalanceOf
+	Query    time.Duration // Query is timeout of transaction metadata, receipt, status, Exists, GetApprovals, manifest, tag lookup and search page queries
+	Fund     time.Duration // Fund is timeout of TopUpBalance
+}
+
+// withTimeout bound ctx by timeout when ctx has no deadline
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// cancelOnClose cancel context of download when data closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (r *cancelOnClose) Close() error {
+	defer r.cancel()
+	return r.ReadCloser.Close()
+}
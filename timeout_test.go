@@ -0,0 +1,146 @@
+package irys
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ja7ad/irys/currency"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithTimeout(t *testing.T) {
+	ctx, cancel := withTimeout(context.Background(), 0)
+	defer cancel()
+	_, ok := ctx.Deadline()
+	require.False(t, ok)
+
+	ctx, cancel = withTimeout(context.Background(), time.Minute)
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	require.True(t, ok)
+	require.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+
+	// deadline of caller not changed
+	parent, cancelParent := context.WithTimeout(context.Background(), time.Hour)
+	defer cancelParent()
+	ctx, cancel = withTimeout(parent, time.Minute)
+	defer cancel()
+	deadline, ok = ctx.Deadline()
+	require.True(t, ok)
+	require.WithinDuration(t, time.Now().Add(time.Hour), deadline, time.Second)
+}
+
+func TestOperationTimeouts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/price/") {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+			return
+		}
+		_, _ = io.WriteString(w, "data")
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	c := &Client{
+		client:   retryablehttp.NewClient(),
+		network:  Node(srv.URL),
+		gateway:  srv.URL,
+		currency: matic,
+		timeouts: OperationTimeouts{Price: 50 * time.Millisecond, Download: time.Minute},
+	}
+
+	start := time.Now()
+	_, err = c.GetPrice(context.Background(), 1024)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), 5*time.Second)
+
+	file, err := c.Download(context.Background(), "tx")
+	require.NoError(t, err)
+	b, err := io.ReadAll(file.Data)
+	require.NoError(t, err)
+	require.Equal(t, "data", string(b))
+	require.NoError(t, file.Data.Close())
+}
+
+func TestOperationTimeoutsOverrideClientTimeout(t *testing.T) {
+	defaultTimeout := _defaultHTTPTimeout
+	_defaultHTTPTimeout = 50 * time.Millisecond
+	defer func() { _defaultHTTPTimeout = defaultTimeout }()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		_, _ = io.WriteString(w, "1000")
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	// without operation timeouts default client timeout apply
+	c, err := New(Node(srv.URL), matic, false, WithContractAddress("0x1"), WithCustomRetryMax(0))
+	require.NoError(t, err)
+	_, err = c.GetPrice(context.Background(), 1024)
+	require.Error(t, err)
+	c.Close()
+
+	// operation timeout longer than default client timeout
+	c, err = New(Node(srv.URL), matic, false, WithContractAddress("0x1"), WithCustomRetryMax(0),
+		WithOperationTimeouts(OperationTimeouts{Price: 5 * time.Second}))
+	require.NoError(t, err)
+	defer c.Close()
+	price, err := c.GetPrice(context.Background(), 1024)
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), price.Int64())
+}
+
+func TestQueryTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	c := &Client{
+		client:   retryablehttp.NewClient(),
+		network:  Node(srv.URL),
+		gateway:  srv.URL,
+		currency: matic,
+		timeouts: OperationTimeouts{Query: 50 * time.Millisecond},
+	}
+
+	start := time.Now()
+	_, err = c.Exists(context.Background(), "tx")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	_, err = c.GetApprovals(context.Background())
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	_, err = c.DownloadByCID(context.Background(), "cid")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), 5*time.Second)
+}
@@ -0,0 +1,95 @@
+package irys
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/Ja7ad/irys/currency"
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+	"github.com/Ja7ad/irys/utils/amount"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TopUpStandard top up balance by amount in standard unit of currency (e.g. "0.5" matic)
+func (c *Client) TopUpStandard(ctx context.Context, value string) error {
+	atomic, err := amount.FromDecimals(value, c.decimals(ctx))
+	if err != nil {
+		return err
+	}
+
+	if atomic.Sign() <= 0 {
+		return errors.ErrInvalidAmount
+	}
+
+	return c.TopUpBalance(ctx, atomic)
+}
+
+// EstimateTopUp estimate cost of TopUpBalance of amount, amount plus chain fee of funding transaction
+func (c *Client) EstimateTopUp(ctx context.Context, amount *big.Int) (types.TopUpEstimate, error) {
+	if amount == nil || amount.Sign() <= 0 {
+		return types.TopUpEstimate{}, errors.ErrInvalidAmount
+	}
+
+	estimate := types.TopUpEstimate{Amount: new(big.Int).Set(amount)}
+
+	switch c.currency.GetType() {
+	case currency.ETHEREUM, currency.MATIC, currency.AVALANCHE, currency.FANTOM, currency.BNB, currency.ARBITRUM, currency.BASE:
+		to := common.HexToAddress(c.contract)
+		gasPrice, gasLimit, err := estimateGas(ctx, c, to, transferData(to, amount))
+		if err != nil {
+			return types.TopUpEstimate{}, err
+		}
+		estimate.GasPrice, estimate.GasLimit = gasPrice, gasLimit
+		estimate.Fee = new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gasLimit))
+		estimate.Total = new(big.Int).Add(amount, estimate.Fee)
+	case currency.ERC20:
+		token, ok := c.currency.(currency.Token)
+		if !ok {
+			return types.TopUpEstimate{}, errors.ErrTokenNotSupported
+		}
+		gasPrice, gasLimit, err := estimateGas(ctx, c, common.HexToAddress(token.GetContractAddress()),
+			transferData(common.HexToAddress(c.contract), amount))
+		if err != nil {
+			return types.TopUpEstimate{}, err
+		}
+		estimate.GasPrice, estimate.GasLimit = gasPrice, gasLimit
+		estimate.Fee = new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gasLimit))
+	case currency.APTOS, currency.COSMOS:
+		estimator, ok := c.currency.(currency.FeeEstimator)
+		if !ok {
+			return types.TopUpEstimate{}, errors.ErrTokenNotSupported
+		}
+		fee, err := estimator.EstimateTransferFee(ctx)
+		if err != nil {
+			return types.TopUpEstimate{}, err
+		}
+		estimate.Fee = fee
+		estimate.Total = new(big.Int).Add(amount, fee)
+	default:
+		return types.TopUpEstimate{}, errors.ErrTokenNotSupported
+	}
+
+	return estimate, nil
+}
+
+// FundToTarget top up only difference of balance and target, returned funded amount is zero when
+// balance already reached target.
+func (c *Client) FundToTarget(ctx context.Context, target *big.Int) (*big.Int, error) {
+	balance, err := c.GetBalance(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	delta := new(big.Int).Sub(target, balance)
+	if delta.Sign() <= 0 {
+		c.debugMsg("[FundToTarget] balance %s already reached target %s", balance, target)
+		return new(big.Int), nil
+	}
+
+	if err := c.TopUpBalance(ctx, delta); err != nil {
+		return nil, err
+	}
+
+	return delta, nil
+}
@@ -0,0 +1,42 @@
+package irys
+
+import "net/http"
+
+// transportTuned report any connection pool or protocol option set
+func (c *Client) transportTuned() bool {
+	return c.maxIdleConnsPerHost > 0 || c.maxConnsPerHost > 0 ||
+		c.idleConnTimeout > 0 || c.expectContinueTimeout != 0 || c.forceHTTP2
+}
+
+// tuneTransport apply connection pool and protocol options on http transport
+func (c *Client) tuneTransport(tr *http.Transport) {
+	if c.maxIdleConnsPerHost > 0 {
+		tr.MaxIdleConnsPerHost = c.maxIdleConnsPerHost
+		if tr.MaxIdleConns > 0 && tr.MaxIdleConns < c.maxIdleConnsPerHost {
+			tr.MaxIdleConns = c.maxIdleConnsPerHost
+		}
+	}
+
+	if c.maxConnsPerHost > 0 {
+		tr.MaxConnsPerHost = c.maxConnsPerHost
+	}
+
+	if c.idleConnTimeout > 0 {
+		tr.IdleConnTimeout = c.idleConnTimeout
+	}
+
+	// negative timeout send body immediately without wait for 100-continue
+	if c.expectContinueTimeout > 0 {
+		tr.ExpectContinueTimeout = c.expectContinueTimeout
+	} else if c.expectContinueTimeout < 0 {
+		tr.ExpectContinueTimeout = 0
+	}
+
+	// custom dialer or tls config disable http/2 unless forced
+	if c.forceHTTP2 {
+		tr.ForceAttemptHTTP2 = true
+	}
+
+	c.debugMsg("tune transport max idle conns per host %d, max conns per host %d, http2 %t",
+		tr.MaxIdleConnsPerHost, tr.MaxConnsPerHost, tr.ForceAttemptHTTP2)
+}
@@ -0,0 +1,18 @@
+package irys
+
+import (
+	"github.com/Ja7ad/irys/signer"
+	"github.com/Ja7ad/irys/types"
+)
+
+// ComputeTxID return transaction id of data before upload, id is same as Upload with same data and tags.
+//
+// Note: id is deterministic only for signers with deterministic signature (e.g. ethereum),
+// arweave signature use random salt and id changes per signing.
+func ComputeTxID(data []byte, s signer.Signer, tags ...types.Tag) (string, error) {
+	item, err := signItem(data, s, false, tags...)
+	if err != nil {
+		return "", err
+	}
+	return item.Id.Base64(), nil
+}
@@ -0,0 +1,29 @@
+package irys
+
+import (
+	"testing"
+
+	"github.com/Ja7ad/irys/signer"
+	"github.com/Ja7ad/irys/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeTxID(t *testing.T) {
+	s, err := signer.NewEthereumSigner("0xf4a2b939592564feb35ab10a8e04f6f2fe0943579fb3c9c33505298978b74893")
+	require.NoError(t, err)
+
+	data := []byte("irys deterministic id")
+	tags := []types.Tag{{Name: "App-Name", Value: "irys-go"}}
+
+	id, err := ComputeTxID(data, s, tags...)
+	require.NoError(t, err)
+	require.NotEmpty(t, id)
+
+	again, err := ComputeTxID(data, s, tags...)
+	require.NoError(t, err)
+	require.Equal(t, id, again)
+
+	other, err := ComputeTxID([]byte("other data"), s, tags...)
+	require.NoError(t, err)
+	require.NotEqual(t, id, other)
+}
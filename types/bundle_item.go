@@ -159,7 +159,7 @@ func (self *BundleItem) UnmarshalJSON(data []byte) error {
 	return json.Unmarshal(data, aux)
 }
 
-func (self *BundleItem) sign(signer signer.Signer) (id, signature []byte, err error) {
+func (self *BundleItem) sign(signer signer.Signer, data any) (id, signature []byte, err error) {
 	// Tags
 	err = self.ensureTagsSerialized()
 	if err != nil {
@@ -174,10 +174,13 @@ func (self *BundleItem) sign(signer signer.Signer) (id, signature []byte, err er
 		self.Target,
 		self.Anchor,
 		self.tagsBytes,
-		self.Data,
+		data,
 	}
 
-	deepHash := DeepHash(values)
+	deepHash, err := DeepHashStream(values)
+	if err != nil {
+		return
+	}
 
 	// Compute the signature
 	signature, err = signer.Sign(deepHash[:])
@@ -217,7 +220,28 @@ func (self *BundleItem) Sign(signer signer.Signer) (err error) {
 	}
 
 	// Signs bundle item
-	self.Id, self.Signature, err = self.sign(signer)
+	self.Id, self.Signature, err = self.sign(signer, self.Data)
+	return
+}
+
+// SignReader sign item with data of size read from r instead of Data, data hashed in chunks so memory
+// of signing not depend on size. Data of item left empty, serialize it with EncodeHeader followed by data.
+func (self *BundleItem) SignReader(signer signer.Signer, r io.Reader, size int64) (err error) {
+	if signer == nil {
+		err = errors.ErrSignerNotSpecified
+		return
+	}
+	if len(self.Owner) != 0 || len(self.Signature) != 0 || len(self.Id) != 0 {
+		// Already signed
+		return
+	}
+	self.SignatureType = signer.GetType()
+	self.Owner, err = signer.GetOwner()
+	if err != nil {
+		return err
+	}
+
+	self.Id, self.Signature, err = self.sign(signer, StreamBlob{Reader: r, Size: size})
 	return
 }
 
@@ -226,6 +250,17 @@ func (self *BundleItem) IsSigned() bool {
 }
 
 func (self *BundleItem) Encode(out io.Writer) (err error) {
+	err = self.EncodeHeader(out)
+	if err != nil {
+		return
+	}
+	_, err = out.Write(self.Data)
+	return
+}
+
+// EncodeHeader write serialized item without data, header followed by data is same as Encode,
+// so large data streamed without copy to buffer.
+func (self *BundleItem) EncodeHeader(out io.Writer) (err error) {
 	if !self.IsSigned() {
 		err = errors.ErrNotSigned
 		return
@@ -289,14 +324,6 @@ func (self *BundleItem) Encode(out io.Writer) (err error) {
 		return
 	}
 	_, err = out.Write(self.tagsBytes)
-	if err != nil {
-		return
-	}
-	_, err = out.Write(self.Data)
-	if err != nil {
-		return
-	}
-
 	return
 }
 
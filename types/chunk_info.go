@@ -0,0 +1,80 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// ChunkInfoResponse is chunks received by node for chunked upload, decoded from v2 schema
+// {"size": n, "chunks": [["offset", size], ...]} and from plain offsets {"total": n, "chunks": [offset, ...]}
+type ChunkInfoResponse struct {
+	Chunks []int       `json:"chunks"` // Chunks is offsets of received chunks
+	Sizes  map[int]int `json:"-"`      // Sizes is size of received chunk by offset, empty when node report offsets only
+	Total  int         `json:"total"`  // Total is size reported by node, zero when not reported
+}
+
+func (self *ChunkInfoResponse) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Size   json.RawMessage   `json:"size"`
+		Total  json.RawMessage   `json:"total"`
+		Chunks []json.RawMessage `json:"chunks"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var info ChunkInfoResponse
+	for _, total := range []json.RawMessage{raw.Size, raw.Total} {
+		if len(total) == 0 || string(total) == "null" {
+			continue
+		}
+		n, err := chunkNumber(total)
+		if err != nil {
+			return fmt.Errorf("chunk info total: %w", err)
+		}
+		info.Total = n
+		break
+	}
+
+	for _, chunk := range raw.Chunks {
+		var pair []json.RawMessage
+		if err := json.Unmarshal(chunk, &pair); err != nil {
+			offset, err := chunkNumber(chunk)
+			if err != nil {
+				return fmt.Errorf("chunk info offset: %w", err)
+			}
+			info.Chunks = append(info.Chunks, offset)
+			continue
+		}
+
+		if len(pair) != 2 {
+			return fmt.Errorf("chunk info entry %s is not [offset, size]", chunk)
+		}
+		offset, err := chunkNumber(pair[0])
+		if err != nil {
+			return fmt.Errorf("chunk info offset: %w", err)
+		}
+		size, err := chunkNumber(pair[1])
+		if err != nil {
+			return fmt.Errorf("chunk info size: %w", err)
+		}
+		if info.Sizes == nil {
+			info.Sizes = make(map[int]int, len(raw.Chunks))
+		}
+		info.Chunks = append(info.Chunks, offset)
+		info.Sizes[offset] = size
+	}
+
+	*self = info
+	return nil
+}
+
+// chunkNumber decode number of chunk info sent as number or numeric string
+func chunkNumber(data json.RawMessage) (int, error) {
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(n.String())
+}
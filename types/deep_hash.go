@@ -3,12 +3,39 @@ package types
 import (
 	"crypto/sha512"
 	"fmt"
+	"io"
+
+	"github.com/Ja7ad/irys/errors"
 )
 
+const _deepHashChunkSize = 256 * 1024
+
+// StreamBlob is deep hash blob of Size bytes read from Reader in chunks, so large data hashed in constant memory
+type StreamBlob struct {
+	Reader io.Reader
+	Size   int64
+}
+
+// DeepHash compute deep hash of in-memory values, it panic on StreamBlob or value of unsupported type,
+// use DeepHashStream to get them as error.
 func DeepHash(data []any) [48]byte {
+	hash, err := deepHash(data, false)
+	if err != nil {
+		panic(err)
+	}
+	return hash
+}
+
+// DeepHashStream compute deep hash of values which may contain StreamBlob, error of reading blob or
+// unsupported value returned
+func DeepHashStream(data []any) ([48]byte, error) {
+	return deepHash(data, true)
+}
+
+func deepHash(data []any, stream bool) ([48]byte, error) {
 	tag := append([]byte("list"), []byte(fmt.Sprintf("%d", len(data)))...)
 	tagHash := sha512.Sum384(tag)
-	return deepHashAcc(data, tagHash)
+	return deepHashAcc(data, tagHash, stream)
 }
 
 func deepHashBytes(x []byte) [48]byte {
@@ -19,6 +46,26 @@ func deepHashBytes(x []byte) [48]byte {
 	return sha512.Sum384(tagged)
 }
 
+// deepHashStream hash blob of reader in chunks of _deepHashChunkSize
+func deepHashStream(blob StreamBlob) ([48]byte, error) {
+	tag := append([]byte("blob"), []byte(fmt.Sprintf("%d", blob.Size))...)
+	tagHash := sha512.Sum384(tag)
+
+	h := sha512.New384()
+	n, err := io.CopyBuffer(h, io.LimitReader(blob.Reader, blob.Size), make([]byte, _deepHashChunkSize))
+	if err != nil {
+		return [48]byte{}, err
+	}
+	if n != blob.Size {
+		return [48]byte{}, fmt.Errorf("%w: read %d of %d bytes", errors.ErrStreamSizeMismatch, n, blob.Size)
+	}
+
+	var blobHash [48]byte
+	h.Sum(blobHash[:0])
+	tagged := append(tagHash[:], blobHash[:]...)
+	return sha512.Sum384(tagged), nil
+}
+
 func convertToSliceOfAny[T string | []byte | Base64String](in []T) (out []any) {
 	out = make([]any, len(in))
 	for i, v := range in {
@@ -27,38 +74,44 @@ func convertToSliceOfAny[T string | []byte | Base64String](in []T) (out []any) {
 	return
 }
 
-func deepHashAcc(data []interface{}, acc [48]byte) [48]byte {
-	if len(data) < 1 {
-		return acc
-	}
+func deepHashAcc(data []interface{}, acc [48]byte, stream bool) ([48]byte, error) {
+	for _, d := range data {
+		dHash := [48]byte{}
+		var err error
 
-	dHash := [48]byte{}
-	d := data[0]
+		switch x := d.(type) {
+		case []byte:
+			dHash = deepHashBytes(x)
+		case string:
+			dHash = deepHashBytes([]byte(x))
+		case BigInt:
+			dHash = deepHashBytes([]byte(x.String()))
+		case Base64String:
+			dHash = deepHashBytes([]byte(x))
+		case RewardAddr:
+			dHash = deepHashBytes([]byte(x))
+		case StreamBlob:
+			if !stream {
+				return [48]byte{}, errors.ErrStreamNotSupported
+			}
+			dHash, err = deepHashStream(x)
+		case []Base64String:
+			dHash, err = deepHash(convertToSliceOfAny(x), stream)
+		case []string:
+			dHash, err = deepHash(convertToSliceOfAny(x), stream)
+		case [][]byte:
+			dHash, err = deepHash(convertToSliceOfAny(x), stream)
+		case []interface{}:
+			dHash, err = deepHash(x, stream)
+		default:
+			return [48]byte{}, fmt.Errorf("%w: %T", errors.ErrUnsupportedDeepHashType, d)
+		}
+		if err != nil {
+			return [48]byte{}, err
+		}
 
-	switch x := d.(type) {
-	case []byte:
-		dHash = deepHashBytes(x)
-	case string:
-		dHash = deepHashBytes([]byte(x))
-	case BigInt:
-		dHash = deepHashBytes([]byte(x.String()))
-	case Base64String:
-		dHash = deepHashBytes([]byte(x))
-	case RewardAddr:
-		dHash = deepHashBytes([]byte(x))
-	case []Base64String:
-		dHash = DeepHash(convertToSliceOfAny(x))
-	case []string:
-		dHash = DeepHash(convertToSliceOfAny(x))
-	case [][]byte:
-		dHash = DeepHash(convertToSliceOfAny(x))
-	case []interface{}:
-		dHash = DeepHash(x)
-	default:
-		panic("unsupported deep hash type")
+		hashPair := append(acc[:], dHash[:]...)
+		acc = sha512.Sum384(hashPair)
 	}
-
-	hashPair := append(acc[:], dHash[:]...)
-	newAcc := sha512.Sum384(hashPair)
-	return deepHashAcc(data[1:], newAcc)
+	return acc, nil
 }
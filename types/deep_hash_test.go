@@ -0,0 +1,29 @@
+package types
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeepHashStream(t *testing.T) {
+	data := bytes.Repeat([]byte("irys"), _deepHashChunkSize)
+
+	hash, err := DeepHashStream([]any{"dataitem", []byte("tags"), StreamBlob{Reader: bytes.NewReader(data), Size: int64(len(data))}})
+	require.NoError(t, err)
+	require.Equal(t, DeepHash([]any{"dataitem", []byte("tags"), data}), hash)
+
+	_, err = DeepHashStream([]any{StreamBlob{Reader: strings.NewReader("short"), Size: 10}})
+	require.ErrorIs(t, err, errors.ErrStreamSizeMismatch)
+
+	_, err = DeepHashStream([]any{"dataitem", []any{int64(1)}})
+	require.ErrorIs(t, err, errors.ErrUnsupportedDeepHashType)
+
+	// reader not read by DeepHash
+	require.PanicsWithError(t, errors.ErrStreamNotSupported.Error(), func() {
+		DeepHash([]any{"dataitem", []any{StreamBlob{Reader: strings.NewReader("blob"), Size: 4}}})
+	})
+}
@@ -0,0 +1,30 @@
+package types
+
+// NFTMetadata is ERC-721 metadata json, also valid ERC-1155 metadata with Decimals and Properties
+// https://eips.ethereum.org/EIPS/eip-721 https://eips.ethereum.org/EIPS/eip-1155#metadata
+type NFTMetadata struct {
+	Name            string         `json:"name"`
+	Description     string         `json:"description,omitempty"`
+	Image           string         `json:"image,omitempty"`
+	AnimationURL    string         `json:"animation_url,omitempty"`
+	ExternalURL     string         `json:"external_url,omitempty"`
+	BackgroundColor string         `json:"background_color,omitempty"`
+	Attributes      []NFTAttribute `json:"attributes,omitempty"`
+	Decimals        *int           `json:"decimals,omitempty"`
+	Properties      map[string]any `json:"properties,omitempty"`
+}
+
+// NFTAttribute is trait of token, DisplayType is opensea display hint (e.g. number, boost_percentage, date)
+type NFTAttribute struct {
+	TraitType   string `json:"trait_type,omitempty"`
+	Value       any    `json:"value"`
+	DisplayType string `json:"display_type,omitempty"`
+}
+
+// NFTUpload is result of nft upload, TokenURI is gateway url of metadata to set on mint
+type NFTUpload struct {
+	Media    Transaction `json:"media"`
+	Metadata Transaction `json:"metadata"`
+	MediaURL string      `json:"media_url"`
+	TokenURI string      `json:"token_uri"`
+}
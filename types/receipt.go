@@ -0,0 +1,73 @@
+package types
+
+import (
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/Ja7ad/irys/signer"
+)
+
+// ReceiptCSVHeader is header row of receipts exported by WriteReceiptsCSV
+var ReceiptCSVHeader = []string{
+	"id", "address", "currency", "signature", "timestamp", "version", "deadline_height", "transaction_timestamp",
+}
+
+// CSVRecord return receipt fields in order of ReceiptCSVHeader
+func (r Receipt) CSVRecord() []string {
+	return []string{
+		r.ID,
+		r.Address,
+		r.Currency,
+		r.Signature,
+		strconv.FormatInt(r.Timestamp, 10),
+		r.Version,
+		strconv.Itoa(r.DeadlineHeight),
+		strconv.FormatInt(r.TransactionTimestamp, 10),
+	}
+}
+
+// Verify check signature of receipt by node signer (e.g. signer.GetSigner(signer.Arweave, public key of node)),
+// signature is over deep hash of "Bundlr", version, id, deadline height and timestamp so ID must be set
+func (r Receipt) Verify(node signer.Signer) error {
+	signature, err := base64.RawURLEncoding.DecodeString(r.Signature)
+	if err != nil {
+		return err
+	}
+
+	hash := DeepHash([]any{
+		"Bundlr",
+		r.Version,
+		r.ID,
+		strconv.Itoa(r.DeadlineHeight),
+		strconv.FormatInt(r.Timestamp, 10),
+	})
+	return node.Verify(hash[:], signature)
+}
+
+// WriteReceiptsJSON write receipts as indented json array
+func WriteReceiptsJSON(w io.Writer, receipts []Receipt) error {
+	if receipts == nil {
+		receipts = []Receipt{}
+	}
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(receipts)
+}
+
+// WriteReceiptsCSV write receipts as csv with ReceiptCSVHeader row
+func WriteReceiptsCSV(w io.Writer, receipts []Receipt) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(ReceiptCSVHeader); err != nil {
+		return err
+	}
+	for _, r := range receipts {
+		if err := cw.Write(r.CSVRecord()); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
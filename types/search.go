@@ -0,0 +1,48 @@
+package types
+
+import "time"
+
+// SortOrder is order of search results by timestamp
+type SortOrder string
+
+const (
+	SortAsc  SortOrder = "ASC"
+	SortDesc SortOrder = "DESC"
+)
+
+// TagFilter match transactions with tag name and one of values
+type TagFilter struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+// SearchQuery filter transactions of graphql search, zero fields are not filtered
+type SearchQuery struct {
+	Tags      []TagFilter
+	Addresses []string
+	Currency  string
+
+	// From and To filter receipt timestamp range, inclusive
+	From time.Time
+	To   time.Time
+
+	// MinBlockHeight and MaxBlockHeight filter block height range, inclusive. Node graphql has no block
+	// filter, so query with block range sent to graphql of gateway (arweave schema), which not filter
+	// Currency and timestamp range (From, To) and order results by block height. Addresses are owner
+	// addresses of gateway.
+	MinBlockHeight int64
+	MaxBlockHeight int64
+
+	Order SortOrder
+	// PageSize is number of transactions per page, default 100
+	PageSize int
+	// After resume search after cursor of previous page (e.g. persisted by incremental indexer)
+	After string
+}
+
+// SearchPage is page of search results, Cursor is cursor of last transaction of page
+type SearchPage struct {
+	Transactions []TransactionNode
+	Cursor       string
+	HasNextPage  bool
+}
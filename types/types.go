@@ -1,21 +1,246 @@
 package types
 
 import (
+	"encoding/base64"
 	"io"
 	"math/big"
 	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Ja7ad/irys/signer"
 )
 
+// NodeInfo is info of node, Currencies present only when node report decimals and funding limits
+// of currencies (optional field of /info), so client side funding limits enforced only when reported
 type NodeInfo struct {
-	Version   string            `json:"version"`
-	Addresses map[string]string `json:"addresses"`
-	Gateway   string            `json:"gateway"`
+	Version       string                  `json:"version"`
+	Addresses     map[string]string       `json:"addresses"`
+	Gateway       string                  `json:"gateway"`
+	Currencies    map[string]CurrencyInfo `json:"currencies,omitempty"`
+	MaxUploadSize int64                   `json:"maxUploadSize,omitempty"`
+}
+
+// NodeCapabilities is features of node, MaxUploadSize is zero when node not report limit and
+// MinChunkSize, MaxChunkSize are zero until learned by chunked upload
+type NodeCapabilities struct {
+	Version       string   `json:"version"`
+	Currencies    []string `json:"currencies"`
+	MaxUploadSize int64    `json:"max_upload_size,omitempty"`
+	Chunking      bool     `json:"chunking"`
+	MinChunkSize  int      `json:"min_chunk_size,omitempty"`
+	MaxChunkSize  int      `json:"max_chunk_size,omitempty"`
+}
+
+// SupportsCurrency report node accept currency of name (e.g. matic)
+func (c NodeCapabilities) SupportsCurrency(name string) bool {
+	for _, currency := range c.Currencies {
+		if currency == name {
+			return true
+		}
+	}
+	return false
+}
+
+// CurrencyInfo is config of currency on node, nil MinFunding or MaxFunding means no limit or limit
+// not reported by node
+type CurrencyInfo struct {
+	Decimals   int      `json:"decimals"`
+	MinFunding *big.Int `json:"minFunding,omitempty"`
+	MaxFunding *big.Int `json:"maxFunding,omitempty"`
+}
+
+// Statuses of transaction on node
+const (
+	TxStatusPending   = "PENDING"
+	TxStatusConfirmed = "CONFIRMED"
+	TxStatusFinalized = "FINALIZED"
+)
+
+// TxStatus is status of transaction on node, Height is arweave height of bundle when confirmed
+type TxStatus struct {
+	Status     string `json:"status"`
+	Height     int64  `json:"height,omitempty"`
+	BundleTxID string `json:"bundleTxId,omitempty"`
+}
+
+// ArweaveInfo is info of arweave node
+type ArweaveInfo struct {
+	Network string `json:"network"`
+	Height  int64  `json:"height"`
 }
 
 type BalanceResponse struct {
 	Balance string `json:"balance"`
 }
 
+type BulkPrice struct {
+	Total *big.Int   // Total fee of all items
+	Items []*big.Int // Items fee of each size in same order
+}
+
+// PriceBreakdown is itemized upload price in atomic unit of currency, Total is BaseFee plus DataFee
+// (PerChunk for each started chunk) and PerByte is exact rate of data fee per byte.
+type PriceBreakdown struct {
+	Size      int      `json:"size"`
+	Chunks    int64    `json:"chunks"`
+	ChunkSize int      `json:"chunk_size"`
+	BaseFee   *big.Int `json:"base_fee"`
+	PerChunk  *big.Int `json:"per_chunk"`
+	PerByte   *big.Rat `json:"per_byte"`
+	DataFee   *big.Int `json:"data_fee"`
+	Total     *big.Int `json:"total"`
+	Currency  string   `json:"currency"`
+	Decimals  int      `json:"decimals"`
+}
+
+type PlanResult struct {
+	Cost    *big.Int         // Cost is aggregate estimated fee of plan
+	Funded  *big.Int         // Funded amount top up before uploads, zero if balance was enough
+	Unspent *big.Int         // Unspent estimated fee of failed items
+	Items   []PlanItemResult // Items result of each item in plan order
+}
+
+type PlanItemResult struct {
+	Index int
+	Tx    Transaction
+	Err   error
+}
+
+// Uploaded return items uploaded successfully
+func (r PlanResult) Uploaded() []PlanItemResult {
+	return r.filter(true)
+}
+
+// Failed return items failed to upload
+func (r PlanResult) Failed() []PlanItemResult {
+	return r.filter(false)
+}
+
+func (r PlanResult) filter(ok bool) []PlanItemResult {
+	items := make([]PlanItemResult, 0, len(r.Items))
+	for _, item := range r.Items {
+		if (item.Err == nil) == ok {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+type AccountSummary struct {
+	Address       string   `json:"address"`
+	Balance       *big.Int `json:"balance"`
+	UnspentCredit *big.Int `json:"unspent_credit"` // UnspentCredit funded by BasicUpload or Plan but upload canceled
+}
+
+// TopUpEstimate is expected cost of TopUpBalance, Fee is estimated chain fee in native token of chain.
+// Total is Amount plus Fee, nil for erc20 tokens which fee paid in other token than amount.
+type TopUpEstimate struct {
+	Amount   *big.Int `json:"amount"`
+	GasLimit uint64   `json:"gas_limit,omitempty"`
+	GasPrice *big.Int `json:"gas_price,omitempty"`
+	Fee      *big.Int `json:"fee"`
+	Total    *big.Int `json:"total,omitempty"`
+}
+
+// Quote is pre-flight check of upload cost against balance, Shortfall is zero when balance cover price
+// and AutoFund report shortfall would be topped up by auto funding (WithAutoFundOn402).
+type Quote struct {
+	Size      int      `json:"size"`
+	Price     *big.Int `json:"price"`
+	Balance   *big.Int `json:"balance"`
+	Shortfall *big.Int `json:"shortfall"`
+	AutoFund  bool     `json:"auto_fund"`
+}
+
+type Approval struct {
+	Amount          string `json:"amount"`
+	PayingAddress   string `json:"payingAddress"`
+	ApprovedAddress string `json:"approvedAddress"`
+	Token           string `json:"token"`
+	ExpiresBy       int64  `json:"expiresBy,omitempty"`
+	Timestamp       int64  `json:"timestamp"`
+}
+
+type JournalState string
+
+const (
+	JournalPending   JournalState = "pending"
+	JournalCompleted JournalState = "completed"
+	JournalFailed    JournalState = "failed" // JournalFailed item rejected by node, not re-posted by Recover
+)
+
+type JournalEntry struct {
+	ID          string       `json:"id"`
+	PayloadHash string       `json:"payload_hash"`
+	Tags        []Tag        `json:"tags"`
+	State       JournalState `json:"state"`
+	CreatedAt   time.Time    `json:"created_at"`
+	CompletedAt time.Time    `json:"completed_at,omitempty"`
+	Err         string       `json:"error,omitempty"` // Err is rejection of node for failed entry
+}
+
+type QueueState string
+
+const (
+	QueueQueued    QueueState = "queued"
+	QueueUploading QueueState = "uploading"
+	QueueCompleted QueueState = "completed"
+	QueueFailed    QueueState = "failed"
+)
+
+// QueueStatus is state of ticket of UploadQueue, TxID is id of item signed on first attempt and Err is
+// error of last attempt
+type QueueStatus struct {
+	Ticket    string     `json:"ticket"`
+	State     QueueState `json:"state"`
+	TxID      string     `json:"tx_id,omitempty"`
+	Attempts  int        `json:"attempts"`
+	Err       string     `json:"error,omitempty"`
+	Tags      []Tag      `json:"tags"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// IndexEntry uploaded item recorded in local upload index
+type IndexEntry struct {
+	ID        string    `json:"id"`
+	Tags      []Tag     `json:"tags"`
+	Size      int64     `json:"size"` // Size of uploaded data in byte
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type AccountRecordKind string
+
+const (
+	AccountFunding  AccountRecordKind = "funding"
+	AccountSnapshot AccountRecordKind = "snapshot"
+	AccountUpload   AccountRecordKind = "upload"
+)
+
+// AccountRecord is entry of account log, Amount is funded amount for funding, balance for snapshot and
+// price at upload time for upload. TxHash is hash of funding or id of uploaded item.
+type AccountRecord struct {
+	Kind     AccountRecordKind `json:"kind"`
+	Currency string            `json:"currency"`
+	Amount   *big.Int          `json:"amount"`
+	TxHash   string            `json:"tx_hash,omitempty"`
+	Time     time.Time         `json:"time"`
+}
+
+// ReconcileReport compare balance expected from funding and uploads since time with actual balance
+type ReconcileReport struct {
+	Since           time.Time
+	OpeningBalance  *big.Int // OpeningBalance of last snapshot at or before Since, zero when no snapshot
+	TotalFunded     *big.Int
+	TotalSpent      *big.Int // TotalSpent is price of uploads since Since recorded at upload time
+	Fundings        int
+	Uploads         int
+	ExpectedBalance *big.Int // ExpectedBalance is OpeningBalance + TotalFunded - TotalSpent
+	ActualBalance   *big.Int
+	Difference      *big.Int // Difference is ActualBalance - ExpectedBalance
+}
+
 type TxToBalanceRequest struct {
 	TxId string `json:"tx_id"`
 }
@@ -41,6 +266,94 @@ type Transaction struct {
 	Anchor    string `json:"anchor"`
 	DataSize  string `json:"data_size"`
 	RawSize   string `json:"raw_size"`
+
+	// receipt fields of upload response
+	Timestamp      int64  `json:"timestamp,omitempty"`
+	Version        string `json:"version,omitempty"`
+	DeadlineHeight int    `json:"deadlineHeight,omitempty"`
+
+	Cost  *big.Int     `json:"-"` // Cost of upload, filled only in dry run mode
+	Stats *UploadStats `json:"-"` // Stats of upload, filled only when upload stats enabled
+}
+
+// UploadResult is transaction of BasicUpload with outcome of funding step before upload
+type UploadResult struct {
+	Transaction
+
+	Funded        bool     // Funded report funding tx sent before upload, set also on error when node not confirmed it
+	FundedAmount  *big.Int // FundedAmount is amount of top up, nil when not funded
+	FundingTxHash string   // FundingTxHash is chain transaction hash of top up
+}
+
+// Receipt return receipt of uploaded transaction
+func (t Transaction) Receipt() Receipt {
+	return Receipt{
+		Signature:      t.Signature,
+		Timestamp:      t.Timestamp,
+		Version:        t.Version,
+		DeadlineHeight: t.DeadlineHeight,
+		ID:             t.ID,
+		Address:        t.Address,
+		Currency:       t.Currency,
+	}
+}
+
+// DecodedTags return tags with base64url decoded name and value
+func (t Transaction) DecodedTags() ([]Tag, error) {
+	tags := make([]Tag, 0, len(t.Tags))
+	for _, tag := range t.Tags {
+		name, err := decodeBase64URL(tag.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := decodeBase64URL(tag.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, Tag{Name: name, Value: value})
+	}
+	return tags, nil
+}
+
+func decodeBase64URL(s string) (string, error) {
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// Manifest is arweave path manifest, map paths to transaction ids
+type Manifest struct {
+	Manifest string                  `json:"manifest"`
+	Version  string                  `json:"version"`
+	Index    *ManifestIndex          `json:"index,omitempty"`
+	Paths    map[string]ManifestPath `json:"paths"`
+}
+
+type ManifestIndex struct {
+	Path string `json:"path"`
+}
+
+type ManifestPath struct {
+	ID string `json:"id"`
+}
+
+type Owner struct {
+	SignatureType signer.SignatureType // SignatureType detected from owner public key
+	PublicKey     []byte               // PublicKey raw owner public key
+	Address       string               // Address checksum hex for ethereum and base64url for arweave
+}
+
+type UploadStats struct {
+	Size            int           // Size of signed data item in byte
+	Duration        time.Duration // Duration total time of upload
+	SignDuration    time.Duration // SignDuration time spent in signing data item
+	NetworkDuration time.Duration // NetworkDuration time spent in sending data item to node
+	BytesPerSecond  float64       // BytesPerSecond upload speed over network
+	Retries         int           // Retries number of retried requests
 }
 
 type File struct {
@@ -67,33 +380,99 @@ type ChunkResponse struct {
 	Max int
 }
 
+type ReceiptField string
+
+// Additional transaction fields can be requested with receipt
+const (
+	ReceiptFieldID        ReceiptField = "id"        // ReceiptFieldID transaction id
+	ReceiptFieldAddress   ReceiptField = "address"   // ReceiptFieldAddress address of uploader
+	ReceiptFieldCurrency  ReceiptField = "currency"  // ReceiptFieldCurrency currency used for pay
+	ReceiptFieldTimestamp ReceiptField = "timestamp" // ReceiptFieldTimestamp transaction timestamp in millisecond
+)
+
 type Receipt struct {
 	Signature      string `json:"signature"`
 	Timestamp      int64  `json:"timestamp"`
 	Version        string `json:"version"`
 	DeadlineHeight int    `json:"deadlineHeight"`
+
+	// fields filled only when selected by ReceiptField
+	ID                   string `json:"id,omitempty"`
+	Address              string `json:"address,omitempty"`
+	Currency             string `json:"currency,omitempty"`
+	TransactionTimestamp int64  `json:"transactionTimestamp,omitempty"`
+}
+
+// ReceiptResponse is graphql response of receipt query
+//
+// Deprecated: use GraphqlResponse[TransactionsData]
+type ReceiptResponse = GraphqlResponse[TransactionsData]
+
+type VerifyResult struct {
+	TxId    string  `json:"tx_id"`
+	Exists  bool    `json:"exists"`
+	Valid   bool    `json:"valid"`
+	Receipt Receipt `json:"receipt"`
+	Err     error   `json:"-"`
+}
+
+type VerifySummary struct {
+	Total   int `json:"total"`
+	Valid   int `json:"valid"`
+	Invalid int `json:"invalid"`
+	Missing int `json:"missing"`
+	Failed  int `json:"failed"`
+}
+
+// TransactionIdsResponse is graphql response of transaction ids query
+//
+// Deprecated: use GraphqlResponse[TransactionsData]
+type TransactionIdsResponse = GraphqlResponse[TransactionsData]
+
+type GraphqlRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+type GraphqlResponse[T any] struct {
+	Data   T              `json:"data"`
+	Errors []GraphqlError `json:"errors,omitempty"`
+}
+
+type GraphqlError struct {
+	Message string `json:"message"`
+	Path    []any  `json:"path,omitempty"`
+}
+
+// TransactionsData is data of transactions query
+type TransactionsData struct {
+	Transactions TransactionConnection `json:"transactions"`
+}
+
+type TransactionConnection struct {
+	Edges    []TransactionEdge `json:"edges"`
+	PageInfo PageInfo          `json:"pageInfo"`
+}
+
+type TransactionEdge struct {
+	Cursor string          `json:"cursor"`
+	Node   TransactionNode `json:"node"`
+}
+
+// TransactionNode is transaction of graphql query, fields filled only when selected in query
+type TransactionNode struct {
+	ID        string  `json:"id"`
+	Address   string  `json:"address"`
+	Currency  string  `json:"currency"`
+	Timestamp int64   `json:"timestamp"`
+	Tags      []Tag   `json:"tags"`
+	Receipt   Receipt `json:"receipt"`
+	// BlockHeight is height of arweave block, filled by search of block height range
+	BlockHeight int64 `json:"blockHeight,omitempty"`
 }
 
-type ReceiptResponse struct {
-	Data struct {
-		Transactions struct {
-			Edges []struct {
-				Node struct {
-					Receipt struct {
-						Signature      string `json:"signature"`
-						Timestamp      int64  `json:"timestamp"`
-						Version        string `json:"version"`
-						DeadlineHeight int    `json:"deadlineHeight"`
-					} `json:"receipt"`
-				} `json:"node"`
-			} `json:"edges"`
-		} `json:"transactions"`
-	} `json:"data"`
-}
-
-type ChunkInfoResponse struct {
-	Chunks []int `json:"chunks"`
-	Total  int   `json:"total"`
+type PageInfo struct {
+	HasNextPage bool `json:"hasNextPage"`
 }
 
 func (b BalanceResponse) ToBigInt() *big.Int {
@@ -0,0 +1,41 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionDecodedTags(t *testing.T) {
+	tx := Transaction{
+		Tags: []Tag{
+			{Name: "Q29udGVudC1UeXBl", Value: "dGV4dC9wbGFpbg"},
+			{Name: "QXBwLU5hbWU=", Value: "aXJ5cw=="},
+		},
+	}
+
+	tags, err := tx.DecodedTags()
+	require.NoError(t, err)
+	require.Equal(t, []Tag{
+		{Name: "Content-Type", Value: "text/plain"},
+		{Name: "App-Name", Value: "irys"},
+	}, tags)
+
+	tx.Tags = []Tag{{Name: "not base64!", Value: ""}}
+	_, err = tx.DecodedTags()
+	require.Error(t, err)
+}
+
+func TestChunkInfoResponse(t *testing.T) {
+	var info ChunkInfoResponse
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"up","size":300,"chunks":[["0",100],["200",100]]}`), &info))
+	require.Equal(t, ChunkInfoResponse{Chunks: []int{0, 200}, Sizes: map[int]int{0: 100, 200: 100}, Total: 300}, info)
+
+	info = ChunkInfoResponse{}
+	require.NoError(t, json.Unmarshal([]byte(`{"total":200,"chunks":[0,"100"]}`), &info))
+	require.Equal(t, ChunkInfoResponse{Chunks: []int{0, 100}, Total: 200}, info)
+
+	require.Error(t, json.Unmarshal([]byte(`{"chunks":[["0"]]}`), &info))
+	require.Error(t, json.Unmarshal([]byte(`{"chunks":[{"offset":0}]}`), &info))
+}
@@ -0,0 +1,39 @@
+package irys
+
+import (
+	"bytes"
+	"context"
+	"os"
+
+	"github.com/Ja7ad/irys/types"
+	"github.com/Ja7ad/irys/utils/mmap"
+)
+
+// UploadFile upload file of path by UploadReader, so upload is not journaled and not fallback to arweave L1
+// with or without WithMmap. With WithMmap file mapped to memory and data signed and sent from mapping
+// instead of read from file.
+func (c *Client) UploadFile(ctx context.Context, path string, tags ...types.Tag) (types.Transaction, error) {
+	if !c.mmap {
+		f, err := os.Open(path)
+		if err != nil {
+			return types.Transaction{}, err
+		}
+		defer f.Close()
+
+		info, err := f.Stat()
+		if err != nil {
+			return types.Transaction{}, err
+		}
+		return c.UploadReader(ctx, f, info.Size(), tags...)
+	}
+
+	m, err := mmap.Open(path)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+	defer m.Close()
+
+	c.debugMsg("[UploadFile] mapped %s (%d bytes)", path, m.Len())
+
+	return c.UploadReader(ctx, bytes.NewReader(m.Bytes()), int64(m.Len()), tags...)
+}
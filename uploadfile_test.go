@@ -0,0 +1,82 @@
+package irys
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/iotest"
+
+	"github.com/Ja7ad/irys/currency"
+	"github.com/Ja7ad/irys/signer"
+	"github.com/Ja7ad/irys/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItemBody(t *testing.T) {
+	s, err := signer.NewEthereumSigner("0xf4a2b939592564feb35ab10a8e04f6f2fe0943579fb3c9c33505298978b74893")
+	require.NoError(t, err)
+
+	item, err := signItem([]byte("hello irys"), s, false, types.Tag{Name: "App-Name", Value: "irys"})
+	require.NoError(t, err)
+
+	raw, err := item.Reader()
+	require.NoError(t, err)
+
+	body, err := newItemBody(item)
+	require.NoError(t, err)
+	require.Equal(t, int64(raw.Len()), body.size)
+
+	r, err := body.reader()
+	require.NoError(t, err)
+	b, err := io.ReadAll(iotest.OneByteReader(r))
+	require.NoError(t, err)
+	require.Equal(t, raw.Bytes(), b)
+
+	r, err = body.reader()
+	require.NoError(t, err)
+	body.close()
+	_, err = r.Read(make([]byte, 8))
+	require.ErrorIs(t, err, io.ErrClosedPipe)
+}
+
+func TestUploadFile(t *testing.T) {
+	data := bytes.Repeat([]byte("hello irys "), 1000)
+	path := filepath.Join(t.TempDir(), "data.txt")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, int64(len(b)), r.ContentLength)
+
+		item := new(types.BundleItem)
+		require.NoError(t, item.Unmarshal(b))
+		require.NoError(t, item.VerifySignature())
+		require.Equal(t, data, []byte(item.Data))
+		_ = json.NewEncoder(w).Encode(types.Transaction{ID: item.Id.Base64()})
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	for _, mmap := range []bool{false, true} {
+		c := &Client{client: retryablehttp.NewClient(), network: Node(srv.URL), currency: matic, mmap: mmap}
+
+		tx, err := c.UploadFile(context.Background(), path)
+		require.NoError(t, err)
+		require.NotEmpty(t, tx.ID)
+	}
+}
@@ -0,0 +1,189 @@
+package irys
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+)
+
+// UploadIndex record uploaded items by tags (WithUploadIndex) for lookup without graphql query
+type UploadIndex interface {
+	// Add entry of uploaded item, existing entry with same id replaced
+	Add(entry types.IndexEntry) error
+	// Find entries of items tagged with name and value ordered by timestamp
+	Find(name, value string) ([]types.IndexEntry, error)
+}
+
+// FileUploadIndex is UploadIndex keep entries in memory and append them to file as json lines,
+// entries of file loaded on open.
+type FileUploadIndex struct {
+	mu      sync.RWMutex
+	file    *os.File
+	entries map[string]types.IndexEntry
+	tags    map[string]map[string]struct{} // ids of items by tag key
+}
+
+var _ UploadIndex = (*FileUploadIndex)(nil)
+
+// NewFileUploadIndex open upload index of path, file and parent dir created if not exists
+func NewFileUploadIndex(path string) (*FileUploadIndex, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := &FileUploadIndex{
+		file:    f,
+		entries: make(map[string]types.IndexEntry),
+		tags:    make(map[string]map[string]struct{}),
+	}
+
+	var (
+		r     = bufio.NewReader(f)
+		valid int64 // valid is offset after last complete line
+	)
+	for {
+		line, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		valid += int64(len(line))
+
+		var entry types.IndexEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			continue
+		}
+		idx.add(entry)
+	}
+
+	// last line half written on crash truncated, so next entry start on own line
+	if err := f.Truncate(valid); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return idx, nil
+}
+
+func (i *FileUploadIndex) Add(entry types.IndexEntry) error {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if _, err := i.file.Write(append(b, '\n')); err != nil {
+		return err
+	}
+	i.add(entry)
+	return nil
+}
+
+func (i *FileUploadIndex) Find(name, value string) ([]types.IndexEntry, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	ids := i.tags[tagKey(name, value)]
+	entries := make([]types.IndexEntry, 0, len(ids))
+	for id := range ids {
+		entries = append(entries, i.entries[id])
+	}
+
+	sort.Slice(entries, func(a, b int) bool {
+		if !entries[a].Timestamp.Equal(entries[b].Timestamp) {
+			return entries[a].Timestamp.Before(entries[b].Timestamp)
+		}
+		return entries[a].ID < entries[b].ID
+	})
+
+	return entries, nil
+}
+
+// Close close file of index
+func (i *FileUploadIndex) Close() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.file.Close()
+}
+
+func (i *FileUploadIndex) add(entry types.IndexEntry) {
+	if old, ok := i.entries[entry.ID]; ok {
+		for _, tag := range old.Tags {
+			delete(i.tags[tagKey(tag.Name, tag.Value)], entry.ID)
+		}
+	}
+
+	i.entries[entry.ID] = entry
+	for _, tag := range entry.Tags {
+		key := tagKey(tag.Name, tag.Value)
+		if i.tags[key] == nil {
+			i.tags[key] = make(map[string]struct{})
+		}
+		i.tags[key][entry.ID] = struct{}{}
+	}
+}
+
+// tagKey key of tag in index, tag names compared case insensitive like http headers
+func tagKey(name, value string) string {
+	return strings.ToLower(name) + "\x00" + value
+}
+
+// FindLocal find uploaded items tagged with name and value in upload index (WithUploadIndex)
+func (c *Client) FindLocal(name, value string) ([]types.IndexEntry, error) {
+	if c.uploadIndex == nil {
+		return nil, errors.ErrUploadIndexNotConfigured
+	}
+	return c.uploadIndex.Find(name, value)
+}
+
+// indexUpload add uploaded transaction of data size with tags of transaction to upload index, index failure
+// reported to OnError hook and not fail upload because item already accepted by node
+func (c *Client) indexUpload(ctx context.Context, tx types.Transaction, size int64) {
+	if c.uploadIndex == nil || c.dryRun {
+		return
+	}
+
+	entry := types.IndexEntry{
+		ID:        tx.ID,
+		Tags:      tx.Tags,
+		Size:      size,
+		Timestamp: time.Now(),
+	}
+	if tx.Timestamp > 0 {
+		entry.Timestamp = time.UnixMilli(tx.Timestamp)
+	}
+
+	if err := c.uploadIndex.Add(entry); err != nil {
+		c.debugMsg("[UploadIndex] add %s failed: %v", tx.ID, err)
+		c.failed(ctx, "IndexUpload", err)
+	}
+}
+
+// withItemTags fill tags of transaction returned by node with tags of signed item, which include tags
+// added on upload (e.g. correlation and content hash tags)
+func withItemTags(tx types.Transaction, item *types.BundleItem) types.Transaction {
+	if len(tx.Tags) == 0 {
+		tx.Tags = item.Tags
+	}
+	return tx
+}
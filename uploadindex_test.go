@@ -0,0 +1,111 @@
+package irys
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ja7ad/irys/currency"
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileUploadIndex(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "index", "uploads.jsonl")
+
+	idx, err := NewFileUploadIndex(path)
+	require.NoError(t, err)
+
+	now := time.Now()
+	require.NoError(t, idx.Add(types.IndexEntry{ID: "tx-b", Tags: []types.Tag{{Name: "App", Value: "demo"}}, Size: 2, Timestamp: now}))
+	require.NoError(t, idx.Add(types.IndexEntry{ID: "tx-a", Tags: []types.Tag{{Name: "app", Value: "demo"}}, Size: 1, Timestamp: now.Add(-time.Second)}))
+	require.NoError(t, idx.Add(types.IndexEntry{ID: "tx-c", Tags: []types.Tag{{Name: "App", Value: "other"}}, Timestamp: now}))
+	// replaced entry not found by old tags
+	require.NoError(t, idx.Add(types.IndexEntry{ID: "tx-c", Tags: []types.Tag{{Name: "App", Value: "new"}}, Timestamp: now}))
+	require.NoError(t, idx.Close())
+
+	idx, err = NewFileUploadIndex(path)
+	require.NoError(t, err)
+	defer idx.Close()
+
+	entries, err := idx.Find("APP", "demo")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, "tx-a", entries[0].ID)
+	require.Equal(t, int64(2), entries[1].Size)
+
+	entries, err = idx.Find("App", "other")
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	entries, err = idx.Find("App", "new")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.NoError(t, idx.Close())
+
+	// half written line of crash truncated on open, next entry start on own line
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"id":"tx-d","tags":[{"name":"App","va`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	idx, err = NewFileUploadIndex(path)
+	require.NoError(t, err)
+	require.NoError(t, idx.Add(types.IndexEntry{ID: "tx-e", Tags: []types.Tag{{Name: "App", Value: "demo"}}, Timestamp: now}))
+	require.NoError(t, idx.Close())
+
+	idx, err = NewFileUploadIndex(path)
+	require.NoError(t, err)
+	entries, err = idx.Find("App", "demo")
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+}
+
+func TestUploadIndexFindLocal(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/tx/") {
+			fmt.Fprint(w, `{"id":"tx-1","timestamp":1700000000000}`)
+		}
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	c := &Client{client: retryablehttp.NewClient(), network: Node(srv.URL), currency: matic}
+
+	_, err = c.FindLocal("App", "demo")
+	require.ErrorIs(t, err, errs.ErrUploadIndexNotConfigured)
+
+	idx, err := NewFileUploadIndex(filepath.Join(t.TempDir(), "uploads.jsonl"))
+	require.NoError(t, err)
+	defer idx.Close()
+	c.uploadIndex = idx
+
+	_, err = c.Upload(context.Background(), []byte("hello"), types.Tag{Name: "App", Value: "demo"})
+	require.NoError(t, err)
+
+	entries, err := c.FindLocal("App", "demo")
+	require.NoError(t, err)
+	// tags of signed item, with content type added on upload
+	require.Equal(t, []types.IndexEntry{{
+		ID:        "tx-1",
+		Tags:      []types.Tag{{Name: "App", Value: "demo"}, {Name: "Content-Type", Value: "text/plain; charset=utf-8"}},
+		Size:      5,
+		Timestamp: time.UnixMilli(1700000000000),
+	}}, entries)
+}
@@ -0,0 +1,481 @@
+package irys
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+)
+
+const (
+	_queueStateExt        = ".json"
+	_queueDataExt         = ".data"
+	_queueItemExt         = ".item"
+	_defaultQueueAttempts = 5
+	_queueBackoff         = time.Second
+	_queueMaxBackoff      = time.Minute
+	_queueFinishedTTL     = 10 * time.Minute
+	_queueCompletedBuffer = 64
+)
+
+// UploadQueue upload enqueued data in background workers with retries, state of tickets persisted in
+// directory so queued uploads resumed by NewUploadQueue of same directory after restart. Data of ticket
+// signed once on first attempt, so retries and resumed uploads post item of same id. Finished tickets
+// are removed from directory and known by Status for 10 minutes after finish. Queue closed by Shutdown or
+// Close of client.
+type UploadQueue struct {
+	c           *Client
+	dir         string
+	maxAttempts int
+	backoff     time.Duration
+	finishedTTL time.Duration
+
+	mu       sync.Mutex
+	status   map[string]types.QueueStatus
+	pending  []string
+	finished []string
+	closed   bool
+
+	notify    chan struct{}
+	completed chan types.QueueStatus
+	quit      chan struct{}
+	ctx       context.Context
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+}
+
+// NewUploadQueue create upload queue persisted in dir and start workers, maxAttempts is number of upload
+// attempts of ticket before failed (default 5). Queued tickets of previous run of dir are resumed.
+func (c *Client) NewUploadQueue(dir string, workers, maxAttempts int) (*UploadQueue, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+
+	if workers <= 0 {
+		workers = 1
+	}
+	if maxAttempts <= 0 {
+		maxAttempts = _defaultQueueAttempts
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	q := &UploadQueue{
+		c:           c,
+		dir:         dir,
+		maxAttempts: maxAttempts,
+		backoff:     _queueBackoff,
+		finishedTTL: _queueFinishedTTL,
+		status:      make(map[string]types.QueueStatus),
+		notify:      make(chan struct{}, 1),
+		completed:   make(chan types.QueueStatus, _queueCompletedBuffer),
+		quit:        make(chan struct{}),
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+
+	if err := q.load(); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// queue stopped by Shutdown of client
+	if !c.drain.track(q) {
+		cancel()
+		return nil, errors.ErrClientClosed
+	}
+
+	q.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go q.worker()
+	}
+
+	go func() {
+		q.wg.Wait()
+		close(q.completed)
+	}()
+
+	return q, nil
+}
+
+// Enqueue persist data with tags and return ticket of upload
+func (q *UploadQueue) Enqueue(data []byte, tags ...types.Tag) (string, error) {
+	ticket, err := newTicket()
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.WriteFile(q.path(ticket, _queueDataExt), data, 0o600); err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	status := types.QueueStatus{
+		Ticket:    ticket,
+		State:     types.QueueQueued,
+		Tags:      tags,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		os.Remove(q.path(ticket, _queueDataExt))
+		return "", errors.ErrQueueClosed
+	}
+
+	if err := q.save(status); err != nil {
+		os.Remove(q.path(ticket, _queueDataExt))
+		return "", err
+	}
+
+	q.status[ticket] = status
+	q.pending = append(q.pending, ticket)
+	q.signal()
+
+	return ticket, nil
+}
+
+// Status return state of ticket
+func (q *UploadQueue) Status(ticket string) (types.QueueStatus, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.evictLocked(time.Now())
+	status, ok := q.status[ticket]
+	if !ok {
+		return types.QueueStatus{}, errors.ErrTicketNotFound
+	}
+	return status, nil
+}
+
+// Completed return channel of tickets completed or failed, workers not wait for caller so ticket finished
+// while channel buffer (64) is full not sent and only known by Status. Channel closed after Close.
+func (q *UploadQueue) Completed() <-chan types.QueueStatus {
+	return q.completed
+}
+
+// Close stop workers after in-flight uploads done, uploads canceled when ctx done before and their tickets
+// resumed on next start. Queued tickets remain persisted.
+func (q *UploadQueue) Close(ctx context.Context) error {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return nil
+	}
+	q.closed = true
+	close(q.quit)
+	q.mu.Unlock()
+
+	q.c.drain.untrack(q)
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		q.cancel()
+		return nil
+	case <-ctx.Done():
+		q.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+func (q *UploadQueue) worker() {
+	defer q.wg.Done()
+
+	for {
+		ticket, ok := q.next()
+		if !ok {
+			return
+		}
+		q.process(ticket)
+	}
+}
+
+// next return next queued ticket, false when queue closed
+func (q *UploadQueue) next() (string, bool) {
+	for {
+		q.mu.Lock()
+		if q.closed {
+			q.mu.Unlock()
+			return "", false
+		}
+		if len(q.pending) != 0 {
+			ticket := q.pending[0]
+			q.pending = q.pending[1:]
+			if len(q.pending) != 0 {
+				// wake other worker for rest of tickets
+				q.signal()
+			}
+			q.mu.Unlock()
+			return ticket, true
+		}
+		q.mu.Unlock()
+
+		select {
+		case <-q.notify:
+		case <-q.quit:
+			return "", false
+		}
+	}
+}
+
+func (q *UploadQueue) process(ticket string) {
+	status := q.update(ticket, func(s *types.QueueStatus) {
+		s.State = types.QueueUploading
+		s.Attempts++
+	})
+
+	raw, err := q.signedItem(ticket, status.Tags)
+	if err != nil {
+		q.finish(ticket, "", err)
+		return
+	}
+
+	tx, err := q.c.UploadSignedItem(q.ctx, raw)
+	switch {
+	case err == nil:
+		q.c.debugMsg("[UploadQueue] ticket %s uploaded with transaction %s", ticket, tx.ID)
+		q.finish(ticket, tx.ID, nil)
+	case q.ctx.Err() != nil:
+		// queue closed during upload, ticket resumed on next start
+		q.update(ticket, func(s *types.QueueStatus) {
+			s.State = types.QueueQueued
+			s.Attempts--
+		})
+	case status.Attempts >= q.maxAttempts:
+		q.finish(ticket, "", err)
+	default:
+		q.c.debugMsg("[UploadQueue] attempt %d of ticket %s failed: %v", status.Attempts, ticket, err)
+		q.update(ticket, func(s *types.QueueStatus) {
+			s.State = types.QueueQueued
+			s.Err = err.Error()
+		})
+		time.AfterFunc(q.retryDelay(status.Attempts), func() { q.retry(ticket) })
+	}
+}
+
+// signedItem return serialized data item of ticket, data signed and persisted on first attempt so every
+// attempt post item of same id instead of new item per retry
+func (q *UploadQueue) signedItem(ticket string, tags []types.Tag) ([]byte, error) {
+	path := q.path(ticket, _queueItemExt)
+	raw, err := os.ReadFile(path)
+	if err == nil {
+		return raw, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(q.path(ticket, _queueDataExt))
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := signItem(data, q.c.uploadSigner(), false, q.c.uploadTags(data, tags...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	// write to temp file and rename, so item never left half written on crash
+	tmp := path + ".tmp"
+	if err := writeItem(tmp, item); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return nil, err
+	}
+	os.Remove(q.path(ticket, _queueDataExt))
+
+	q.update(ticket, func(s *types.QueueStatus) {
+		s.TxID = item.Id.Base64()
+	})
+
+	b, err := item.Reader()
+	if err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// finish mark ticket completed or failed, remove its files and send it to completed channel
+func (q *UploadQueue) finish(ticket, txId string, err error) {
+	q.mu.Lock()
+	status := q.status[ticket]
+	status.State = types.QueueCompleted
+	if len(txId) != 0 {
+		status.TxID = txId
+	}
+	status.Err = ""
+	if err != nil {
+		status.State = types.QueueFailed
+		status.Err = err.Error()
+	}
+	status.UpdatedAt = time.Now()
+	q.status[ticket] = status
+	q.finished = append(q.finished, ticket)
+	q.evictLocked(status.UpdatedAt)
+	q.mu.Unlock()
+
+	// finished ticket pruned from dir, so load read only unfinished tickets
+	q.remove(ticket)
+
+	select {
+	case q.completed <- status:
+	default:
+		q.c.warnMsg("[UploadQueue] completed channel full, ticket %s finished as %s not sent", ticket, status.State)
+	}
+}
+
+// evictLocked drop finished tickets older than finishedTTL from status, tickets finished in order so
+// oldest ones are first
+func (q *UploadQueue) evictLocked(now time.Time) {
+	n := 0
+	for ; n < len(q.finished); n++ {
+		ticket := q.finished[n]
+		if now.Sub(q.status[ticket].UpdatedAt) < q.finishedTTL {
+			break
+		}
+		delete(q.status, ticket)
+	}
+	q.finished = q.finished[n:]
+}
+
+func (q *UploadQueue) retry(ticket string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return
+	}
+	q.pending = append(q.pending, ticket)
+	q.signal()
+}
+
+// retryDelay return exponential backoff of attempt
+func (q *UploadQueue) retryDelay(attempt int) time.Duration {
+	delay := q.backoff
+	for i := 1; i < attempt && delay < _queueMaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > _queueMaxBackoff {
+		delay = _queueMaxBackoff
+	}
+	return delay
+}
+
+// update change status of ticket and persist it, persist error only logged since state in memory is valid
+// and ticket is resumed from last persisted state
+func (q *UploadQueue) update(ticket string, fn func(s *types.QueueStatus)) types.QueueStatus {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	status := q.status[ticket]
+	fn(&status)
+	status.UpdatedAt = time.Now()
+	q.status[ticket] = status
+
+	if err := q.save(status); err != nil {
+		q.c.debugMsg("[UploadQueue] persist ticket %s: %v", ticket, err)
+	}
+	return status
+}
+
+func (q *UploadQueue) signal() {
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+// load read persisted tickets of dir, unfinished tickets queued in order of creation
+func (q *UploadQueue) load() error {
+	files, err := os.ReadDir(q.dir)
+	if err != nil {
+		return err
+	}
+
+	var queued []types.QueueStatus
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), _queueStateExt) {
+			continue
+		}
+
+		b, err := os.ReadFile(filepath.Join(q.dir, f.Name()))
+		if err != nil {
+			return err
+		}
+
+		var status types.QueueStatus
+		if err := json.Unmarshal(b, &status); err != nil {
+			return err
+		}
+
+		if status.State != types.QueueQueued && status.State != types.QueueUploading {
+			// finished ticket left by crash before prune
+			q.remove(status.Ticket)
+			continue
+		}
+
+		status.State = types.QueueQueued
+		queued = append(queued, status)
+		q.status[status.Ticket] = status
+	}
+
+	sort.Slice(queued, func(i, j int) bool {
+		return queued[i].CreatedAt.Before(queued[j].CreatedAt)
+	})
+	for _, status := range queued {
+		q.pending = append(q.pending, status.Ticket)
+	}
+	return nil
+}
+
+func (q *UploadQueue) save(status types.QueueStatus) error {
+	b, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+
+	// write to temp file and rename, so state never left half written on crash
+	tmp := q.path(status.Ticket, _queueStateExt+".tmp")
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, q.path(status.Ticket, _queueStateExt))
+}
+
+// remove delete state, data and signed item of ticket
+func (q *UploadQueue) remove(ticket string) {
+	for _, ext := range []string{_queueStateExt, _queueDataExt, _queueItemExt} {
+		os.Remove(q.path(ticket, ext))
+	}
+}
+
+func (q *UploadQueue) path(ticket, ext string) string {
+	return filepath.Join(q.dir, ticket+ext)
+}
+
+func newTicket() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
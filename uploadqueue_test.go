@@ -0,0 +1,183 @@
+package irys
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ja7ad/irys/currency"
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadQueue(t *testing.T) {
+	var (
+		mu  sync.Mutex
+		ids []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		item := new(types.BundleItem)
+		if err := item.Unmarshal(b); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		ids = append(ids, item.Id.Base64())
+		n := len(ids)
+		mu.Unlock()
+
+		// first attempt fail
+		if n == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(types.Transaction{ID: item.Id.Base64()})
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	c := &Client{
+		client:   retryablehttp.NewClient(),
+		network:  Node(srv.URL),
+		currency: matic,
+	}
+	c.client.RetryMax = 0
+
+	dir := t.TempDir()
+	q, err := c.NewUploadQueue(dir, 2, 3)
+	require.NoError(t, err)
+	q.backoff = 10 * time.Millisecond
+
+	ticket, err := q.Enqueue([]byte("hello irys"), types.Tag{Name: "App-Name", Value: "queue"})
+	require.NoError(t, err)
+
+	select {
+	case status := <-q.Completed():
+		require.Equal(t, ticket, status.Ticket)
+		require.Equal(t, types.QueueCompleted, status.State)
+		require.Equal(t, 2, status.Attempts)
+
+		// retry post item signed on first attempt
+		mu.Lock()
+		require.Len(t, ids, 2)
+		require.Equal(t, ids[0], ids[1])
+		require.Equal(t, ids[0], status.TxID)
+		mu.Unlock()
+	case <-time.After(5 * time.Second):
+		t.Fatal("ticket not completed")
+	}
+
+	status, err := q.Status(ticket)
+	require.NoError(t, err)
+	require.Equal(t, types.QueueCompleted, status.State)
+
+	// files of finished ticket pruned
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, files)
+
+	_, err = q.Status("unknown")
+	require.ErrorIs(t, err, errs.ErrTicketNotFound)
+
+	require.NoError(t, q.Close(context.Background()))
+	_, err = q.Enqueue([]byte("late"))
+	require.ErrorIs(t, err, errs.ErrQueueClosed)
+
+	// ticket queued before crash resumed by next queue of dir
+	now := time.Now()
+	require.NoError(t, q.save(types.QueueStatus{Ticket: "pending", State: types.QueueUploading, CreatedAt: now, UpdatedAt: now}))
+	require.NoError(t, os.WriteFile(q.path("pending", _queueDataExt), []byte("resumed"), 0o600))
+
+	q, err = c.NewUploadQueue(dir, 1, 3)
+	require.NoError(t, err)
+	defer q.Close(context.Background())
+
+	// finished ticket not loaded by next queue
+	_, err = q.Status(ticket)
+	require.ErrorIs(t, err, errs.ErrTicketNotFound)
+
+	select {
+	case status := <-q.Completed():
+		require.Equal(t, "pending", status.Ticket)
+		require.Equal(t, types.QueueCompleted, status.State)
+	case <-time.After(5 * time.Second):
+		t.Fatal("ticket not resumed")
+	}
+}
+
+func TestUploadQueueFinished(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		item := new(types.BundleItem)
+		if err := item.Unmarshal(b); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(types.Transaction{ID: item.Id.Base64()})
+	}))
+	defer srv.Close()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	matic, err := currency.NewWithBackend(currency.MATIC, hex.EncodeToString(crypto.FromECDSA(key)), nil)
+	require.NoError(t, err)
+
+	c := &Client{
+		client:   retryablehttp.NewClient(),
+		network:  Node(srv.URL),
+		currency: matic,
+	}
+	c.client.RetryMax = 0
+
+	q, err := c.NewUploadQueue(t.TempDir(), 2, 3)
+	require.NoError(t, err)
+	defer q.Close(context.Background())
+
+	// workers not blocked by completed channel nobody drain
+	tickets := make([]string, _queueCompletedBuffer+2)
+	for i := range tickets {
+		tickets[i], err = q.Enqueue([]byte("hello irys"))
+		require.NoError(t, err)
+	}
+	require.Eventually(t, func() bool {
+		for _, ticket := range tickets {
+			status, err := q.Status(ticket)
+			if err != nil || status.State != types.QueueCompleted {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Len(t, q.Completed(), _queueCompletedBuffer)
+
+	// finished tickets evicted after ttl
+	q.mu.Lock()
+	q.finishedTTL = time.Millisecond
+	q.mu.Unlock()
+	time.Sleep(5 * time.Millisecond)
+
+	_, err = q.Status(tickets[0])
+	require.ErrorIs(t, err, errs.ErrTicketNotFound)
+	q.mu.Lock()
+	require.Empty(t, q.status)
+	require.Empty(t, q.finished)
+	q.mu.Unlock()
+}
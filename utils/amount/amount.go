@@ -0,0 +1,85 @@
+// Package amount convert balances between atomic units (wei, winston) and standard units of currency
+package amount
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/Ja7ad/irys/currency"
+)
+
+const _defaultTokenDecimals = 6 // most of stable coins (usdc, usdt) use 6 decimals
+
+var _decimals = map[currency.CurrencyType]int{
+	currency.ETHEREUM:  18,
+	currency.MATIC:     18,
+	currency.BNB:       18,
+	currency.ARBITRUM:  18,
+	currency.AVALANCHE: 18,
+	currency.FANTOM:    18,
+	currency.BASE:      18,
+	currency.ARWEAVE:   12,
+	currency.ERC20:     _defaultTokenDecimals,
+	currency.APTOS:     8,
+}
+
+// Decimals return number of decimals of currency atomic unit
+func Decimals(c currency.Currency) int {
+	if d, ok := c.(interface{ GetDecimals() int }); ok {
+		return d.GetDecimals()
+	}
+	return _decimals[c.GetType()]
+}
+
+// ToStandard convert atomic unit amount to standard unit (e.g. wei to eth)
+func ToStandard(c currency.Currency, v *big.Int) *big.Float {
+	f := new(big.Float).SetPrec(256).SetInt(v)
+	return f.Quo(f, new(big.Float).SetPrec(256).SetInt(unit(c)))
+}
+
+// FromStandard convert decimal string in standard unit to atomic unit (e.g. "1.5" eth to wei),
+// digits more than currency decimals are truncated.
+func FromStandard(c currency.Currency, v string) (*big.Int, error) {
+	return FromDecimals(v, Decimals(c))
+}
+
+// FromDecimals convert decimal string to atomic unit of decimals (e.g. decimals reported by node)
+func FromDecimals(v string, decimals int) (*big.Int, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(v))
+	if !ok {
+		return nil, fmt.Errorf("invalid amount: %s", v)
+	}
+
+	r.Mul(r, new(big.Rat).SetInt(pow10(decimals)))
+	return new(big.Int).Quo(r.Num(), r.Denom()), nil
+}
+
+// Format return exact decimal string of atomic amount in standard unit with symbol (e.g. "1.5 matic")
+func Format(c currency.Currency, v *big.Int) string {
+	decimals := Decimals(c)
+
+	sign := ""
+	abs := new(big.Int).Set(v)
+	if abs.Sign() < 0 {
+		sign = "-"
+		abs.Neg(abs)
+	}
+
+	q, r := new(big.Int).QuoRem(abs, unit(c), new(big.Int))
+	out := sign + q.String()
+
+	if frac := strings.TrimRight(fmt.Sprintf("%0*s", decimals, r.String()), "0"); len(frac) != 0 && decimals > 0 {
+		out += "." + frac
+	}
+
+	return out + " " + c.GetSymbol()
+}
+
+func unit(c currency.Currency) *big.Int {
+	return pow10(Decimals(c))
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
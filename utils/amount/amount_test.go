@@ -0,0 +1,35 @@
+package amount
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Ja7ad/irys/currency"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvert(t *testing.T) {
+	matic, err := currency.NewWithBackend(currency.MATIC, "f4a2b939592564feb35ab10a8e04f6f2fe0943579fb3c9c33505298978b74893", nil)
+	require.NoError(t, err)
+
+	wei, err := FromStandard(matic, "1.5")
+	require.NoError(t, err)
+	require.Equal(t, "1500000000000000000", wei.String())
+
+	f, _ := ToStandard(matic, wei).Float64()
+	require.Equal(t, 1.5, f)
+
+	require.Equal(t, "1.5 matic", Format(matic, wei))
+	require.Equal(t, "0.000000000000000001 matic", Format(matic, big.NewInt(1)))
+
+	two, err := FromStandard(matic, "2")
+	require.NoError(t, err)
+	require.Equal(t, "2 matic", Format(matic, two))
+
+	_, err = FromStandard(matic, "abc")
+	require.Error(t, err)
+
+	usdc, err := FromDecimals("1.2345678", 6)
+	require.NoError(t, err)
+	require.Equal(t, "1234567", usdc.String())
+}
@@ -1,7 +1,9 @@
 package logger
 
 import (
+	"bytes"
 	"log/slog"
+	"strings"
 	"testing"
 )
 
@@ -20,3 +22,18 @@ func TestHandler_Handle(t *testing.T) {
 
 	logger.Debug("logger initiated")
 }
+
+func TestNewWithHandler(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := NewWithHandler(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelInfo}), 3)
+
+	logger.Debug("filtered")
+	if buf.Len() != 0 {
+		t.Fatal("debug message must be filtered by level")
+	}
+
+	logger.Info("logged")
+	if !strings.Contains(buf.String(), "logged") {
+		t.Fatal("info message not logged")
+	}
+}
@@ -36,10 +36,11 @@ type Log struct {
 }
 
 type Options struct {
-	Development  bool // Development add development details of machine
-	Debug        bool // Debug show debug devel message
-	EnableCaller bool // EnableCaller show caller in line code
-	SkipCaller   int  // SkipCaller skip caller level of CallerFrames https://github.com/golang/go/issues/59145#issuecomment-1481920720
+	Development  bool       // Development add development details of machine
+	Debug        bool       // Debug show debug devel message
+	Level        slog.Level // Level minimum level of log, ignored when Debug is true (default info)
+	EnableCaller bool       // EnableCaller show caller in line code
+	SkipCaller   int        // SkipCaller skip caller level of CallerFrames https://github.com/golang/go/issues/59145#issuecomment-1481920720
 }
 
 type Logger interface {
@@ -67,6 +68,7 @@ func New(
 		return a
 	}
 
+	slogHandlerOpt.Level = loggerOption.Level
 	if loggerOption.Debug {
 		slogHandlerOpt.Level = slog.LevelDebug
 	}
@@ -100,6 +102,14 @@ func New(
 	return log, nil
 }
 
+// NewWithHandler create logger from any slog.Handler for integrate with host application logging
+func NewWithHandler(handler slog.Handler, skipCaller int) Logger {
+	return &Log{
+		skipCaller: skipCaller,
+		slog:       slog.New(handler),
+	}
+}
+
 func (l *Log) Debug(msg string, keyValues ...any) {
 	l.Log(context.Background(), slog.LevelDebug, msg, keyValues...)
 }
@@ -143,6 +153,10 @@ func (l *Log) FatalContext(ctx context.Context, msg string, keyValues ...any) {
 }
 
 func (l *Log) Log(ctx context.Context, level slog.Level, msg string, keyValues ...any) {
+	if !l.slog.Handler().Enabled(ctx, level) {
+		return
+	}
+
 	var pcs [1]uintptr
 	runtime.Callers(l.skipCaller, pcs[:])
 	rec := slog.NewRecord(time.Now(), level, msg, pcs[0])
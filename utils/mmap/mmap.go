@@ -0,0 +1,57 @@
+// Package mmap map file read only to memory for upload of large files without read them to heap,
+// platforms without mmap support read file to memory.
+package mmap
+
+import (
+	"os"
+	"sync"
+)
+
+// Mapping is read only mapped file, data valid until Close
+type Mapping struct {
+	mu   sync.Mutex
+	data []byte
+}
+
+// Open map file of path to memory
+func Open(path string) (*Mapping, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := mapFile(f, info.Size())
+	if err != nil {
+		return nil, err
+	}
+	return &Mapping{data: data}, nil
+}
+
+// Bytes return mapped data, data must not be used after Close
+func (m *Mapping) Bytes() []byte {
+	return m.data
+}
+
+// Len return size of mapped file
+func (m *Mapping) Len() int {
+	return len(m.data)
+}
+
+// Close unmap file, next calls of Close are no-op
+func (m *Mapping) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.data == nil {
+		return nil
+	}
+	data := m.data
+	m.data = nil
+	return unmap(data)
+}
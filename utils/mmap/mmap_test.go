@@ -0,0 +1,30 @@
+package mmap
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data")
+	require.NoError(t, os.WriteFile(path, []byte("hello irys"), 0o600))
+
+	m, err := Open(path)
+	require.NoError(t, err)
+	require.Equal(t, 10, m.Len())
+	require.Equal(t, "hello irys", string(m.Bytes()))
+	require.NoError(t, m.Close())
+	require.NoError(t, m.Close())
+	require.Nil(t, m.Bytes())
+
+	empty := filepath.Join(t.TempDir(), "empty")
+	require.NoError(t, os.WriteFile(empty, nil, 0o600))
+
+	m, err = Open(empty)
+	require.NoError(t, err)
+	require.Equal(t, 0, m.Len())
+	require.NoError(t, m.Close())
+}
@@ -0,0 +1,528 @@
+// Package qrcode encode short content (like gateway urls) to QR code in byte mode with medium error correction
+package qrcode
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+// ErrContentTooLong returned when content not fit in supported versions (1-10)
+var ErrContentTooLong = errors.New("content is too long for qr code")
+
+const (
+	_quietZone     = 4
+	_formatMask    = 0x5412
+	_formatPoly    = 0x537
+	_versionPoly   = 0x1F25
+	_gfPoly        = 0x11D
+	_byteModeBits  = 0x4
+	_maxVersion    = 10
+	_padCodeword1  = 0xEC
+	_padCodeword2  = 0x11
+	_eclMediumBits = 0x0
+)
+
+// blockSpec is error correction blocks of version in medium level
+type blockSpec struct {
+	ecPerBlock int
+	group1     int // group1 number of blocks with data1 data codewords
+	data1      int
+	group2     int // group2 number of blocks with data1+1 data codewords
+}
+
+var _blocks = [_maxVersion + 1]blockSpec{
+	{},
+	{10, 1, 16, 0},
+	{16, 1, 28, 0},
+	{26, 1, 44, 0},
+	{18, 2, 32, 0},
+	{24, 2, 43, 0},
+	{16, 4, 27, 0},
+	{18, 4, 31, 0},
+	{22, 2, 38, 2},
+	{22, 3, 36, 2},
+	{26, 4, 43, 1},
+}
+
+var _alignment = [_maxVersion + 1][]int{
+	{}, {},
+	{6, 18},
+	{6, 22},
+	{6, 26},
+	{6, 30},
+	{6, 34},
+	{6, 22, 38},
+	{6, 24, 42},
+	{6, 26, 46},
+	{6, 28, 50},
+}
+
+func (b blockSpec) dataCodewords() int {
+	return b.group1*b.data1 + b.group2*(b.data1+1)
+}
+
+// QRCode is square matrix of modules, true is dark module
+type QRCode struct {
+	Version int
+	Size    int
+
+	modules  [][]bool
+	function [][]bool
+}
+
+// Encode content in smallest version fit it
+func Encode(content []byte) (*QRCode, error) {
+	for version := 1; version <= _maxVersion; version++ {
+		if len(content) <= capacity(version) {
+			return encode(content, version), nil
+		}
+	}
+	return nil, ErrContentTooLong
+}
+
+// Dark return true if module in column x and row y is dark
+func (q *QRCode) Dark(x, y int) bool {
+	return q.modules[y][x]
+}
+
+// Image render qr code with scale pixels per module and quiet zone
+func (q *QRCode) Image(scale int) image.Image {
+	if scale < 1 {
+		scale = 1
+	}
+
+	size := (q.Size + 2*_quietZone) * scale
+	img := image.NewGray(image.Rect(0, 0, size, size))
+
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			mx, my := x/scale-_quietZone, y/scale-_quietZone
+			if mx >= 0 && my >= 0 && mx < q.Size && my < q.Size && q.modules[my][mx] {
+				img.SetGray(x, y, color.Gray{Y: 0})
+				continue
+			}
+			img.SetGray(x, y, color.Gray{Y: 255})
+		}
+	}
+
+	return img
+}
+
+// PNG render qr code as png image with scale pixels per module
+func (q *QRCode) PNG(scale int) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, q.Image(scale)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func capacity(version int) int {
+	return _blocks[version].dataCodewords() - (4+countBits(version)+7)/8
+}
+
+func countBits(version int) int {
+	if version < 10 {
+		return 8
+	}
+	return 16
+}
+
+func encode(content []byte, version int) *QRCode {
+	size := version*4 + 17
+	q := &QRCode{
+		Version:  version,
+		Size:     size,
+		modules:  make([][]bool, size),
+		function: make([][]bool, size),
+	}
+	for i := range q.modules {
+		q.modules[i] = make([]bool, size)
+		q.function[i] = make([]bool, size)
+	}
+
+	q.drawFunctionPatterns()
+	q.drawCodewords(addErrorCorrection(dataCodewords(content, version), version))
+
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		q.applyMask(mask)
+		q.drawFormatBits(mask)
+		if p := q.penalty(); bestPenalty < 0 || p < bestPenalty {
+			best, bestPenalty = mask, p
+		}
+		q.applyMask(mask)
+	}
+
+	q.applyMask(best)
+	q.drawFormatBits(best)
+
+	return q
+}
+
+func (q *QRCode) set(x, y int, dark bool) {
+	q.modules[y][x] = dark
+	q.function[y][x] = true
+}
+
+func (q *QRCode) drawFunctionPatterns() {
+	for i := 0; i < q.Size; i++ {
+		q.set(6, i, i%2 == 0)
+		q.set(i, 6, i%2 == 0)
+	}
+
+	q.drawFinder(3, 3)
+	q.drawFinder(q.Size-4, 3)
+	q.drawFinder(3, q.Size-4)
+
+	pos := _alignment[q.Version]
+	last := len(pos) - 1
+	for i := range pos {
+		for j := range pos {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			q.drawAlignment(pos[i], pos[j])
+		}
+	}
+
+	// reserve format area, real bits drawn after masking
+	q.drawFormatBits(0)
+	q.drawVersionBits()
+}
+
+func (q *QRCode) drawFinder(cx, cy int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			x, y := cx+dx, cy+dy
+			if x < 0 || y < 0 || x >= q.Size || y >= q.Size {
+				continue
+			}
+			dist := maxAbs(dx, dy)
+			q.set(x, y, dist != 2 && dist != 4)
+		}
+	}
+}
+
+func (q *QRCode) drawAlignment(cx, cy int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			q.set(cx+dx, cy+dy, maxAbs(dx, dy) != 1)
+		}
+	}
+}
+
+func (q *QRCode) drawFormatBits(mask int) {
+	bits := formatBits(mask)
+
+	for i := 0; i <= 5; i++ {
+		q.set(8, i, bit(bits, i))
+	}
+	q.set(8, 7, bit(bits, 6))
+	q.set(8, 8, bit(bits, 7))
+	q.set(7, 8, bit(bits, 8))
+	for i := 9; i < 15; i++ {
+		q.set(14-i, 8, bit(bits, i))
+	}
+
+	for i := 0; i < 8; i++ {
+		q.set(q.Size-1-i, 8, bit(bits, i))
+	}
+	for i := 8; i < 15; i++ {
+		q.set(8, q.Size-15+i, bit(bits, i))
+	}
+	q.set(8, q.Size-8, true)
+}
+
+func (q *QRCode) drawVersionBits() {
+	if q.Version < 7 {
+		return
+	}
+
+	bits := versionBits(q.Version)
+	for i := 0; i < 18; i++ {
+		a, b := q.Size-11+i%3, i/3
+		q.set(a, b, bit(bits, i))
+		q.set(b, a, bit(bits, i))
+	}
+}
+
+// drawCodewords place codewords in zigzag order from bottom right, skipping function modules
+func (q *QRCode) drawCodewords(data []byte) {
+	i := 0
+	for right := q.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < q.Size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = q.Size - 1 - vert
+				}
+				if q.function[y][x] || i >= len(data)*8 {
+					continue
+				}
+				q.modules[y][x] = bit(int(data[i>>3]), 7-i&7)
+				i++
+			}
+		}
+	}
+}
+
+func (q *QRCode) applyMask(mask int) {
+	for y := 0; y < q.Size; y++ {
+		for x := 0; x < q.Size; x++ {
+			if !q.function[y][x] && masked(mask, x, y) {
+				q.modules[y][x] = !q.modules[y][x]
+			}
+		}
+	}
+}
+
+func masked(mask, x, y int) bool {
+	switch mask {
+	case 0:
+		return (x+y)%2 == 0
+	case 1:
+		return y%2 == 0
+	case 2:
+		return x%3 == 0
+	case 3:
+		return (x+y)%3 == 0
+	case 4:
+		return (x/3+y/2)%2 == 0
+	case 5:
+		return x*y%2+x*y%3 == 0
+	case 6:
+		return (x*y%2+x*y%3)%2 == 0
+	default:
+		return ((x+y)%2+x*y%3)%2 == 0
+	}
+}
+
+// penalty score modules by rules of spec, lower score is easier to scan
+func (q *QRCode) penalty() int {
+	score := 0
+	at := func(x, y int, vertical bool) bool {
+		if vertical {
+			return q.modules[x][y]
+		}
+		return q.modules[y][x]
+	}
+
+	finderLike := [][]bool{
+		{true, false, true, true, true, false, true, false, false, false, false},
+		{false, false, false, false, true, false, true, true, true, false, true},
+	}
+
+	for _, vertical := range []bool{false, true} {
+		for y := 0; y < q.Size; y++ {
+			run := 1
+			for x := 1; x <= q.Size; x++ {
+				if x < q.Size && at(x, y, vertical) == at(x-1, y, vertical) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					score += 3 + run - 5
+				}
+				run = 1
+			}
+
+			for x := 0; x+11 <= q.Size; x++ {
+				for _, pattern := range finderLike {
+					match := true
+					for k, dark := range pattern {
+						if at(x+k, y, vertical) != dark {
+							match = false
+							break
+						}
+					}
+					if match {
+						score += 40
+					}
+				}
+			}
+		}
+	}
+
+	dark := 0
+	for y := 0; y < q.Size; y++ {
+		for x := 0; x < q.Size; x++ {
+			if q.modules[y][x] {
+				dark++
+			}
+			if x+1 < q.Size && y+1 < q.Size {
+				c := q.modules[y][x]
+				if c == q.modules[y][x+1] && c == q.modules[y+1][x] && c == q.modules[y+1][x+1] {
+					score += 3
+				}
+			}
+		}
+	}
+
+	total := q.Size * q.Size
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	if k > 0 {
+		score += k * 10
+	}
+
+	return score
+}
+
+// dataCodewords encode content in byte mode with terminator and pad codewords
+func dataCodewords(content []byte, version int) []byte {
+	var w bitWriter
+	w.write(_byteModeBits, 4)
+	w.write(len(content), countBits(version))
+	for _, b := range content {
+		w.write(int(b), 8)
+	}
+
+	capacityBits := _blocks[version].dataCodewords() * 8
+	terminator := capacityBits - w.n
+	if terminator > 4 {
+		terminator = 4
+	}
+	w.write(0, terminator)
+	w.write(0, (8-w.n%8)%8)
+
+	for pad := _padCodeword1; w.n < capacityBits; pad ^= _padCodeword1 ^ _padCodeword2 {
+		w.write(pad, 8)
+	}
+
+	return w.buf
+}
+
+// addErrorCorrection split data to blocks, compute reed-solomon codewords and interleave them
+func addErrorCorrection(data []byte, version int) []byte {
+	spec := _blocks[version]
+	divisor := rsDivisor(spec.ecPerBlock)
+
+	var blocks, ecs [][]byte
+	offset := 0
+	for i := 0; i < spec.group1+spec.group2; i++ {
+		n := spec.data1
+		if i >= spec.group1 {
+			n++
+		}
+		block := data[offset : offset+n]
+		offset += n
+
+		blocks = append(blocks, block)
+		ecs = append(ecs, rsRemainder(block, divisor))
+	}
+
+	result := make([]byte, 0, len(data)+len(blocks)*spec.ecPerBlock)
+	for i := 0; i <= spec.data1; i++ {
+		for _, block := range blocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+	for i := 0; i < spec.ecPerBlock; i++ {
+		for _, ec := range ecs {
+			result = append(result, ec[i])
+		}
+	}
+
+	return result
+}
+
+func rsDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = gfMultiply(result[j], root)
+			if j+1 < degree {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+
+	return result
+}
+
+func rsRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, coef := range divisor {
+			result[i] ^= gfMultiply(coef, factor)
+		}
+	}
+	return result
+}
+
+func gfMultiply(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * _gfPoly)
+		z ^= int((y>>i)&1) * int(x)
+	}
+	return byte(z)
+}
+
+func formatBits(mask int) int {
+	data := _eclMediumBits<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * _formatPoly)
+	}
+	return (data<<10 | rem) ^ _formatMask
+}
+
+func versionBits(version int) int {
+	rem := version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * _versionPoly)
+	}
+	return version<<12 | rem
+}
+
+func bit(x, i int) bool {
+	return (x>>i)&1 != 0
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func maxAbs(x, y int) int {
+	if abs(x) > abs(y) {
+		return abs(x)
+	}
+	return abs(y)
+}
+
+type bitWriter struct {
+	buf []byte
+	n   int
+}
+
+func (w *bitWriter) write(value, length int) {
+	for i := length - 1; i >= 0; i-- {
+		if w.n%8 == 0 {
+			w.buf = append(w.buf, 0)
+		}
+		if bit(value, i) {
+			w.buf[len(w.buf)-1] |= 1 << (7 - w.n%8)
+		}
+		w.n++
+	}
+}
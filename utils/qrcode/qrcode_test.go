@@ -0,0 +1,58 @@
+package qrcode
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReedSolomon(t *testing.T) {
+	// HELLO WORLD in version 1-M from qr code specification example
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	require.Equal(t, []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}, rsRemainder(data, rsDivisor(10)))
+}
+
+func TestFormatAndVersionBits(t *testing.T) {
+	require.Equal(t, "101010000010010", fmt.Sprintf("%015b", formatBits(0)))
+	require.Equal(t, "100000011001110", fmt.Sprintf("%015b", formatBits(5)))
+	require.Equal(t, "100101010100000", fmt.Sprintf("%015b", formatBits(7)))
+	require.Equal(t, "000111110010010100", fmt.Sprintf("%018b", versionBits(7)))
+}
+
+func TestDataCodewords(t *testing.T) {
+	got := dataCodewords([]byte("hi"), 1)
+	require.Len(t, got, 16)
+	require.Equal(t, []byte{0x40, 0x26, 0x86, 0x90, 0xEC, 0x11}, got[:6])
+}
+
+func TestEncode(t *testing.T) {
+	q, err := Encode([]byte("https://gateway.irys.xyz/0bC3b3w4jX6cu-Qq4ExX0bZ9Tg2gKGdi3VtYO4eEc6I"))
+	require.NoError(t, err)
+	require.Equal(t, 5, q.Version)
+	require.Equal(t, 37, q.Size)
+
+	// finder pattern corners and dark module
+	require.True(t, q.Dark(0, 0))
+	require.True(t, q.Dark(q.Size-1, 0))
+	require.True(t, q.Dark(0, q.Size-1))
+	require.True(t, q.Dark(8, q.Size-8))
+
+	b, err := q.PNG(4)
+	require.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(b))
+	require.NoError(t, err)
+	require.Equal(t, (q.Size+2*_quietZone)*4, img.Bounds().Dx())
+
+	for version := 1; version <= _maxVersion; version++ {
+		q, err := Encode(make([]byte, capacity(version)))
+		require.NoError(t, err)
+		require.Equal(t, version, q.Version)
+	}
+
+	_, err = Encode(make([]byte, capacity(_maxVersion)+1))
+	require.ErrorIs(t, err, ErrContentTooLong)
+}
@@ -0,0 +1,94 @@
+package irys
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/types"
+)
+
+const _defaultVerifyConcurrency = 10
+
+func (c *Client) VerifyReceipts(ctx context.Context, txIds <-chan string, concurrency int, onResult func(types.VerifyResult)) (types.VerifySummary, error) {
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		summary types.VerifySummary
+	)
+
+	if c.receiptVerifier == nil {
+		return summary, errors.ErrReceiptVerifierNotConfigured
+	}
+
+	if concurrency <= 0 {
+		concurrency = _defaultVerifyConcurrency
+	}
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case txId, ok := <-txIds:
+					if !ok {
+						return
+					}
+
+					result := c.verifyReceipt(ctx, txId)
+
+					mu.Lock()
+					summary.Total++
+					switch {
+					case result.Err != nil:
+						summary.Failed++
+					case !result.Exists:
+						summary.Missing++
+					case result.Valid:
+						summary.Valid++
+					default:
+						summary.Invalid++
+					}
+					if onResult != nil {
+						onResult(result)
+					}
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	c.debugMsg("[VerifyReceipts] verified %d receipts, %d valid", summary.Total, summary.Valid)
+
+	return summary, ctx.Err()
+}
+
+func (c *Client) verifyReceipt(ctx context.Context, txId string) types.VerifyResult {
+	result := types.VerifyResult{TxId: txId}
+
+	receipt, err := c.GetReceipt(ctx, txId)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	result.Receipt = receipt
+	result.Exists = len(receipt.Signature) != 0
+	if !result.Exists {
+		return result
+	}
+
+	// id signed by node is id of transaction, not selected by receipt fields
+	receipt.ID = txId
+	if err := receipt.Verify(c.receiptVerifier); err != nil {
+		c.debugMsg("[VerifyReceipts] receipt of %s is invalid: %v", txId, err)
+		return result
+	}
+	result.Valid = true
+
+	return result
+}
@@ -0,0 +1,79 @@
+package irys
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/fixtures"
+	"github.com/Ja7ad/irys/types"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyReceipts(t *testing.T) {
+	node, err := fixtures.Signer()
+	require.NoError(t, err)
+	other, err := fixtures.Signer()
+	require.NoError(t, err)
+
+	valid, err := fixtures.Receipt(node, "valid", 100, time.UnixMilli(1700000000000))
+	require.NoError(t, err)
+	forged, err := fixtures.Receipt(other, "forged", 100, time.UnixMilli(1700000000000))
+	require.NoError(t, err)
+	// receipt of other transaction replayed for id
+	replayed, err := fixtures.Receipt(node, "valid", 100, time.UnixMilli(1700000000000))
+	require.NoError(t, err)
+
+	receipts := map[string]types.Receipt{"valid": valid, "forged": forged, "replayed": replayed}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Variables struct {
+				Ids []string `json:"ids"`
+			} `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		var nodes []types.TransactionNode
+		for _, id := range request.Variables.Ids {
+			if receipt, ok := receipts[id]; ok {
+				nodes = append(nodes, types.TransactionNode{ID: id, Receipt: receipt})
+			}
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(fixtures.Transactions(false, nodes...)))
+	}))
+	defer srv.Close()
+
+	c := &Client{client: retryablehttp.NewClient(), network: Node(srv.URL)}
+	c.client.RetryMax = 0
+
+	ids := func() <-chan string {
+		ch := make(chan string, 4)
+		for _, id := range []string{"valid", "forged", "replayed", "missing"} {
+			ch <- id
+		}
+		close(ch)
+		return ch
+	}
+
+	_, err = c.VerifyReceipts(context.Background(), ids(), 2, nil)
+	require.ErrorIs(t, err, errs.ErrReceiptVerifierNotConfigured)
+
+	WithReceiptVerifier(node)(c)
+
+	results := make(map[string]types.VerifyResult)
+	summary, err := c.VerifyReceipts(context.Background(), ids(), 2, func(result types.VerifyResult) {
+		results[result.TxId] = result
+	})
+	require.NoError(t, err)
+	require.Equal(t, types.VerifySummary{Total: 4, Valid: 1, Invalid: 2, Missing: 1}, summary)
+	require.True(t, results["valid"].Valid)
+	require.True(t, results["forged"].Exists)
+	require.False(t, results["forged"].Valid)
+	require.False(t, results["replayed"].Valid)
+	require.False(t, results["missing"].Exists)
+}
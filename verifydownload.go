@@ -0,0 +1,79 @@
+package irys
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/signer"
+	"github.com/Ja7ad/irys/types"
+)
+
+// VerifyDownload download data and rebuild data item from it with transaction metadata, then check id and
+// owner signature over deep hash, so tampered or corrupted content of gateway detected. Data returned when valid.
+func (c *Client) VerifyDownload(ctx context.Context, txId string) ([]byte, error) {
+	tx, err := c.GetMetaData(ctx, txId)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := c.download(ctx, txId)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Data.Close()
+
+	data, err := io.ReadAll(file.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := bundleItemOf(tx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := item.Verify(); err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrDownloadVerification, err)
+	}
+
+	if err := item.VerifySignature(); err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrDownloadVerification, err)
+	}
+
+	return data, nil
+}
+
+// bundleItemOf rebuild data item from transaction metadata (base64url fields) and data
+func bundleItemOf(tx types.Transaction, data []byte) (*types.BundleItem, error) {
+	item := &types.BundleItem{Data: data}
+
+	for _, f := range []struct {
+		dst *types.Base64String
+		src string
+	}{
+		{&item.Id, tx.ID},
+		{&item.Owner, tx.Owner},
+		{&item.Signature, tx.Signature},
+		{&item.Target, tx.Target},
+		{&item.Anchor, tx.Anchor},
+	} {
+		if err := f.dst.Decode(f.src); err != nil {
+			return nil, err
+		}
+	}
+
+	tags, err := tx.DecodedTags()
+	if err != nil {
+		return nil, err
+	}
+	item.Tags = tags
+
+	item.SignatureType, err = signer.TypeByOwner(item.Owner)
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
@@ -0,0 +1,54 @@
+package irys
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	errs "github.com/Ja7ad/irys/errors"
+	"github.com/Ja7ad/irys/signer"
+	"github.com/Ja7ad/irys/types"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyDownload(t *testing.T) {
+	s, err := signer.NewEthereumSigner("0xf4a2b939592564feb35ab10a8e04f6f2fe0943579fb3c9c33505298978b74893")
+	require.NoError(t, err)
+
+	item, err := signItem([]byte("hello irys"), s, false, types.Tag{Name: "App-Name", Value: "irys"})
+	require.NoError(t, err)
+
+	encode := base64.RawURLEncoding.EncodeToString
+	tx := types.Transaction{
+		ID:        item.Id.Base64(),
+		Owner:     item.Owner.Base64(),
+		Signature: item.Signature.Base64(),
+	}
+	for _, tag := range item.Tags {
+		tx.Tags = append(tx.Tags, types.Tag{Name: encode([]byte(tag.Name)), Value: encode([]byte(tag.Value))})
+	}
+
+	data := "hello irys"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/tx/"+tx.ID {
+			_ = json.NewEncoder(w).Encode(tx)
+			return
+		}
+		_, _ = w.Write([]byte(data))
+	}))
+	defer srv.Close()
+
+	c := &Client{client: retryablehttp.NewClient(), gateway: srv.URL}
+
+	b, err := c.VerifyDownload(context.Background(), tx.ID)
+	require.NoError(t, err)
+	require.Equal(t, "hello irys", string(b))
+
+	data = "tampered"
+	_, err = c.VerifyDownload(context.Background(), tx.ID)
+	require.ErrorIs(t, err, errs.ErrDownloadVerification)
+}